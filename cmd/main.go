@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -19,27 +20,32 @@ import (
 	"avito-shop-test/config"
 	"avito-shop-test/internal/adapter"
 	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/hasher"
 	"avito-shop-test/internal/middleware"
+	"avito-shop-test/internal/oidc"
 	"avito-shop-test/internal/repository"
+	"avito-shop-test/internal/scheduler"
 	"avito-shop-test/internal/token"
 	"avito-shop-test/internal/usecase"
 )
 
 func main() {
+	backfillAchievements := flag.Bool("backfill-achievements", false, "выдать достижения по текущим правилам всем пользователям и завершить работу")
+	flag.Parse()
+
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatalf("Ошибка загрузки файла .env: %v", err)
 	}
 	dbCongig := config.DBConfig()
 
-	db, err := gorm.Open(postgres.Open(dbCongig), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dbCongig), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Ошибка подключения к базе данных:", err)
 	}
 	log.Println("Подключение к базе данных успешно!!!")
 
 	serverAddress := os.Getenv("SERVER_ADDRESS")
-	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
 
 	sqlDB, _ := db.DB()
 	sqlDB.SetMaxIdleConns(100)
@@ -51,24 +57,176 @@ func main() {
 	}
 
 	storeRepo := repository.NewStoreRepository(db)
+	variantRepo := repository.NewVariantRepository(db)
+	pricingRepo := repository.NewPricingRepository(db)
 
 	userRepo := repository.NewUSerRepository(db)
+	staffIDs, err := userRepo.GetUserIDs(config.StaffUsernames())
+	if err != nil {
+		log.Fatalf("Ошибка загрузки сотрудников: %v", err)
+	}
+
+	achievementRules, err := usecase.LoadAchievementRules(config.AchievementsFile())
+	if err != nil {
+		log.Fatalf("Ошибка загрузки правил достижений: %v", err)
+	}
+	achievementRepo := repository.NewAchievementRepository(db)
+	achievementUC := usecase.NewAchievementUseCase(achievementRepo, userRepo, achievementRules)
+
+	if *backfillAchievements {
+		if err := achievementUC.Backfill(); err != nil {
+			log.Fatalf("Ошибка выдачи достижений: %v", err)
+		}
+		log.Println("Достижения выданы по текущим правилам")
+		return
+	}
 
 	transactionRepo := repository.NewCoinTransactionRepository(db)
-	transactionUC := usecase.NewCoinTransactionUseCase(transactionRepo, userRepo)
+	transactionUC := usecase.NewCoinTransactionUseCase(transactionRepo, userRepo, achievementUC)
 
 	purchaseRepo := repository.NewPurchaseRepository(db)
-	purchaseUC := usecase.NewPurchaseUseCase(purchaseRepo, userRepo, storeRepo)
+	purchaseUC := usecase.NewPurchaseUseCase(purchaseRepo, userRepo, storeRepo, variantRepo, pricingRepo, achievementUC)
+
+	variantUC := usecase.NewVariantUseCase(variantRepo, storeRepo, userRepo, staffIDs)
+
+	pricingUC := usecase.NewPricingUseCase(pricingRepo, storeRepo, variantRepo, userRepo, staffIDs)
+
+	ruleRepo := repository.NewRuleRepository(db)
+	ruleUC := usecase.NewRuleUseCase(ruleRepo, storeRepo, userRepo, staffIDs)
+
+	giftRepo := repository.NewGiftRepository(db)
+	giftUC := usecase.NewGiftUseCase(giftRepo, userRepo, storeRepo, variantRepo, pricingRepo, purchaseRepo, achievementUC)
+
+	wishlistRepo := repository.NewWishlistRepository(db)
+	wishlistUC := usecase.NewWishlistUseCase(wishlistRepo, userRepo, storeRepo, variantRepo, pricingRepo, purchaseRepo, achievementUC)
+
+	marketRepo := repository.NewMarketRepository(db)
+	marketUC := usecase.NewMarketUseCase(marketRepo, userRepo)
+
+	tradeRepo := repository.NewTradeRepository(db)
+	tradeUC := usecase.NewTradeUseCase(tradeRepo, userRepo)
+
+	auctionRepo := repository.NewAuctionRepository(db)
+	auctionUC := usecase.NewAuctionUseCase(auctionRepo, userRepo, storeRepo, variantRepo, staffIDs, achievementUC)
+
+	raffleRepo := repository.NewRaffleRepository(db)
+	raffleUC := usecase.NewRaffleUseCase(raffleRepo, userRepo, storeRepo, variantRepo, staffIDs, achievementUC)
+
+	bountyRepo := repository.NewBountyRepository(db)
+	bountyUC := usecase.NewBountyUseCase(bountyRepo, userRepo, achievementUC)
+
+	kudosRepo := repository.NewKudosRepository(db)
+	kudosUC := usecase.NewKudosUseCase(kudosRepo, userRepo)
 
-	userUC := usecase.NewUserUsecase(userRepo, purchaseRepo, transactionRepo, token.NewGenerator(jwtSecret))
+	leaderboardRepo := repository.NewLeaderboardRepository(db)
+	leaderboardUC := usecase.NewLeaderboardUseCase(leaderboardRepo)
+
+	budgetRepo := repository.NewBudgetRepository(db)
+	budgetUC := usecase.NewBudgetUseCase(budgetRepo, userRepo, staffIDs, achievementUC)
+
+	returnRepo := repository.NewReturnRepository(db)
+	returnUC := usecase.NewReturnUseCase(returnRepo, purchaseRepo, userRepo, storeRepo, config.ReturnWindow(), staffIDs)
+
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	if config.LoginThrottleStore() == "memory" {
+		loginAttemptRepo = repository.NewMemoryLoginAttemptRepository()
+	}
+	loginThrottle := usecase.NewLoginThrottle(loginAttemptRepo, config.LoginMaxFailures(), config.LoginIPMaxFailures(), config.LoginLockout())
+
+	adminRepo := repository.NewAdminRepository(db)
+	adminUC := usecase.NewAdminUseCase(adminRepo, userRepo, loginThrottle, config.StaffUsernames(), config.AdminUsernames())
+	if err := adminUC.BootstrapRoles(); err != nil {
+		log.Printf("Не удалось назначить роли из конфигурации: %v", err)
+	}
+
+	signingKeyRepo := repository.NewSigningKeyRepository(db)
+	keyring, err := token.NewKeyring(signingKeyRepo, config.JWTSigningAlgorithm(), config.SigningKeyRotation(), config.AccessTokenTTL())
+	if err != nil {
+		log.Fatalf("Ошибка настройки подписи токенов: %v", err)
+	}
+	if err := keyring.Refresh(); err != nil {
+		log.Fatalf("Ошибка загрузки ключей подписи: %v", err)
+	}
+
+	tokenRepo := repository.NewTokenRepository(db)
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	twoFactorUC := usecase.NewTwoFactorUseCase(twoFactorRepo, userRepo, tokenRepo, config.TOTPIssuer())
+	tokenGenerator := &token.Generator{Keys: keyring, Issuer: config.JWTIssuer(), Audience: config.JWTAudience(), TTL: config.AccessTokenTTL()}
+	tokenVerifier := &token.Verifier{Keys: keyring, Issuer: config.JWTIssuer(), Audience: config.JWTAudience()}
+	userUC := usecase.NewUserUsecase(userRepo, purchaseRepo, transactionRepo, tokenRepo, tokenGenerator, hasher.NewBcrypt(config.BcryptCost()), config.AutoSignup(), loginThrottle, twoFactorRepo)
+
+	var ssoUC usecase.SSOUseCase
+	if issuer := config.OIDCIssuer(); issuer != "" {
+		provider := oidc.NewClient(oidc.Config{
+			Issuer:       issuer,
+			ClientID:     config.OIDCClientID(),
+			ClientSecret: config.OIDCClientSecret(),
+			RedirectURL:  config.OIDCRedirectURL(),
+			Scopes:       config.OIDCScopes(),
+		}, nil)
+		ssoUC = usecase.NewSSOUseCase(provider, repository.NewIdentityRepository(db), userRepo, userUC, hasher.NewBcrypt(config.BcryptCost()))
+	}
+
+	serviceAccountRepo := repository.NewServiceAccountRepository(db)
+	serviceAccountUC := usecase.NewServiceAccountUseCase(serviceAccountRepo, userRepo)
+	serviceAuth := middleware.APIKeyMiddleware(serviceAccountUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	authz := middleware.NewAuthorizer()
 
 	router := gin.Default()
+	if err := router.SetTrustedProxies(config.TrustedProxies()); err != nil {
+		log.Fatalf("Ошибка настройки доверенных прокси: %v", err)
+	}
 	apiGroup := router.Group("/api")
 	ginRouter := adapter.NewGinRouter(apiGroup)
 
-	handler.NewUserHandler(ginRouter, userUC, middleware.AuthMiddleware(jwtSecret))
-	handler.NewCoinTransactionHandler(ginRouter, transactionUC, middleware.AuthMiddleware(jwtSecret))
-	handler.NewPurchaseHandler(ginRouter, purchaseUC, middleware.AuthMiddleware(jwtSecret))
+	handler.NewJWKSHandler(adapter.NewGinRouter(&router.RouterGroup), keyring)
+
+	handler.NewUserHandler(ginRouter, userUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewTwoFactorHandler(ginRouter, twoFactorUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewSessionHandler(ginRouter, userUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	if ssoUC != nil {
+		handler.NewSSOHandler(ginRouter, ssoUC)
+	}
+	handler.NewCoinTransactionHandler(ginRouter, transactionUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewPurchaseHandler(ginRouter, purchaseUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewReturnHandler(ginRouter, returnUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewVariantHandler(ginRouter, variantUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewGiftHandler(ginRouter, giftUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewRuleHandler(ginRouter, ruleUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewPricingHandler(ginRouter, pricingUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewWishlistHandler(ginRouter, wishlistUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewMarketHandler(ginRouter, marketUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewTradeHandler(ginRouter, tradeUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewAuctionHandler(ginRouter, auctionUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewRaffleHandler(ginRouter, raffleUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewBountyHandler(ginRouter, bountyUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewKudosHandler(ginRouter, kudosUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewLeaderboardHandler(ginRouter, leaderboardUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewAchievementHandler(ginRouter, achievementUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewBudgetHandler(ginRouter, budgetUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo))
+	handler.NewAdminHandler(ginRouter, adminUC, serviceAuth, authz)
+	handler.NewServiceAccountHandler(ginRouter, serviceAccountUC, middleware.AuthMiddleware(tokenVerifier, tokenRepo), authz)
+	handler.NewCatalogHandler(ginRouter, pricingUC, serviceAuth, authz)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	scheduler.Every(jobsCtx, "wishlist-expiry", time.Minute, wishlistUC.ExpireCampaigns)
+	scheduler.Every(jobsCtx, "market-expiry", time.Minute, marketUC.ExpireListings)
+	scheduler.Every(jobsCtx, "auction-close", time.Minute, auctionUC.CloseAuctions)
+	scheduler.Every(jobsCtx, "raffle-draw", time.Minute, raffleUC.DrawRaffles)
+	scheduler.Every(jobsCtx, "bounty-expiry", time.Minute, bountyUC.ExpireBounties)
+	scheduler.Every(jobsCtx, "budget-refill", time.Minute, budgetUC.RefillBudgets)
+	scheduler.Every(jobsCtx, "token-cleanup", time.Hour, userUC.PurgeExpiredTokens)
+	scheduler.Every(jobsCtx, "signing-keys", time.Minute, keyring.Refresh)
+	scheduler.Every(jobsCtx, "login-attempts-cleanup", time.Hour, loginThrottle.PurgeStale)
+	scheduler.Every(jobsCtx, "mfa-challenge-cleanup", time.Hour, twoFactorUC.PurgeExpiredChallenges)
+	if ssoUC != nil {
+		scheduler.Every(jobsCtx, "sso-state-cleanup", time.Hour, ssoUC.PurgeExpiredStates)
+	}
+	if err := leaderboardUC.RefreshLeaderboards(); err != nil {
+		log.Printf("Не удалось обновить рейтинги: %v", err)
+	}
+	scheduler.Every(jobsCtx, "leaderboard-refresh", config.LeaderboardRefreshInterval(), leaderboardUC.RefreshLeaderboards)
 
 	srv := &http.Server{
 		Addr:    serverAddress,
@@ -87,6 +245,7 @@ func main() {
 	<-c
 
 	log.Println("Завершение работы сервера...")
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
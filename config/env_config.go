@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func getEnv(key, def string) string {
@@ -57,4 +60,139 @@ func jdbcToGormConfig(jdbcURL string) (string, error) {
 	password := matches[5]
 
 	return fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=disable", user, password, dbname, host, port), nil
-}
\ No newline at end of file
+}
+
+func ReturnWindow() time.Duration {
+	hours, err := strconv.Atoi(getEnv("RETURN_WINDOW_HOURS", "336"))
+	if err != nil || hours <= 0 {
+		hours = 336
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func LeaderboardRefreshInterval() time.Duration {
+	minutes, err := strconv.Atoi(getEnv("LEADERBOARD_REFRESH_MINUTES", "5"))
+	if err != nil || minutes <= 0 {
+		minutes = 5
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func BcryptCost() int {
+	cost, err := strconv.Atoi(getEnv("BCRYPT_COST", "10"))
+	if err != nil {
+		cost = 10
+	}
+	return cost
+}
+
+func AccessTokenTTL() time.Duration {
+	minutes, err := strconv.Atoi(getEnv("ACCESS_TOKEN_TTL_MINUTES", "15"))
+	if err != nil || minutes <= 0 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func JWTSigningAlgorithm() string {
+	return getEnv("JWT_SIGNING_ALG", "RS256")
+}
+
+func JWTIssuer() string {
+	return getEnv("JWT_ISSUER", "avito-shop")
+}
+
+func JWTAudience() string {
+	return getEnv("JWT_AUDIENCE", "avito-shop-api")
+}
+
+func SigningKeyRotation() time.Duration {
+	hours, err := strconv.Atoi(getEnv("JWT_KEY_ROTATION_HOURS", "720"))
+	if err != nil || hours <= 0 {
+		hours = 720
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func LoginThrottleStore() string {
+	return getEnv("LOGIN_THROTTLE_STORE", "db")
+}
+
+func LoginMaxFailures() int {
+	failures, err := strconv.Atoi(getEnv("LOGIN_MAX_FAILURES", "10"))
+	if err != nil || failures <= 0 {
+		failures = 10
+	}
+	return failures
+}
+
+func LoginIPMaxFailures() int {
+	failures, err := strconv.Atoi(getEnv("LOGIN_IP_MAX_FAILURES", "100"))
+	if err != nil || failures <= 0 {
+		failures = 100
+	}
+	return failures
+}
+
+func LoginLockout() time.Duration {
+	minutes, err := strconv.Atoi(getEnv("LOGIN_LOCKOUT_MINUTES", "15"))
+	if err != nil || minutes <= 0 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func TOTPIssuer() string {
+	return getEnv("TOTP_ISSUER", "Avito Shop")
+}
+
+func TrustedProxies() []string {
+	return commaList("TRUSTED_PROXIES")
+}
+
+func OIDCIssuer() string {
+	return getEnv("OIDC_ISSUER", "")
+}
+
+func OIDCClientID() string {
+	return getEnv("OIDC_CLIENT_ID", "")
+}
+
+func OIDCClientSecret() string {
+	return getEnv("OIDC_CLIENT_SECRET", "")
+}
+
+func OIDCRedirectURL() string {
+	return getEnv("OIDC_REDIRECT_URL", "")
+}
+
+func OIDCScopes() []string {
+	return strings.Fields(getEnv("OIDC_SCOPES", "openid email profile"))
+}
+
+func AutoSignup() bool {
+	enabled, err := strconv.ParseBool(getEnv("AUTH_AUTO_SIGNUP", "false"))
+	return err == nil && enabled
+}
+
+func StaffUsernames() []string {
+	return commaList("STAFF_USERNAMES")
+}
+
+func AdminUsernames() []string {
+	return commaList("ADMIN_USERNAMES")
+}
+
+func commaList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func AchievementsFile() string {
+	return getEnv("ACHIEVEMENTS_FILE", "config/achievements.json")
+}
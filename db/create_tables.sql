@@ -5,6 +5,16 @@ CREATE TABLE IF NOT EXISTS users (
     username VARCHAR(255) UNIQUE NOT NULL,
     password VARCHAR(255) NOT NULL,
     balance INT DEFAULT 1000,
+    role VARCHAR(20) NOT NULL DEFAULT 'employee',
+    email VARCHAR(255),
+    department VARCHAR(100),
+    hired_at TIMESTAMP,
+    public_kudos BOOLEAN NOT NULL DEFAULT false,
+    hide_from_leaderboards BOOLEAN NOT NULL DEFAULT false,
+    service_account BOOLEAN NOT NULL DEFAULT false,
+    totp_secret VARCHAR(64) NOT NULL DEFAULT '',
+    totp_enabled BOOLEAN NOT NULL DEFAULT false,
+    totp_last_step BIGINT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -13,6 +23,11 @@ CREATE TABLE IF NOT EXISTS inventory (
     user_id UUID NOT NULL,
     item_type VARCHAR(50) NOT NULL,
     quantity INT DEFAULT 1,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    price INT,
+    no_return BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
@@ -21,6 +36,10 @@ CREATE TABLE IF NOT EXISTS transactions (
     from_user_id UUID NOT NULL,
     to_user_id UUID NOT NULL,
     amount INT NOT NULL,
+    message TEXT,
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    budget_id UUID,
+    reversal_of UUID UNIQUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
     FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE
@@ -30,6 +49,461 @@ CREATE TABLE IF NOT EXISTS items (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     name VARCHAR(255) UNIQUE NOT NULL,
     price INT NOT NULL,
+    has_variants BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS item_rules (
+    item_name VARCHAR(255) PRIMARY KEY,
+    max_per_user INT,
+    max_per_period INT,
+    period_days INT,
+    min_tenure_months INT,
+    allowed_departments TEXT
+);
+
+CREATE TABLE IF NOT EXISTS price_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_name VARCHAR(255) NOT NULL,
+    price INT NOT NULL CHECK (price > 0),
+    effective_at TIMESTAMP NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_changes_item_idx ON price_changes (item_name, effective_at);
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    item_name VARCHAR(255),
+    percent_off INT NOT NULL CHECK (percent_off > 0 AND percent_off <= 100),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    created_by UUID
+);
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    code VARCHAR(50) PRIMARY KEY,
+    item_name VARCHAR(255),
+    percent_off INT NOT NULL CHECK (percent_off > 0 AND percent_off <= 100),
+    max_uses INT NOT NULL DEFAULT 1,
+    uses INT NOT NULL DEFAULT 0,
+    user_id UUID,
+    expires_at TIMESTAMP,
+    created_by UUID,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_redemptions (
+    code VARCHAR(50) NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (code) REFERENCES promo_codes(code) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS item_options (
+    item_name VARCHAR(255) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    value VARCHAR(50) NOT NULL,
+    PRIMARY KEY (item_name, name, value)
+);
+
+CREATE TABLE IF NOT EXISTS item_skus (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(100) UNIQUE NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    stock INT NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    price INT
+);
+
+CREATE TABLE IF NOT EXISTS item_sku_options (
+    sku_id UUID NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    value VARCHAR(50) NOT NULL,
+    PRIMARY KEY (sku_id, name),
+    FOREIGN KEY (sku_id) REFERENCES item_skus(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS returns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    inventory_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    item_type VARCHAR(50) NOT NULL,
+    quantity INT NOT NULL,
+    amount INT NOT NULL,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by UUID,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS returns_active_inventory_idx
+    ON returns (inventory_id) WHERE status IN ('pending', 'approved');
+
+CREATE TABLE IF NOT EXISTS gifts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    inventory_id UUID NOT NULL,
+    item_type VARCHAR(50) NOT NULL,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    amount INT NOT NULL,
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'delivered',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    declined_at TIMESTAMP,
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS wishlists (
+    user_id UUID PRIMARY KEY,
+    share_token VARCHAR(64) UNIQUE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    sku VARCHAR(100),
+    target INT NOT NULL DEFAULT 0,
+    funded INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'wished',
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS contributions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wishlist_item_id UUID NOT NULL,
+    contributor_id UUID NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (wishlist_item_id) REFERENCES wishlist_items(id) ON DELETE CASCADE,
+    FOREIGN KEY (contributor_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS listings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_id UUID NOT NULL,
+    buyer_id UUID,
+    inventory_id UUID NOT NULL,
+    item_type VARCHAR(50) NOT NULL,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    price INT NOT NULL CHECK (price > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sold_at TIMESTAMP,
+    FOREIGN KEY (seller_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (buyer_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listings_active_inventory
+    ON listings (inventory_id) WHERE status = 'active';
+
+CREATE INDEX IF NOT EXISTS idx_listings_status_item ON listings (status, item_type);
+
+CREATE TABLE IF NOT EXISTS trades (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proposer_id UUID NOT NULL,
+    counterparty_id UUID NOT NULL,
+    proposer_coins INT NOT NULL DEFAULT 0 CHECK (proposer_coins >= 0),
+    counterparty_coins INT NOT NULL DEFAULT 0 CHECK (counterparty_coins >= 0),
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    FOREIGN KEY (proposer_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (counterparty_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS trade_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    trade_id UUID NOT NULL,
+    inventory_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    item_type VARCHAR(50) NOT NULL,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    quantity INT NOT NULL,
+    FOREIGN KEY (trade_id) REFERENCES trades(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS auctions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_name VARCHAR(255) NOT NULL,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    reserve_price INT NOT NULL DEFAULT 0 CHECK (reserve_price >= 0),
+    min_increment INT NOT NULL DEFAULT 1 CHECK (min_increment > 0),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    top_bid INT NOT NULL DEFAULT 0,
+    top_bidder_id UUID,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP,
+    CHECK (ends_at > starts_at),
+    FOREIGN KEY (top_bidder_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS bids (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auction_id UUID NOT NULL,
+    bidder_id UUID NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(id) ON DELETE CASCADE,
+    FOREIGN KEY (bidder_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS raffles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    sku VARCHAR(100),
+    variant VARCHAR(255),
+    ticket_price INT NOT NULL CHECK (ticket_price > 0),
+    max_tickets INT NOT NULL DEFAULT 0,
+    max_tickets_per_user INT NOT NULL DEFAULT 0,
+    winners INT NOT NULL DEFAULT 1 CHECK (winners > 0),
+    tickets_sold INT NOT NULL DEFAULT 0,
+    draw_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    seed VARCHAR(64) NOT NULL,
+    seed_hash VARCHAR(64) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    drawn_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS raffle_tickets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    raffle_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    number INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (raffle_id, number),
+    FOREIGN KEY (raffle_id) REFERENCES raffles(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bounties (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    creator_id UUID NOT NULL,
+    claimant_id UUID,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    reward INT NOT NULL CHECK (reward > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    submission_note TEXT,
+    review_comment TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    FOREIGN KEY (creator_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (claimant_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS kudos_reactions (
+    transaction_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (transaction_id, user_id, emoji),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS kudos_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_public ON transactions (created_at DESC) WHERE is_public;
+
+CREATE TABLE IF NOT EXISTS leaderboard_entries (
+    board VARCHAR(20) NOT NULL,
+    period VARCHAR(20) NOT NULL,
+    user_id UUID NOT NULL,
+    score INT NOT NULL,
+    refreshed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (board, period, user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id UUID NOT NULL,
+    code VARCHAR(100) NOT NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, code),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID,
+    team VARCHAR(100),
+    balance INT NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    refill_amount INT NOT NULL CHECK (refill_amount > 0),
+    refill_period VARCHAR(20) NOT NULL,
+    refilled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((owner_id IS NULL) <> (team IS NULL)),
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS budget_holders (
+    budget_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    PRIMARY KEY (budget_id, user_id),
+    FOREIGN KEY (budget_id) REFERENCES budgets(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_budget ON transactions (budget_id, created_at) WHERE budget_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    activates_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issuer, subject),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS sso_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key VARCHAR(300) PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    blocked_until TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    prefix VARCHAR(16) UNIQUE NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT NOT NULL,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys (user_id);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    user_id UUID NOT NULL REFERENCES users(id),
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS purchases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    payer_id UUID,
+    inventory_id UUID,
+    item_type VARCHAR(50) NOT NULL,
+    sku VARCHAR(100),
+    price INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    refunded_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (payer_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (inventory_id) REFERENCES inventory(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchases_user_item ON purchases (user_id, item_type, created_at);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    details TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -10,4 +10,53 @@ VALUES
     ('umbrella', 200),
     ('socks', 10),
     ('wallet', 50),
-    ('pink-hoody', 500) ON CONFLICT (name) DO NOTHING;
\ No newline at end of file
+    ('pink-hoody', 500) ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO
+    item_options (item_name, name, value)
+VALUES
+    ('t-shirt', 'size', 'S'),
+    ('t-shirt', 'size', 'M'),
+    ('t-shirt', 'size', 'L'),
+    ('t-shirt', 'color', 'white'),
+    ('t-shirt', 'color', 'black'),
+    ('hoody', 'size', 'S'),
+    ('hoody', 'size', 'M'),
+    ('hoody', 'size', 'L') ON CONFLICT DO NOTHING;
+
+INSERT INTO
+    item_skus (code, item_name, stock)
+VALUES
+    ('t-shirt-s-white', 't-shirt', 50),
+    ('t-shirt-m-white', 't-shirt', 50),
+    ('t-shirt-l-white', 't-shirt', 50),
+    ('t-shirt-s-black', 't-shirt', 50),
+    ('t-shirt-m-black', 't-shirt', 50),
+    ('t-shirt-l-black', 't-shirt', 50),
+    ('hoody-s', 'hoody', 30),
+    ('hoody-m', 'hoody', 30),
+    ('hoody-l', 'hoody', 30) ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO
+    item_sku_options (sku_id, name, value)
+SELECT s.id, o.name, o.value
+FROM (
+    VALUES
+        ('t-shirt-s-white', 'size', 'S'), ('t-shirt-s-white', 'color', 'white'),
+        ('t-shirt-m-white', 'size', 'M'), ('t-shirt-m-white', 'color', 'white'),
+        ('t-shirt-l-white', 'size', 'L'), ('t-shirt-l-white', 'color', 'white'),
+        ('t-shirt-s-black', 'size', 'S'), ('t-shirt-s-black', 'color', 'black'),
+        ('t-shirt-m-black', 'size', 'M'), ('t-shirt-m-black', 'color', 'black'),
+        ('t-shirt-l-black', 'size', 'L'), ('t-shirt-l-black', 'color', 'black'),
+        ('hoody-s', 'size', 'S'),
+        ('hoody-m', 'size', 'M'),
+        ('hoody-l', 'size', 'L')
+) AS o (code, name, value)
+JOIN item_skus s ON s.code = o.code ON CONFLICT DO NOTHING;
+
+INSERT INTO
+    item_rules (item_name, max_per_user)
+VALUES
+    ('pink-hoody', 1) ON CONFLICT (item_name) DO NOTHING;
+
+UPDATE items SET has_variants = EXISTS (SELECT 1 FROM item_skus WHERE item_skus.item_name = items.name);
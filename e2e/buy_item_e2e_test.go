@@ -11,12 +11,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"avito-shop-test/internal/adapter"
 	"avito-shop-test/internal/middleware"
 	"avito-shop-test/internal/models"
 
 	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/hasher"
 	"avito-shop-test/internal/repository"
 	"avito-shop-test/internal/usecase"
 
@@ -31,22 +33,25 @@ func TestBuyItemE2ESuccesful(t *testing.T) {
 		log.Fatalf("failed to run migration: %v", err)
 	}
 
-	jwtSecret := []byte("1234")
+	keys := setupKeyring(db)
 
 	userRepo := repository.NewUSerRepository(db)
 	coinTransactionRepo := repository.NewCoinTransactionRepository(db)
 	purchaseRepo := repository.NewPurchaseRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
 	storeRepo := repository.NewStoreRepository(db)
-	purchaseUC := usecase.NewPurchaseUseCase(purchaseRepo, userRepo, storeRepo)
+	variantRepo := repository.NewVariantRepository(db)
+	pricingRepo := repository.NewPricingRepository(db)
+	purchaseUC := usecase.NewPurchaseUseCase(purchaseRepo, userRepo, storeRepo, variantRepo, pricingRepo)
 
-	userUc := usecase.NewUserUsecase(userRepo, purchaseRepo, coinTransactionRepo, token.NewGenerator(jwtSecret))
+	userUc := usecase.NewUserUsecase(userRepo, purchaseRepo, coinTransactionRepo, tokenRepo, token.NewGenerator(keys), hasher.NewBcrypt(bcrypt.MinCost), true, setupLoginThrottle(db), repository.NewTwoFactorRepository(db))
 
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	apiGroup := router.Group("/api")
 	ginRouter := adapter.NewGinRouter(apiGroup)
-	handler.NewUserHandler(ginRouter, userUc, middleware.AuthMiddleware(jwtSecret))
-	handler.NewPurchaseHandler(ginRouter, purchaseUC, middleware.AuthMiddleware(jwtSecret))
+	handler.NewUserHandler(ginRouter, userUc, middleware.AuthMiddleware(token.NewVerifier(keys), tokenRepo))
+	handler.NewPurchaseHandler(ginRouter, purchaseUC, middleware.AuthMiddleware(token.NewVerifier(keys), tokenRepo))
 
 	user := models.User{Username: "user", Password: "password"}
 	authRequestBody := map[string]string{"username": user.Username, "password": user.Password}
@@ -10,12 +10,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"avito-shop-test/internal/adapter"
 	"avito-shop-test/internal/middleware"
 	"avito-shop-test/internal/models"
 
 	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/hasher"
 	"avito-shop-test/internal/repository"
 	"avito-shop-test/internal/usecase"
 
@@ -29,23 +31,24 @@ func TestTransferCoinsE2ESuccesful(t *testing.T) {
 	db := setupTestDB()
 	
 
-	jwtSecret := []byte("1234")
+	keys := setupKeyring(db)
 
 	userRepo := repository.NewUSerRepository(db)
 	transactionRepo := repository.NewCoinTransactionRepository(db)
 	purchaseRepo := repository.NewPurchaseRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
 
 	transactionUC := usecase.NewCoinTransactionUseCase(transactionRepo, userRepo)
 
-	userUc := usecase.NewUserUsecase(userRepo, purchaseRepo, transactionRepo, token.NewGenerator(jwtSecret))
+	userUc := usecase.NewUserUsecase(userRepo, purchaseRepo, transactionRepo, tokenRepo, token.NewGenerator(keys), hasher.NewBcrypt(bcrypt.MinCost), true, setupLoginThrottle(db), repository.NewTwoFactorRepository(db))
 
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	apiGroup := router.Group("/api")
 	ginRouter := adapter.NewGinRouter(apiGroup)
-	handler.NewUserHandler(ginRouter, userUc, middleware.AuthMiddleware(jwtSecret))
+	handler.NewUserHandler(ginRouter, userUc, middleware.AuthMiddleware(token.NewVerifier(keys), tokenRepo))
 
-	handler.NewCoinTransactionHandler(ginRouter, transactionUC, middleware.AuthMiddleware(jwtSecret))
+	handler.NewCoinTransactionHandler(ginRouter, transactionUC, middleware.AuthMiddleware(token.NewVerifier(keys), tokenRepo))
 
 	user1 := models.User{Username: "user1", Password: "password1"}
 	user2 := models.User{Username: "user2", Password: "password2"}
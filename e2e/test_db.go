@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	"avito-shop-test/internal/repository"
+	"avito-shop-test/internal/token"
+	"avito-shop-test/internal/usecase"
 )
 
 func setupTestDB() *gorm.DB {
@@ -21,13 +26,29 @@ func setupTestDB() *gorm.DB {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		dbHost, dbUser, dbPassword, dbName, dbPort)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Ошибка подключения к тестовой базе данных: %v", err)
 	}
 
 	return db
 }
+
+func setupKeyring(db *gorm.DB) *token.Keyring {
+	keys, err := token.NewKeyring(repository.NewSigningKeyRepository(db), token.AlgEdDSA, token.DefaultRotation, token.DefaultTTL)
+	if err != nil {
+		log.Fatalf("Ошибка настройки подписи токенов: %v", err)
+	}
+	if err := keys.Refresh(); err != nil {
+		log.Fatalf("Ошибка загрузки ключей подписи: %v", err)
+	}
+	return keys
+}
+
+func setupLoginThrottle(db *gorm.DB) usecase.LoginThrottle {
+	return usecase.NewLoginThrottle(repository.NewLoginAttemptRepository(db), 10, 100, 15*time.Minute)
+}
+
 func runMigration(db *gorm.DB, filename string) error {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
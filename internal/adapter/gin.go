@@ -35,6 +35,26 @@ func (g *GinContext) Param(key string) string {
 	return g.c.Param(key) 
 }
 
+func (g *GinContext) Query(key string) string {
+	return g.c.Query(key)
+}
+
+func (g *GinContext) ClientIP() string {
+	return g.c.ClientIP()
+}
+
+func (g *GinContext) UserAgent() string {
+	return g.c.Request.UserAgent()
+}
+
+func (g *GinContext) Method() string {
+	return g.c.Request.Method
+}
+
+func (g *GinContext) Path() string {
+	return g.c.Request.URL.Path
+}
+
 
 type GinRouter struct {
 	group *gin.RouterGroup
@@ -56,9 +76,13 @@ func (g *GinRouter) Use(middleware handler.Middleware) {
 		}
 
 		
+		passed := false
 		middleware.Handle(func(hCtx handler.Context) {
-			
+			passed = true
 		})(ctx) 
+		if !passed {
+			c.Abort()
+		}
 	})
 }
 
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type AchievementUseCase interface {
+	GetBadges(username string) (*models.UserBadges, error)
+}
+
+type AchievementDelivery struct {
+	AchievementUC AchievementUseCase
+}
+
+func (d *AchievementDelivery) GetMyBadges(c Context) {
+	username := c.MustGet("username").(string)
+
+	badges, err := d.AchievementUC.GetBadges(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, badges)
+}
+
+func (d *AchievementDelivery) GetUserBadges(c Context) {
+	badges, err := d.AchievementUC.GetBadges(c.Param("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, badges)
+}
+
+func NewAchievementHandler(api Router, achievementUC AchievementUseCase, middleware Middleware) {
+	handler := &AchievementDelivery{
+		AchievementUC: achievementUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/badges", handler.GetMyBadges)
+	protected.GET("/users/:username/badges", handler.GetUserBadges)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type AdminUseCase interface {
+	SaveItem(username string, req models.SaveItemRequest) error
+	GrantCoins(username string, req models.GrantRequest) error
+	ReverseTransaction(username, transactionID string, req models.ReverseRequest) error
+	SetRole(username, target string, req models.SetRoleRequest) error
+	UnlockLogin(username, target string, req models.UnlockLoginRequest) error
+	GetAuditLog(username string, limit, offset int) ([]models.AuditEntry, error)
+}
+
+type AdminDelivery struct {
+	AdminUC AdminUseCase
+}
+
+func (d *AdminDelivery) SaveItem(c Context) {
+	var req models.SaveItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.AdminUC.SaveItem(username, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Товар сохранён"})
+}
+
+func (d *AdminDelivery) GrantCoins(c Context) {
+	var req models.GrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.AdminUC.GrantCoins(username, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Монеты начислены"})
+}
+
+func (d *AdminDelivery) ReverseTransaction(c Context) {
+	var req models.ReverseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.AdminUC.ReverseTransaction(username, c.Param("id"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Перевод отменён"})
+}
+
+func (d *AdminDelivery) SetRole(c Context) {
+	var req models.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.AdminUC.SetRole(username, c.Param("username"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Роль изменена"})
+}
+
+func (d *AdminDelivery) UnlockLogin(c Context) {
+	var req models.UnlockLoginRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.AdminUC.UnlockLogin(username, c.Param("username"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Вход разблокирован"})
+}
+
+func (d *AdminDelivery) GetAuditLog(c Context) {
+	var limit, offset int
+	err := queryInts(c, map[string]*int{
+		"limit":  &limit,
+		"offset": &offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	entries, err := d.AdminUC.GetAuditLog(username, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func NewAdminHandler(api Router, adminUC AdminUseCase, middleware Middleware, authz Authorizer) {
+	handler := &AdminDelivery{
+		AdminUC: adminUC,
+	}
+
+	restricted := func(permission string) Router {
+		group := api.Group("/admin")
+		group.Use(middleware)
+		group.Use(authz.Require(permission))
+		return group
+	}
+
+	restricted(models.PermCatalogEdit).POST("/items", handler.SaveItem)
+	restricted(models.PermCoinsGrant).POST("/grants", handler.GrantCoins)
+	restricted(models.PermCoinsReverse).POST("/transactions/:id/reverse", handler.ReverseTransaction)
+	restricted(models.PermUsersManage).POST("/users/:username/role", handler.SetRole)
+	restricted(models.PermUsersManage).POST("/users/:username/unlock", handler.UnlockLogin)
+	restricted(models.PermAuditView).GET("/audit", handler.GetAuditLog)
+}
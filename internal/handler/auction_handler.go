@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type AuctionUseCase interface {
+	CreateAuction(username string, req models.CreateAuctionRequest) (*models.AuctionInfo, error)
+	GetAuctions() ([]models.AuctionInfo, error)
+	GetAuction(id string) (*models.AuctionInfo, error)
+	PlaceBid(username, auctionID string, amount int) (*models.AuctionInfo, error)
+	CancelAuction(username, auctionID string) error
+}
+
+type AuctionDelivery struct {
+	AuctionUC AuctionUseCase
+}
+
+func (d *AuctionDelivery) GetAuctions(c Context) {
+	auctions, err := d.AuctionUC.GetAuctions()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, auctions)
+}
+
+func (d *AuctionDelivery) GetAuction(c Context) {
+	auction, err := d.AuctionUC.GetAuction(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, auction)
+}
+
+func (d *AuctionDelivery) CreateAuction(c Context) {
+	var req models.CreateAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	auction, err := d.AuctionUC.CreateAuction(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, auction)
+}
+
+func (d *AuctionDelivery) PlaceBid(c Context) {
+	var req models.PlaceBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	auction, err := d.AuctionUC.PlaceBid(username, c.Param("id"), req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, auction)
+}
+
+func (d *AuctionDelivery) CancelAuction(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.AuctionUC.CancelAuction(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Аукцион отменён"})
+}
+
+func NewAuctionHandler(api Router, auctionUC AuctionUseCase, middleware Middleware) {
+	handler := &AuctionDelivery{
+		AuctionUC: auctionUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/auctions", handler.GetAuctions)
+	protected.POST("/auctions", handler.CreateAuction)
+	protected.GET("/auctions/:id", handler.GetAuction)
+	protected.POST("/auctions/:id/bids", handler.PlaceBid)
+	protected.POST("/auctions/:id/cancel", handler.CancelAuction)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type BountyUseCase interface {
+	CreateBounty(username string, req models.CreateBountyRequest) (*models.BountyInfo, error)
+	GetOpenBounties() ([]models.BountyInfo, error)
+	GetUserBounties(username string) ([]models.BountyInfo, error)
+	ClaimBounty(username, bountyID string) error
+	ReleaseBounty(username, bountyID string) error
+	SubmitBounty(username, bountyID, note string) error
+	ApproveBounty(username, bountyID, comment string) error
+	RejectBounty(username, bountyID, comment string) error
+	CancelBounty(username, bountyID string) error
+}
+
+type BountyDelivery struct {
+	BountyUC BountyUseCase
+}
+
+func (d *BountyDelivery) GetOpenBounties(c Context) {
+	bounties, err := d.BountyUC.GetOpenBounties()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bounties)
+}
+
+func (d *BountyDelivery) GetUserBounties(c Context) {
+	username := c.MustGet("username").(string)
+
+	bounties, err := d.BountyUC.GetUserBounties(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bounties)
+}
+
+func (d *BountyDelivery) CreateBounty(c Context) {
+	var req models.CreateBountyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	bounty, err := d.BountyUC.CreateBounty(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bounty)
+}
+
+func (d *BountyDelivery) ClaimBounty(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.ClaimBounty(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Задание взято"})
+}
+
+func (d *BountyDelivery) ReleaseBounty(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.ReleaseBounty(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Задание снова открыто"})
+}
+
+func (d *BountyDelivery) SubmitBounty(c Context) {
+	var req models.SubmitBountyRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.SubmitBounty(username, c.Param("id"), req.Note); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Результат отправлен на проверку"})
+}
+
+func (d *BountyDelivery) ApproveBounty(c Context) {
+	var req models.ReviewBountyRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.ApproveBounty(username, c.Param("id"), req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Награда выплачена"})
+}
+
+func (d *BountyDelivery) RejectBounty(c Context) {
+	var req models.ReviewBountyRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.RejectBounty(username, c.Param("id"), req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Результат отправлен на доработку"})
+}
+
+func (d *BountyDelivery) CancelBounty(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.BountyUC.CancelBounty(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Задание отменено, монеты возвращены"})
+}
+
+func NewBountyHandler(api Router, bountyUC BountyUseCase, middleware Middleware) {
+	handler := &BountyDelivery{
+		BountyUC: bountyUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/bounties", handler.GetOpenBounties)
+	protected.POST("/bounties", handler.CreateBounty)
+	protected.GET("/bounties/mine", handler.GetUserBounties)
+	protected.POST("/bounties/:id/claim", handler.ClaimBounty)
+	protected.POST("/bounties/:id/release", handler.ReleaseBounty)
+	protected.POST("/bounties/:id/submit", handler.SubmitBounty)
+	protected.POST("/bounties/:id/approve", handler.ApproveBounty)
+	protected.POST("/bounties/:id/reject", handler.RejectBounty)
+	protected.POST("/bounties/:id/cancel", handler.CancelBounty)
+}
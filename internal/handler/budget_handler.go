@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type BudgetUseCase interface {
+	CreateBudget(username string, req models.CreateBudgetRequest) (*models.BudgetInfo, error)
+	GetBudgets(username string) ([]models.BudgetInfo, error)
+	SendFromBudget(username, budgetID string, req models.SendCoinRequest) error
+	GetUsage(username, budgetID, period string) (*models.BudgetReport, error)
+}
+
+type BudgetDelivery struct {
+	BudgetUC BudgetUseCase
+}
+
+func (d *BudgetDelivery) GetBudgets(c Context) {
+	username := c.MustGet("username").(string)
+
+	budgets, err := d.BudgetUC.GetBudgets(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+func (d *BudgetDelivery) CreateBudget(c Context) {
+	var req models.CreateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	budget, err := d.BudgetUC.CreateBudget(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (d *BudgetDelivery) SendFromBudget(c Context) {
+	var req models.SendCoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.BudgetUC.SendFromBudget(username, c.Param("id"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Монеты отправлены из бюджета"})
+}
+
+func (d *BudgetDelivery) GetUsage(c Context) {
+	username := c.MustGet("username").(string)
+
+	report, err := d.BudgetUC.GetUsage(username, c.Param("id"), c.Query("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func NewBudgetHandler(api Router, budgetUC BudgetUseCase, middleware Middleware) {
+	handler := &BudgetDelivery{
+		BudgetUC: budgetUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/budgets", handler.GetBudgets)
+	protected.POST("/budgets", handler.CreateBudget)
+	protected.POST("/budgets/:id/send", handler.SendFromBudget)
+	protected.GET("/budgets/:id/usage", handler.GetUsage)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type CatalogUseCase interface {
+	GetCatalog() ([]models.CatalogItem, error)
+}
+
+type CatalogDelivery struct {
+	CatalogUC CatalogUseCase
+}
+
+func (d *CatalogDelivery) GetCatalog(c Context) {
+	catalog, err := d.CatalogUC.GetCatalog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+func NewCatalogHandler(api Router, catalogUC CatalogUseCase, middleware Middleware, authz Authorizer) {
+	handler := &CatalogDelivery{
+		CatalogUC: catalogUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+	protected.Use(authz.Require(models.PermCatalogRead))
+
+	protected.GET("/catalog", handler.GetCatalog)
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type CoinTransactionUseCase interface {
-	SendCoins(fromUser, toUser string, amount int) error
+	Send(fromUser string, req models.SendCoinRequest) error
 }
 
 type coinTransactionDelivery struct {
@@ -27,7 +27,7 @@ func (d *coinTransactionDelivery) SendCoin(c Context) {
 
 	username := c.MustGet("username").(string)
 
-	err := d.coinTransactionUC.SendCoins(username, requestBody.ToUser, requestBody.Amount)
+	err := d.coinTransactionUC.Send(username, requestBody)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
 		return
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type GiftUseCase interface {
+	SendGift(fromUser string, req models.SendGiftRequest) (*models.Gift, error)
+	DeclineGift(username, giftID string) error
+	GetGiftHistory(username string) (*models.GiftHistory, error)
+}
+
+type GiftDelivery struct {
+	GiftUC GiftUseCase
+}
+
+func (d *GiftDelivery) SendGift(c Context) {
+	var req models.SendGiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	gift, err := d.GiftUC.SendGift(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Подарок отправлен", "id": gift.ID})
+}
+
+func (d *GiftDelivery) DeclineGift(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.GiftUC.DeclineGift(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Подарок отклонён, монеты возвращены отправителю"})
+}
+
+func (d *GiftDelivery) GetGiftHistory(c Context) {
+	username := c.MustGet("username").(string)
+
+	history, err := d.GiftUC.GetGiftHistory(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func NewGiftHandler(api Router, giftUC GiftUseCase, middleware Middleware) {
+	handler := &GiftDelivery{
+		GiftUC: giftUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.POST("/gifts", handler.SendGift)
+	protected.GET("/gifts", handler.GetGiftHistory)
+	protected.POST("/gifts/:id/decline", handler.DeclineGift)
+}
@@ -7,6 +7,11 @@ type Context interface {
 	Set(key string, value interface{})
 	Get(key string) (value interface{}, exists bool)
 	Param(key string) string
+	Query(key string) string
+	ClientIP() string
+	UserAgent() string
+	Method() string
+	Path() string
 }
 
 type Router interface {
@@ -19,3 +24,7 @@ type Router interface {
 type Middleware interface {
 	Handle(handlerFunc func(Context)) func(Context)
 }
+
+type Authorizer interface {
+	Require(permission string) Middleware
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type KeySet interface {
+	JWKS() models.JWKS
+}
+
+type JWKSDelivery struct {
+	Keys KeySet
+}
+
+func (d *JWKSDelivery) GetJWKS(c Context) {
+	c.JSON(http.StatusOK, d.Keys.JWKS())
+}
+
+func NewJWKSHandler(root Router, keys KeySet) {
+	handler := &JWKSDelivery{
+		Keys: keys,
+	}
+
+	root.GET("/.well-known/jwks.json", handler.GetJWKS)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type KudosUseCase interface {
+	GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error)
+	AddReaction(username, transactionID, emoji string) error
+	RemoveReaction(username, transactionID, emoji string) error
+	AddComment(username, transactionID, text string) (*models.KudosComment, error)
+	GetComments(transactionID string) ([]models.KudosComment, error)
+	GetPrivacy(username string) (*models.PrivacySettings, error)
+	UpdatePrivacy(username string, settings models.PrivacySettings) error
+}
+
+type KudosDelivery struct {
+	KudosUC KudosUseCase
+}
+
+func (d *KudosDelivery) GetFeed(c Context) {
+	filter := models.KudosFilter{Team: c.Query("team")}
+	err := queryInts(c, map[string]*int{
+		"limit":  &filter.Limit,
+		"offset": &filter.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	feed, err := d.KudosUC.GetFeed(filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+func (d *KudosDelivery) AddReaction(c Context) {
+	var req models.KudosReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.KudosUC.AddReaction(username, c.Param("id"), req.Emoji); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Реакция добавлена"})
+}
+
+func (d *KudosDelivery) RemoveReaction(c Context) {
+	var req models.KudosReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.KudosUC.RemoveReaction(username, c.Param("id"), req.Emoji); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Реакция удалена"})
+}
+
+func (d *KudosDelivery) GetComments(c Context) {
+	comments, err := d.KudosUC.GetComments(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (d *KudosDelivery) AddComment(c Context) {
+	var req models.KudosCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	comment, err := d.KudosUC.AddComment(username, c.Param("id"), req.Text)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (d *KudosDelivery) GetPrivacy(c Context) {
+	username := c.MustGet("username").(string)
+
+	settings, err := d.KudosUC.GetPrivacy(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (d *KudosDelivery) UpdatePrivacy(c Context) {
+	var req models.PrivacySettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.KudosUC.UpdatePrivacy(username, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, req)
+}
+
+func NewKudosHandler(api Router, kudosUC KudosUseCase, middleware Middleware) {
+	handler := &KudosDelivery{
+		KudosUC: kudosUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/kudos", handler.GetFeed)
+	protected.GET("/kudos/:id/comments", handler.GetComments)
+	protected.POST("/kudos/:id/comments", handler.AddComment)
+	protected.POST("/kudos/:id/reactions", handler.AddReaction)
+	protected.POST("/kudos/:id/reactions/remove", handler.RemoveReaction)
+	protected.GET("/privacy", handler.GetPrivacy)
+	protected.POST("/privacy", handler.UpdatePrivacy)
+}
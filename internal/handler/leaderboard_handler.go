@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type LeaderboardUseCase interface {
+	GetLeaderboard(board, period string) (*models.Leaderboard, error)
+}
+
+type LeaderboardDelivery struct {
+	LeaderboardUC LeaderboardUseCase
+}
+
+func (d *LeaderboardDelivery) GetLeaderboard(c Context) {
+	leaderboard, err := d.LeaderboardUC.GetLeaderboard(c.Param("board"), c.Query("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+func NewLeaderboardHandler(api Router, leaderboardUC LeaderboardUseCase, middleware Middleware) {
+	handler := &LeaderboardDelivery{
+		LeaderboardUC: leaderboardUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/leaderboards/:board", handler.GetLeaderboard)
+}
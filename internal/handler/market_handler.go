@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"avito-shop-test/internal/models"
+)
+
+type MarketUseCase interface {
+	CreateListing(username string, req models.CreateListingRequest) (*models.Listing, error)
+	SearchListings(filter models.ListingFilter) ([]models.ListingInfo, error)
+	GetUserListings(username string) ([]models.Listing, error)
+	CancelListing(username, listingID string) error
+	BuyListing(username, listingID string) (*models.Listing, error)
+}
+
+type MarketDelivery struct {
+	MarketUC MarketUseCase
+}
+
+func (d *MarketDelivery) SearchListings(c Context) {
+	filter := models.ListingFilter{
+		Query:  c.Query("q"),
+		Seller: c.Query("seller"),
+	}
+	for key, target := range map[string]*int{
+		"minPrice": &filter.MinPrice,
+		"maxPrice": &filter.MaxPrice,
+		"limit":    &filter.Limit,
+		"offset":   &filter.Offset,
+	} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+			return
+		}
+		*target = parsed
+	}
+
+	listings, err := d.MarketUC.SearchListings(filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, listings)
+}
+
+func (d *MarketDelivery) CreateListing(c Context) {
+	var req models.CreateListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	listing, err := d.MarketUC.CreateListing(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, listing)
+}
+
+func (d *MarketDelivery) GetUserListings(c Context) {
+	username := c.MustGet("username").(string)
+
+	listings, err := d.MarketUC.GetUserListings(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, listings)
+}
+
+func (d *MarketDelivery) CancelListing(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.MarketUC.CancelListing(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Объявление снято"})
+}
+
+func (d *MarketDelivery) BuyListing(c Context) {
+	username := c.MustGet("username").(string)
+
+	listing, err := d.MarketUC.BuyListing(username, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, listing)
+}
+
+func NewMarketHandler(api Router, marketUC MarketUseCase, middleware Middleware) {
+	handler := &MarketDelivery{
+		MarketUC: marketUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/market", handler.SearchListings)
+	protected.POST("/market", handler.CreateListing)
+	protected.GET("/market/mine", handler.GetUserListings)
+	protected.POST("/market/:id/buy", handler.BuyListing)
+	protected.POST("/market/:id/cancel", handler.CancelListing)
+}
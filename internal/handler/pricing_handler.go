@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type PricingUseCase interface {
+	GetPrice(username string, req models.BuyRequest) (*models.PriceQuote, error)
+	GetPriceHistory(itemName string) ([]models.PriceChange, error)
+	SchedulePrice(username, itemName string, req models.SchedulePriceRequest) (*models.PriceChange, error)
+	CreatePromotion(username string, req models.CreatePromotionRequest) (*models.Promotion, error)
+	CreatePromoCode(username string, req models.CreatePromoCodeRequest) (*models.PromoCode, error)
+}
+
+type PricingDelivery struct {
+	PricingUC PricingUseCase
+}
+
+func (d *PricingDelivery) GetPrice(c Context) {
+	username := c.MustGet("username").(string)
+
+	req := models.BuyRequest{
+		Item:      c.Param("item"),
+		SKU:       c.Query("sku"),
+		PromoCode: c.Query("code"),
+	}
+
+	quote, err := d.PricingUC.GetPrice(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func (d *PricingDelivery) GetPriceHistory(c Context) {
+	history, err := d.PricingUC.GetPriceHistory(c.Param("item"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (d *PricingDelivery) SchedulePrice(c Context) {
+	var req models.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	change, err := d.PricingUC.SchedulePrice(username, c.Param("item"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
+
+func (d *PricingDelivery) CreatePromotion(c Context) {
+	var req models.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	promotion, err := d.PricingUC.CreatePromotion(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+func (d *PricingDelivery) CreatePromoCode(c Context) {
+	var req models.CreatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	code, err := d.PricingUC.CreatePromoCode(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, code)
+}
+
+func NewPricingHandler(api Router, pricingUC PricingUseCase, middleware Middleware) {
+	handler := &PricingDelivery{
+		PricingUC: pricingUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/items/:item/price", handler.GetPrice)
+	protected.GET("/items/:item/prices", handler.GetPriceHistory)
+	protected.POST("/items/:item/prices", handler.SchedulePrice)
+	protected.POST("/promotions", handler.CreatePromotion)
+	protected.POST("/promo-codes", handler.CreatePromoCode)
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+
+	"avito-shop-test/internal/models"
 )
 
 type PurchaseUseCase interface {
 	BuyItem(username string, itemName string) error
+	Buy(username string, req models.BuyRequest) error
+	GetPurchaseLines(username string) ([]models.PurchaseLine, error)
 }
 
 type PurchaseDelivery struct {
@@ -22,13 +27,36 @@ func (d *PurchaseDelivery) BuyItem(c Context) {
 
 	username := c.MustGet("username").(string)
 
-	if err := d.PurchaseUC.BuyItem(username, item); err != nil {
+	req := models.BuyRequest{
+		Item:      item,
+		SKU:       c.Query("sku"),
+		PromoCode: c.Query("code"),
+	}
+
+	if err := d.PurchaseUC.Buy(username, req); err != nil {
+		var eligibilityErr *models.EligibilityError
+		if errors.As(err, &eligibilityErr) {
+			c.JSON(http.StatusForbidden, map[string]string{"Errors": eligibilityErr.Message, "code": eligibilityErr.Code})
+			return
+		}
 		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, map[string]string{"Message": "Товар куплен успешно"})
 }
 
+func (d *PurchaseDelivery) GetPurchaseLines(c Context) {
+	username := c.MustGet("username").(string)
+
+	lines, err := d.PurchaseUC.GetPurchaseLines(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lines)
+}
+
 func NewPurchaseHandler(api Router, purchaseUC PurchaseUseCase, middleware Middleware) {
 	handler := &PurchaseDelivery{
 		PurchaseUC: purchaseUC,
@@ -38,4 +66,5 @@ func NewPurchaseHandler(api Router, purchaseUC PurchaseUseCase, middleware Middl
 	protected.Use(middleware)
 
 	protected.GET("/buy/:item", handler.BuyItem)
+	protected.GET("/purchases", handler.GetPurchaseLines)
 }
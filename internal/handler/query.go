@@ -0,0 +1,18 @@
+package handler
+
+import "strconv"
+
+func queryInts(c Context, targets map[string]*int) error {
+	for key, target := range targets {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*target = parsed
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type RaffleUseCase interface {
+	CreateRaffle(username string, req models.CreateRaffleRequest) (*models.RaffleInfo, error)
+	GetRaffles() ([]models.RaffleInfo, error)
+	GetRaffle(id string) (*models.RaffleInfo, error)
+	BuyTickets(username, raffleID string, count int) ([]int, error)
+	CancelRaffle(username, raffleID string) error
+}
+
+type RaffleDelivery struct {
+	RaffleUC RaffleUseCase
+}
+
+func (d *RaffleDelivery) GetRaffles(c Context) {
+	raffles, err := d.RaffleUC.GetRaffles()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, raffles)
+}
+
+func (d *RaffleDelivery) GetRaffle(c Context) {
+	raffle, err := d.RaffleUC.GetRaffle(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, raffle)
+}
+
+func (d *RaffleDelivery) CreateRaffle(c Context) {
+	var req models.CreateRaffleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	raffle, err := d.RaffleUC.CreateRaffle(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, raffle)
+}
+
+func (d *RaffleDelivery) BuyTickets(c Context) {
+	var req models.BuyTicketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	numbers, err := d.RaffleUC.BuyTickets(username, c.Param("id"), req.Count)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string][]int{"tickets": numbers})
+}
+
+func (d *RaffleDelivery) CancelRaffle(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.RaffleUC.CancelRaffle(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Розыгрыш отменён, билеты возвращены"})
+}
+
+func NewRaffleHandler(api Router, raffleUC RaffleUseCase, middleware Middleware) {
+	handler := &RaffleDelivery{
+		RaffleUC: raffleUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/raffles", handler.GetRaffles)
+	protected.POST("/raffles", handler.CreateRaffle)
+	protected.GET("/raffles/:id", handler.GetRaffle)
+	protected.POST("/raffles/:id/tickets", handler.BuyTickets)
+	protected.POST("/raffles/:id/cancel", handler.CancelRaffle)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type ReturnUseCase interface {
+	RequestReturn(username, inventoryID, reason string) (*models.ReturnRequest, error)
+	GetUserReturns(username string) ([]models.ReturnRequest, error)
+	GetPendingReturns(username string) ([]models.ReturnRequest, error)
+	ApproveReturn(username, returnID, comment string) error
+	RejectReturn(username, returnID, comment string) error
+}
+
+type ReturnDelivery struct {
+	ReturnUC ReturnUseCase
+}
+
+func (d *ReturnDelivery) RequestReturn(c Context) {
+	var req models.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	ret, err := d.ReturnUC.RequestReturn(username, req.InventoryID, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+func (d *ReturnDelivery) GetUserReturns(c Context) {
+	username := c.MustGet("username").(string)
+
+	returns, err := d.ReturnUC.GetUserReturns(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, returns)
+}
+
+func (d *ReturnDelivery) GetPendingReturns(c Context) {
+	username := c.MustGet("username").(string)
+
+	returns, err := d.ReturnUC.GetPendingReturns(username)
+	if err != nil {
+		c.JSON(http.StatusForbidden, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, returns)
+}
+
+func (d *ReturnDelivery) ApproveReturn(c Context) {
+	var req models.ReviewReturnRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.ReturnUC.ApproveReturn(username, c.Param("id"), req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Возврат одобрен"})
+}
+
+func (d *ReturnDelivery) RejectReturn(c Context) {
+	var req models.ReviewReturnRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	if err := d.ReturnUC.RejectReturn(username, c.Param("id"), req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Возврат отклонён"})
+}
+
+func NewReturnHandler(api Router, returnUC ReturnUseCase, middleware Middleware) {
+	handler := &ReturnDelivery{
+		ReturnUC: returnUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.POST("/returns", handler.RequestReturn)
+	protected.GET("/returns", handler.GetUserReturns)
+	protected.GET("/returns/pending", handler.GetPendingReturns)
+	protected.POST("/returns/:id/approve", handler.ApproveReturn)
+	protected.POST("/returns/:id/reject", handler.RejectReturn)
+}
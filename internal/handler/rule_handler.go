@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type RuleUseCase interface {
+	GetRule(itemName string) (*models.ItemRule, error)
+	SaveRule(username, itemName string, rule models.ItemRule) error
+	UpdateUserAttributes(username, targetUsername string, req models.UpdateUserAttributesRequest) error
+}
+
+type RuleDelivery struct {
+	RuleUC RuleUseCase
+}
+
+func (d *RuleDelivery) GetRule(c Context) {
+	rule, err := d.RuleUC.GetRule(c.Param("item"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (d *RuleDelivery) SaveRule(c Context) {
+	var rule models.ItemRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.RuleUC.SaveRule(username, c.Param("item"), rule); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Правила товара сохранены"})
+}
+
+func (d *RuleDelivery) UpdateUserAttributes(c Context) {
+	var req models.UpdateUserAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.RuleUC.UpdateUserAttributes(username, c.Param("username"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Данные сотрудника обновлены"})
+}
+
+func NewRuleHandler(api Router, ruleUC RuleUseCase, middleware Middleware) {
+	handler := &RuleDelivery{
+		RuleUC: ruleUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/items/:item/rules", handler.GetRule)
+	protected.POST("/items/:item/rules", handler.SaveRule)
+	protected.POST("/users/:username/attributes", handler.UpdateUserAttributes)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type ServiceAccountUseCase interface {
+	CreateServiceAccount(username string, req models.CreateServiceAccountRequest) (*models.ServiceAccount, error)
+	GetServiceAccounts(username string) ([]models.ServiceAccount, error)
+	CreateAPIKey(username, accountName string, req models.CreateAPIKeyRequest) (*models.APIKeyResponse, error)
+	RotateAPIKey(username, keyID string, req models.RotateAPIKeyRequest) (*models.APIKeyResponse, error)
+	RevokeAPIKey(username, keyID string) error
+}
+
+type ServiceAccountDelivery struct {
+	ServiceAccountUC ServiceAccountUseCase
+}
+
+func (d *ServiceAccountDelivery) CreateServiceAccount(c Context) {
+	var req models.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	account, err := d.ServiceAccountUC.CreateServiceAccount(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (d *ServiceAccountDelivery) GetServiceAccounts(c Context) {
+	username := c.MustGet("username").(string)
+
+	accounts, err := d.ServiceAccountUC.GetServiceAccounts(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (d *ServiceAccountDelivery) CreateAPIKey(c Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	key, err := d.ServiceAccountUC.CreateAPIKey(username, c.Param("name"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+func (d *ServiceAccountDelivery) RotateAPIKey(c Context) {
+	var req models.RotateAPIKeyRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+
+	key, err := d.ServiceAccountUC.RotateAPIKey(username, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+func (d *ServiceAccountDelivery) RevokeAPIKey(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.ServiceAccountUC.RevokeAPIKey(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Ключ отозван"})
+}
+
+func NewServiceAccountHandler(api Router, serviceAccountUC ServiceAccountUseCase, middleware Middleware, authz Authorizer) {
+	handler := &ServiceAccountDelivery{
+		ServiceAccountUC: serviceAccountUC,
+	}
+
+	restricted := api.Group("/admin")
+	restricted.Use(middleware)
+	restricted.Use(authz.Require(models.PermUsersManage))
+
+	restricted.POST("/service-accounts", handler.CreateServiceAccount)
+	restricted.GET("/service-accounts", handler.GetServiceAccounts)
+	restricted.POST("/service-accounts/:name/keys", handler.CreateAPIKey)
+	restricted.POST("/api-keys/:id/rotate", handler.RotateAPIKey)
+	restricted.POST("/api-keys/:id/revoke", handler.RevokeAPIKey)
+}
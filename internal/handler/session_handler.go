@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type SessionUseCase interface {
+	GetSessions(username, currentID string) ([]models.SessionInfo, error)
+	RevokeSession(username, sessionID string) error
+	RevokeAllSessions(username, currentID string, req models.RevokeSessionsRequest) error
+}
+
+type SessionDelivery struct {
+	SessionUC SessionUseCase
+}
+
+func (d *SessionDelivery) GetSessions(c Context) {
+	username := c.MustGet("username").(string)
+	sessionID := c.MustGet("sessionID").(string)
+
+	sessions, err := d.SessionUC.GetSessions(username, sessionID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (d *SessionDelivery) RevokeSession(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.SessionUC.RevokeSession(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Сессия завершена"})
+}
+
+func (d *SessionDelivery) RevokeAllSessions(c Context) {
+	var req models.RevokeSessionsRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+	sessionID := c.MustGet("sessionID").(string)
+
+	if err := d.SessionUC.RevokeAllSessions(username, sessionID, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Сессии завершены"})
+}
+
+func NewSessionHandler(api Router, sessionUC SessionUseCase, middleware Middleware) {
+	handler := &SessionDelivery{
+		SessionUC: sessionUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/sessions", handler.GetSessions)
+	protected.POST("/sessions/revoke-all", handler.RevokeAllSessions)
+	protected.POST("/sessions/:id/revoke", handler.RevokeSession)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type SSOUseCase interface {
+	StartLogin() (*models.SSOLoginResponse, error)
+	CompleteLogin(state, code string, client models.ClientInfo) (*models.AuthResponse, error)
+}
+
+type SSODelivery struct {
+	SSOUC SSOUseCase
+}
+
+func (d *SSODelivery) StartLogin(c Context) {
+	login, err := d.SSOUC.StartLogin()
+	if err != nil {
+		c.JSON(http.StatusBadGateway, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, login)
+}
+
+func (d *SSODelivery) Callback(c Context) {
+	if reason := c.Query("error"); reason != "" {
+		c.JSON(http.StatusUnauthorized, map[string]string{"Errors": "провайдер SSO отклонил вход: " + reason})
+		return
+	}
+
+	tokens, err := d.SSOUC.CompleteLogin(c.Query("state"), c.Query("code"), clientInfo(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func NewSSOHandler(api Router, ssoUC SSOUseCase) {
+	handler := &SSODelivery{
+		SSOUC: ssoUC,
+	}
+
+	api.GET("/oidc/login", handler.StartLogin)
+	api.GET("/oidc/callback", handler.Callback)
+}
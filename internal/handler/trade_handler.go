@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type TradeUseCase interface {
+	ProposeTrade(username string, req models.CreateTradeRequest) (*models.TradeInfo, error)
+	GetTrades(username string) (*models.TradeHistory, error)
+	AcceptTrade(username, tradeID string) error
+	RejectTrade(username, tradeID string) error
+	CancelTrade(username, tradeID string) error
+}
+
+type TradeDelivery struct {
+	TradeUC TradeUseCase
+}
+
+func (d *TradeDelivery) ProposeTrade(c Context) {
+	var req models.CreateTradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	trade, err := d.TradeUC.ProposeTrade(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trade)
+}
+
+func (d *TradeDelivery) GetTrades(c Context) {
+	username := c.MustGet("username").(string)
+
+	trades, err := d.TradeUC.GetTrades(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trades)
+}
+
+func (d *TradeDelivery) AcceptTrade(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.TradeUC.AcceptTrade(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Обмен состоялся"})
+}
+
+func (d *TradeDelivery) RejectTrade(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.TradeUC.RejectTrade(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Предложение обмена отклонено"})
+}
+
+func (d *TradeDelivery) CancelTrade(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.TradeUC.CancelTrade(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Предложение обмена отозвано"})
+}
+
+func NewTradeHandler(api Router, tradeUC TradeUseCase, middleware Middleware) {
+	handler := &TradeDelivery{
+		TradeUC: tradeUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/trades", handler.GetTrades)
+	protected.POST("/trades", handler.ProposeTrade)
+	protected.POST("/trades/:id/accept", handler.AcceptTrade)
+	protected.POST("/trades/:id/reject", handler.RejectTrade)
+	protected.POST("/trades/:id/cancel", handler.CancelTrade)
+}
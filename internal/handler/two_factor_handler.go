@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type TwoFactorUseCase interface {
+	GetStatus(username string) (*models.TwoFactorStatus, error)
+	Enroll(username, sessionID string) (*models.TOTPEnrollment, error)
+	Confirm(username, sessionID string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error)
+	Disable(username string, req models.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(username string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error)
+}
+
+type TwoFactorDelivery struct {
+	TwoFactorUC TwoFactorUseCase
+}
+
+func (d *TwoFactorDelivery) GetStatus(c Context) {
+	username := c.MustGet("username").(string)
+
+	status, err := d.TwoFactorUC.GetStatus(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (d *TwoFactorDelivery) Enroll(c Context) {
+	username := c.MustGet("username").(string)
+	sessionID := c.MustGet("sessionID").(string)
+
+	enrollment, err := d.TwoFactorUC.Enroll(username, sessionID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func (d *TwoFactorDelivery) Confirm(c Context) {
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+	sessionID := c.MustGet("sessionID").(string)
+
+	codes, err := d.TwoFactorUC.Confirm(username, sessionID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+func (d *TwoFactorDelivery) Disable(c Context) {
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.TwoFactorUC.Disable(username, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Двухфакторная аутентификация отключена"})
+}
+
+func (d *TwoFactorDelivery) RegenerateRecoveryCodes(c Context) {
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	codes, err := d.TwoFactorUC.RegenerateRecoveryCodes(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+func NewTwoFactorHandler(api Router, twoFactorUC TwoFactorUseCase, middleware Middleware) {
+	handler := &TwoFactorDelivery{
+		TwoFactorUC: twoFactorUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/2fa", handler.GetStatus)
+	protected.POST("/2fa/enroll", handler.Enroll)
+	protected.POST("/2fa/confirm", handler.Confirm)
+	protected.POST("/2fa/disable", handler.Disable)
+	protected.POST("/2fa/recovery-codes", handler.RegenerateRecoveryCodes)
+}
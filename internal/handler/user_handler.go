@@ -1,13 +1,20 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"avito-shop-test/internal/models"
 )
 
 type UserUseCase interface {
-	Authenticate(username, password string) (string, error)
+	Authenticate(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	Register(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	Login(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	VerifyTwoFactor(challenge, code string, client models.ClientInfo) (*models.AuthResponse, error)
+	Refresh(refreshToken string, client models.ClientInfo) (*models.AuthResponse, error)
+	Logout(username, jti string, expiresAt time.Time, req models.LogoutRequest) error
 	GetUserInfo(username string) (*models.UserInfo, error)
 	GetCoinHistory(userID string) (models.CoinHistory, error)
 	GetPurchasedItems(userID string) ([]models.PurchasedItem, error)
@@ -24,17 +31,112 @@ func (d *UserDelivery) Authenticate(c Context) {
 		return
 	}
 
-	token, err := d.UserUC.Authenticate(req.Username, req.Password)
+	tokens, err := d.UserUC.Authenticate(req.Username, req.Password, clientInfo(c))
 
+	if err != nil {
+		loginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (d *UserDelivery) Register(c Context) {
+	var req models.AuthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос."})
+		return
+	}
+
+	tokens, err := d.UserUC.Register(req.Username, req.Password, clientInfo(c))
+	if err != nil {
+		var throttled *models.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.JSON(http.StatusTooManyRequests, map[string]string{"Errors": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (d *UserDelivery) Login(c Context) {
+	var req models.AuthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос."})
+		return
+	}
+
+	tokens, err := d.UserUC.Login(req.Username, req.Password, clientInfo(c))
+	if err != nil {
+		loginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (d *UserDelivery) VerifyTwoFactor(c Context) {
+	var req models.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос."})
+		return
+	}
+
+	tokens, err := d.UserUC.VerifyTwoFactor(req.Challenge, req.Code, clientInfo(c))
+	if err != nil {
+		loginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func loginError(c Context, err error) {
+	var throttled *models.LoginThrottledError
+	if errors.As(err, &throttled) {
+		c.JSON(http.StatusTooManyRequests, map[string]string{"Errors": err.Error()})
+		return
+	}
+	c.JSON(http.StatusUnauthorized, map[string]string{"Errors": err.Error()})
+}
+
+func clientInfo(c Context) models.ClientInfo {
+	return models.ClientInfo{IP: c.ClientIP(), UserAgent: c.UserAgent()}
+}
+
+func (d *UserDelivery) Refresh(c Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос."})
+		return
+	}
+
+	tokens, err := d.UserUC.Refresh(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, map[string]string{"Errors": err.Error()})
 		return
 	}
-	response := map[string]string{
-		"token": token,
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (d *UserDelivery) Logout(c Context) {
+	var req models.LogoutRequest
+	_ = c.ShouldBindJSON(&req)
+
+	username := c.MustGet("username").(string)
+	jti := c.MustGet("jti").(string)
+	expiresAt := c.MustGet("tokenExpiresAt").(time.Time)
+
+	if err := d.UserUC.Logout(username, jti, expiresAt, req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, map[string]string{"Message": "Выход выполнен"})
 }
 
 func (d *UserDelivery) GetUserInfo(c Context) {
@@ -63,8 +165,13 @@ func NewUserHandler(api Router, userUC UserUseCase, middleware Middleware) {
 	}
 
 	api.POST("/auth", handler.Authenticate)
+	api.POST("/auth/2fa", handler.VerifyTwoFactor)
+	api.POST("/register", handler.Register)
+	api.POST("/login", handler.Login)
+	api.POST("/refresh", handler.Refresh)
 
 	protected := api.Group("/")
 	protected.Use(middleware)
 	protected.GET("/info", handler.GetUserInfo)
+	protected.POST("/logout", handler.Logout)
 }
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type VariantUseCase interface {
+	GetVariants(itemName string) (*models.ItemVariants, error)
+	AddOption(username, itemName string, req models.AddItemOptionRequest) error
+	CreateSKU(username, itemName string, req models.CreateSKURequest) (*models.SKU, error)
+	AdjustStock(username, code string, delta int) error
+}
+
+type VariantDelivery struct {
+	VariantUC VariantUseCase
+}
+
+func (d *VariantDelivery) GetVariants(c Context) {
+	variants, err := d.VariantUC.GetVariants(c.Param("item"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, variants)
+}
+
+func (d *VariantDelivery) AddOption(c Context) {
+	var req models.AddItemOptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.VariantUC.AddOption(username, c.Param("item"), req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Характеристика добавлена"})
+}
+
+func (d *VariantDelivery) CreateSKU(c Context) {
+	var req models.CreateSKURequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	sku, err := d.VariantUC.CreateSKU(username, c.Param("item"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sku)
+}
+
+func (d *VariantDelivery) AdjustStock(c Context) {
+	var req models.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	if err := d.VariantUC.AdjustStock(username, c.Param("sku"), req.Delta); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Остаток обновлён"})
+}
+
+func NewVariantHandler(api Router, variantUC VariantUseCase, middleware Middleware) {
+	handler := &VariantDelivery{
+		VariantUC: variantUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/items/:item/variants", handler.GetVariants)
+	protected.POST("/items/:item/options", handler.AddOption)
+	protected.POST("/items/:item/skus", handler.CreateSKU)
+	protected.POST("/skus/:sku/stock", handler.AdjustStock)
+}
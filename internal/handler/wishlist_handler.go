@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/models"
+)
+
+type WishlistUseCase interface {
+	GetWishlist(username string) (*models.WishlistView, error)
+	GetSharedWishlist(token string) (*models.WishlistView, error)
+	AddItem(username string, req models.AddWishlistItemRequest) (*models.WishlistItem, error)
+	RemoveItem(username, itemID string) error
+	StartCampaign(username, itemID string, days int) (*models.WishlistItem, error)
+	Contribute(username, itemID string, amount int) (*models.WishlistItem, error)
+}
+
+type WishlistDelivery struct {
+	WishlistUC WishlistUseCase
+}
+
+func (d *WishlistDelivery) GetWishlist(c Context) {
+	username := c.MustGet("username").(string)
+
+	wishlist, err := d.WishlistUC.GetWishlist(username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+func (d *WishlistDelivery) GetSharedWishlist(c Context) {
+	wishlist, err := d.WishlistUC.GetSharedWishlist(c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+func (d *WishlistDelivery) AddItem(c Context) {
+	var req models.AddWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	item, err := d.WishlistUC.AddItem(username, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (d *WishlistDelivery) RemoveItem(c Context) {
+	username := c.MustGet("username").(string)
+
+	if err := d.WishlistUC.RemoveItem(username, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"Message": "Товар удалён из списка желаний"})
+}
+
+func (d *WishlistDelivery) StartCampaign(c Context) {
+	var req models.StartCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	item, err := d.WishlistUC.StartCampaign(username, c.Param("id"), req.Days)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (d *WishlistDelivery) Contribute(c Context) {
+	var req models.ContributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": "Неверный запрос"})
+		return
+	}
+
+	username := c.MustGet("username").(string)
+
+	item, err := d.WishlistUC.Contribute(username, c.Param("id"), req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"Errors": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func NewWishlistHandler(api Router, wishlistUC WishlistUseCase, middleware Middleware) {
+	handler := &WishlistDelivery{
+		WishlistUC: wishlistUC,
+	}
+
+	protected := api.Group("/")
+	protected.Use(middleware)
+
+	protected.GET("/wishlist", handler.GetWishlist)
+	protected.POST("/wishlist", handler.AddItem)
+	protected.POST("/wishlist/:id/remove", handler.RemoveItem)
+	protected.POST("/wishlist/:id/campaign", handler.StartCampaign)
+	protected.POST("/wishlist/:id/contribute", handler.Contribute)
+	protected.GET("/wishlists/:token", handler.GetSharedWishlist)
+}
@@ -0,0 +1,43 @@
+package hasher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcrypt_HashAndCompare(t *testing.T) {
+	h := NewBcrypt(bcrypt.MinCost)
+
+	hash, err := h.Hash("password")
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "password", hash)
+	assert.True(t, h.Compare(hash, "password"))
+	assert.False(t, h.Compare(hash, "wrongpassword"))
+	assert.False(t, h.NeedsRehash(hash))
+}
+
+func TestBcrypt_PlaintextNeedsRehash(t *testing.T) {
+	h := NewBcrypt(bcrypt.MinCost)
+
+	assert.True(t, h.Compare("password", "password"))
+	assert.False(t, h.Compare("password", "wrongpassword"))
+	assert.True(t, h.NeedsRehash("password"))
+}
+
+func TestBcrypt_CostChangeNeedsRehash(t *testing.T) {
+	hash, err := NewBcrypt(bcrypt.MinCost).Hash("password")
+	assert.NoError(t, err)
+
+	h := NewBcrypt(bcrypt.MinCost + 1)
+
+	assert.True(t, h.Compare(hash, "password"))
+	assert.True(t, h.NeedsRehash(hash))
+}
+
+func TestNewBcrypt_InvalidCostFallsBackToDefault(t *testing.T) {
+	assert.Equal(t, bcrypt.DefaultCost, NewBcrypt(0).Cost)
+	assert.Equal(t, bcrypt.DefaultCost, NewBcrypt(bcrypt.MaxCost+1).Cost)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/models"
+)
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(secret string) (*models.APIKeyPrincipal, error)
+}
+
+func APIKeyMiddleware(keys APIKeyAuthenticator, fallback handler.Middleware) handler.Middleware {
+	return &apiKeyMiddleware{keys: keys, fallback: fallback}
+}
+
+type apiKeyMiddleware struct {
+	keys     APIKeyAuthenticator
+	fallback handler.Middleware
+}
+
+func (m *apiKeyMiddleware) Handle(next func(handler.Context)) func(handler.Context) {
+	jwt := m.fallback.Handle(next)
+
+	return func(c handler.Context) {
+		header, _ := c.Get("Authorization")
+		secret, _ := header.(string)
+		secret = strings.TrimPrefix(secret, "Bearer ")
+		if !strings.HasPrefix(secret, models.APIKeyPrefix) {
+			jwt(c)
+			return
+		}
+
+		principal, err := m.keys.AuthenticateAPIKey(secret)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, map[string]string{"Errors": "недействительный API-ключ"})
+			return
+		}
+
+		log.Printf("Сервисный аккаунт %s (ключ %s): %s %s", principal.Account, principal.Prefix, c.Method(), c.Path())
+
+		c.Set("username", principal.Account)
+		c.Set("role", models.RoleService)
+		c.Set("scopes", principal.Scopes)
+		c.Set("apiKeyID", principal.KeyID)
+		next(c)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/models"
+)
+
+func NewAuthorizer() handler.Authorizer {
+	return &authorizer{}
+}
+
+type authorizer struct{}
+
+func (a *authorizer) Require(permission string) handler.Middleware {
+	return &permissionMiddleware{permission: permission}
+}
+
+type permissionMiddleware struct {
+	permission string
+}
+
+func (m *permissionMiddleware) Handle(next func(handler.Context)) func(handler.Context) {
+	return func(c handler.Context) {
+		if !m.allows(c) {
+			c.JSON(http.StatusForbidden, map[string]string{"Errors": "недостаточно прав"})
+			return
+		}
+		next(c)
+	}
+}
+
+func (m *permissionMiddleware) allows(c handler.Context) bool {
+	if scopes, ok := c.Get("scopes"); ok {
+		granted, _ := scopes.([]string)
+		for _, scope := range granted {
+			if scope == m.permission {
+				return true
+			}
+		}
+		return false
+	}
+
+	role, _ := c.Get("role")
+	name, ok := role.(string)
+	return ok && models.HasPermission(name, m.permission)
+}
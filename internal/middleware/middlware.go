@@ -3,23 +3,29 @@ package middleware
 import (
 	"net/http"
 	"strings"
-
-	"github.com/dgrijalva/jwt-go"
+	"time"
 
 	"avito-shop-test/internal/handler"
+	"avito-shop-test/internal/models"
+	"avito-shop-test/internal/token"
 )
 
-type TokenClaims struct {
-	Username string `json:"username"`
-	jwt.StandardClaims
+type TokenVerifier interface {
+	Verify(tokenString string) (*token.Claims, error)
+}
+
+type TokenStore interface {
+	IsRevoked(jti string) (bool, error)
+	TouchSession(id, ip string, now time.Time) (bool, error)
 }
 
-func AuthMiddleware(jwtKey []byte) handler.Middleware {
-	return &authMidleware{jwtKey: jwtKey}
+func AuthMiddleware(verifier TokenVerifier, tokens TokenStore) handler.Middleware {
+	return &authMidleware{verifier: verifier, tokens: tokens}
 }
 
 type authMidleware struct {
-	jwtKey []byte
+	verifier TokenVerifier
+	tokens   TokenStore
 }
 
 func (m *authMidleware) Handle(next func(handler.Context)) func(handler.Context) {
@@ -32,18 +38,44 @@ func (m *authMidleware) Handle(next func(handler.Context)) func(handler.Context)
 		}
 
 		tokenString = strings.TrimPrefix(tokenString.(string), "Bearer ")
+		if strings.HasPrefix(tokenString.(string), models.APIKeyPrefix) {
+			c.JSON(http.StatusForbidden, map[string]string{"Errors": "API-ключ не даёт доступа к этому маршруту"})
+			return
+		}
 
-		claims := &TokenClaims{}
-		token, err := jwt.ParseWithClaims(tokenString.(string), claims, func(token *jwt.Token) (interface{}, error) {
-			return m.jwtKey, nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := m.verifier.Verify(tokenString.(string))
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, map[string]string{"Errors": "некорректный токен"})
 			return
 		}
 
+		revoked, err := m.tokens.IsRevoked(claims.Id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, map[string]string{"Errors": "не удалось проверить токен"})
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, map[string]string{"Errors": "токен отозван"})
+			return
+		}
+
+		if claims.SessionID != "" {
+			active, err := m.tokens.TouchSession(claims.SessionID, c.ClientIP(), time.Now())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, map[string]string{"Errors": "не удалось проверить сессию"})
+				return
+			}
+			if !active {
+				c.JSON(http.StatusUnauthorized, map[string]string{"Errors": "сессия завершена"})
+				return
+			}
+		}
+
 		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+		c.Set("jti", claims.Id)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("tokenExpiresAt", time.Unix(claims.ExpiresAt, 0))
 		next(c)
 	}
 }
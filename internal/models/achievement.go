@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	MetricTransfersSent      = "transfers_sent"
+	MetricDistinctRecipients = "distinct_recipients"
+	MetricCoinsSent          = "coins_sent"
+	MetricTransfersReceived  = "transfers_received"
+	MetricCoinsReceived      = "coins_received"
+	MetricItemsBought        = "items_bought"
+	MetricDistinctItems      = "distinct_items"
+	MetricCatalogPercent     = "catalog_percent"
+)
+
+type AchievementRule struct {
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Metric      string `json:"metric"`
+	Threshold   int    `json:"threshold"`
+}
+
+type ActivityMetrics struct {
+	TransfersSent      int `gorm:"column:transfers_sent"`
+	DistinctRecipients int `gorm:"column:distinct_recipients"`
+	CoinsSent          int `gorm:"column:coins_sent"`
+	TransfersReceived  int `gorm:"column:transfers_received"`
+	CoinsReceived      int `gorm:"column:coins_received"`
+	ItemsBought        int `gorm:"column:items_bought"`
+	DistinctItems      int `gorm:"column:distinct_items"`
+	CatalogSize        int `gorm:"column:catalog_size"`
+}
+
+type UserBadge struct {
+	UserID    string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Code      string    `gorm:"column:code;primaryKey"`
+	AwardedAt time.Time `gorm:"column:awarded_at"`
+}
+
+func (UserBadge) TableName() string {
+	return "user_badges"
+}
+
+type BadgeInfo struct {
+	Code        string    `json:"code"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	AwardedAt   time.Time `json:"awardedAt"`
+}
+
+type UserBadges struct {
+	Username string      `json:"username"`
+	Badges   []BadgeInfo `json:"badges"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const (
+	AuctionStatusOpen      = "open"
+	AuctionStatusSold      = "sold"
+	AuctionStatusUnsold    = "unsold"
+	AuctionStatusCancelled = "cancelled"
+
+	BidStatusHeld     = "held"
+	BidStatusReleased = "released"
+	BidStatusCaptured = "captured"
+)
+
+type Auction struct {
+	ID           string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	ItemName     string     `gorm:"column:item_name"`
+	SKU          string     `gorm:"column:sku"`
+	Variant      string     `gorm:"column:variant"`
+	ReservePrice int        `gorm:"column:reserve_price"`
+	MinIncrement int        `gorm:"column:min_increment"`
+	StartsAt     time.Time  `gorm:"column:starts_at"`
+	EndsAt       time.Time  `gorm:"column:ends_at"`
+	Status       string     `gorm:"column:status"`
+	TopBid       int        `gorm:"column:top_bid"`
+	TopBidderID  *string    `gorm:"column:top_bidder_id;type:uuid"`
+	CreatedBy    string     `gorm:"column:created_by;type:uuid"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	ClosedAt     *time.Time `gorm:"column:closed_at"`
+}
+
+func (Auction) TableName() string {
+	return "auctions"
+}
+
+type Bid struct {
+	ID        string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	AuctionID string    `gorm:"column:auction_id;type:uuid"`
+	BidderID  string    `gorm:"column:bidder_id;type:uuid"`
+	Amount    int       `gorm:"column:amount"`
+	Status    string    `gorm:"column:status"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (Bid) TableName() string {
+	return "bids"
+}
+
+type CreateAuctionRequest struct {
+	Item         string    `json:"item" binding:"required"`
+	SKU          string    `json:"sku"`
+	ReservePrice int       `json:"reservePrice"`
+	MinIncrement int       `json:"minIncrement"`
+	StartsAt     time.Time `json:"startsAt" binding:"required"`
+	EndsAt       time.Time `json:"endsAt" binding:"required"`
+}
+
+type PlaceBidRequest struct {
+	Amount int `json:"amount" binding:"required"`
+}
+
+type AuctionInfo struct {
+	ID           string     `json:"id"`
+	ItemName     string     `json:"type"`
+	SKU          string     `json:"sku,omitempty"`
+	Variant      string     `json:"variant,omitempty"`
+	MinIncrement int        `json:"minIncrement"`
+	StartsAt     time.Time  `json:"startsAt"`
+	EndsAt       time.Time  `json:"endsAt"`
+	Status       string     `json:"status"`
+	TopBid       int        `json:"topBid"`
+	TopBidder    string     `json:"topBidder,omitempty"`
+	ReserveMet   bool       `json:"reserveMet"`
+	Bids         []BidInfo  `json:"bids,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+}
+
+type BidInfo struct {
+	Bidder    string    `json:"bidder"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type AuditRecord struct {
+	ID         string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	ActorID    string    `gorm:"column:actor_id;type:uuid"`
+	Action     string    `gorm:"column:action"`
+	EntityType string    `gorm:"column:entity_type"`
+	EntityID   string    `gorm:"column:entity_id"`
+	Details    string    `gorm:"column:details"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (AuditRecord) TableName() string {
+	return "audit_log"
+}
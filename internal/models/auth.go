@@ -1,14 +1,224 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
 type AuthRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
 
 type AuthResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	Challenge    string `json:"challenge,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+	All          bool   `json:"all"`
 }
 
 type ErrorResponse struct {
 	Errors string `json:"errors"`
 }
+
+type RefreshToken struct {
+	ID        string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	UserID    string     `gorm:"column:user_id;type:uuid"`
+	FamilyID  string     `gorm:"column:family_id;type:uuid;default:uuid_generate_v4()"`
+	TokenHash string     `gorm:"column:token_hash"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+type Session struct {
+	ID         string     `gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	UserID     string     `gorm:"column:user_id;type:uuid"`
+	UserAgent  string     `gorm:"column:user_agent"`
+	IP         string     `gorm:"column:ip"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	LastSeenAt time.Time  `gorm:"column:last_seen_at"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+type RevokeSessionsRequest struct {
+	KeepCurrent bool `json:"keepCurrent"`
+}
+
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+type SigningKey struct {
+	KID         string     `gorm:"column:kid;primaryKey"`
+	Algorithm   string     `gorm:"column:algorithm"`
+	PrivateKey  string     `gorm:"column:private_key"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	ActivatesAt time.Time  `gorm:"column:activates_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+}
+
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+type UserIdentity struct {
+	Issuer    string    `gorm:"column:issuer;primaryKey"`
+	Subject   string    `gorm:"column:subject;primaryKey"`
+	UserID    string    `gorm:"column:user_id;type:uuid"`
+	Email     string    `gorm:"column:email"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+type SSOLoginState struct {
+	State        string    `gorm:"column:state;primaryKey"`
+	Nonce        string    `gorm:"column:nonce"`
+	CodeVerifier string    `gorm:"column:code_verifier"`
+	ExpiresAt    time.Time `gorm:"column:expires_at"`
+}
+
+func (SSOLoginState) TableName() string {
+	return "sso_login_states"
+}
+
+type SSOLoginResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+type LoginAttempt struct {
+	Key           string     `gorm:"column:key;primaryKey"`
+	Failures      int        `gorm:"column:failures"`
+	LastFailureAt time.Time  `gorm:"column:last_failure_at"`
+	BlockedUntil  *time.Time `gorm:"column:blocked_until"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	seconds := int(e.RetryAfter.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return fmt.Sprintf("слишком много попыток входа, повторите через %d с", seconds)
+}
+
+type UnlockLoginRequest struct {
+	IP string `json:"ip"`
+}
+
+type RecoveryCode struct {
+	UserID   string     `gorm:"column:user_id;type:uuid;primaryKey"`
+	CodeHash string     `gorm:"column:code_hash;primaryKey"`
+	UsedAt   *time.Time `gorm:"column:used_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
+
+type MFAChallenge struct {
+	ID        string    `gorm:"column:id;primaryKey"`
+	UserID    string    `gorm:"column:user_id;type:uuid"`
+	Attempts  int       `gorm:"column:attempts"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (MFAChallenge) TableName() string {
+	return "mfa_challenges"
+}
+
+type TwoFactorLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
+
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recoveryCodes"`
+}
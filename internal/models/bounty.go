@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	BountyStatusOpen      = "open"
+	BountyStatusClaimed   = "claimed"
+	BountyStatusSubmitted = "submitted"
+	BountyStatusPaid      = "paid"
+	BountyStatusExpired   = "expired"
+	BountyStatusCancelled = "cancelled"
+)
+
+type Bounty struct {
+	ID             string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	CreatorID      string     `gorm:"column:creator_id;type:uuid"`
+	ClaimantID     *string    `gorm:"column:claimant_id;type:uuid"`
+	Title          string     `gorm:"column:title"`
+	Description    string     `gorm:"column:description"`
+	Reward         int        `gorm:"column:reward"`
+	Status         string     `gorm:"column:status"`
+	SubmissionNote string     `gorm:"column:submission_note"`
+	ReviewComment  string     `gorm:"column:review_comment"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	ClaimedAt      *time.Time `gorm:"column:claimed_at"`
+	CompletedAt    *time.Time `gorm:"column:completed_at"`
+}
+
+func (Bounty) TableName() string {
+	return "bounties"
+}
+
+type CreateBountyRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Reward      int    `json:"reward" binding:"required"`
+	Days        int    `json:"days"`
+}
+
+type SubmitBountyRequest struct {
+	Note string `json:"note"`
+}
+
+type ReviewBountyRequest struct {
+	Comment string `json:"comment"`
+}
+
+type BountyInfo struct {
+	ID             string     `json:"id"`
+	Creator        string     `json:"creator"`
+	Claimant       string     `json:"claimant,omitempty"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description,omitempty"`
+	Reward         int        `json:"reward"`
+	Status         string     `json:"status"`
+	SubmissionNote string     `json:"submissionNote,omitempty"`
+	ReviewComment  string     `json:"reviewComment,omitempty"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+}
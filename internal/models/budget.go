@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+const (
+	BudgetPeriodWeek  = "week"
+	BudgetPeriodMonth = "month"
+)
+
+type Budget struct {
+	ID           string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	Name         string    `gorm:"column:name"`
+	OwnerID      *string   `gorm:"column:owner_id;type:uuid"`
+	Owner        string    `gorm:"->;column:owner"`
+	Team         *string   `gorm:"column:team"`
+	Balance      int       `gorm:"column:balance"`
+	RefillAmount int       `gorm:"column:refill_amount"`
+	RefillPeriod string    `gorm:"column:refill_period"`
+	RefilledAt   time.Time `gorm:"column:refilled_at"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+type BudgetHolder struct {
+	BudgetID string `gorm:"column:budget_id;type:uuid;primaryKey"`
+	UserID   string `gorm:"column:user_id;type:uuid;primaryKey"`
+}
+
+func (BudgetHolder) TableName() string {
+	return "budget_holders"
+}
+
+type CreateBudgetRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	Owner        string   `json:"owner"`
+	Team         string   `json:"team"`
+	Holders      []string `json:"holders"`
+	RefillAmount int      `json:"refillAmount" binding:"required"`
+	RefillPeriod string   `json:"refillPeriod"`
+}
+
+type BudgetInfo struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Owner        string    `json:"owner,omitempty"`
+	Team         string    `json:"team,omitempty"`
+	Balance      int       `json:"balance"`
+	RefillAmount int       `json:"refillAmount"`
+	RefillPeriod string    `json:"refillPeriod"`
+	RefilledAt   time.Time `json:"refilledAt"`
+}
+
+type BudgetUsage struct {
+	PeriodStart time.Time `json:"periodStart" gorm:"column:period_start"`
+	Spent       int       `json:"spent" gorm:"column:spent"`
+	Transfers   int       `json:"transfers" gorm:"column:transfers"`
+	Recipients  int       `json:"recipients" gorm:"column:recipients"`
+}
+
+type BudgetReport struct {
+	Budget BudgetInfo    `json:"budget"`
+	Period string        `json:"period"`
+	Usage  []BudgetUsage `json:"usage"`
+}
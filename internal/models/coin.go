@@ -1,15 +1,24 @@
 package models
 
+import "time"
+
 type SendCoinRequest struct {
-	ToUser string `json:"toUser,omitempty" binding:"required"`
-	Amount int    `json:"amount,omitempty" binding:"required"`
+	ToUser  string `json:"toUser,omitempty" binding:"required"`
+	Amount  int    `json:"amount,omitempty" binding:"required"`
+	Message string `json:"message,omitempty"`
+	Public  bool   `json:"public,omitempty"`
 }
 
 type CoinTransaction struct {
-	ID       string `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
-	FromUser string `gorm:"column:from_user_id;type:uuid"`
-	ToUser   string `gorm:"column:to_user_id;type:uuid"`
-	Amount   int    `gorm:"column:amount"`
+	ID         string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	FromUser   string    `gorm:"column:from_user_id;type:uuid"`
+	ToUser     string    `gorm:"column:to_user_id;type:uuid"`
+	Amount     int       `gorm:"column:amount"`
+	Message    string    `gorm:"column:message"`
+	Public     bool      `gorm:"column:is_public"`
+	BudgetID   *string   `gorm:"column:budget_id;type:uuid"`
+	ReversalOf *string   `gorm:"column:reversal_of;type:uuid"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
 }
 
 type CoinHistory struct {
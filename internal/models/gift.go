@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+const (
+	GiftStatusDelivered = "delivered"
+	GiftStatusDeclined  = "declined"
+)
+
+type Gift struct {
+	ID          string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	FromUser    string     `gorm:"column:from_user_id;type:uuid"`
+	ToUser      string     `gorm:"column:to_user_id;type:uuid"`
+	InventoryID string     `gorm:"column:inventory_id;type:uuid"`
+	ItemType    string     `gorm:"column:item_type"`
+	SKU         string     `gorm:"column:sku"`
+	Variant     string     `gorm:"column:variant"`
+	Amount      int        `gorm:"column:amount"`
+	Message     string     `gorm:"column:message"`
+	Status      string     `gorm:"column:status"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	DeclinedAt  *time.Time `gorm:"column:declined_at"`
+}
+
+func (Gift) TableName() string {
+	return "gifts"
+}
+
+type SendGiftRequest struct {
+	ToUser    string `json:"toUser" binding:"required"`
+	Item      string `json:"item" binding:"required"`
+	SKU       string `json:"sku"`
+	PromoCode string `json:"promoCode"`
+	Message   string `json:"message"`
+}
+
+type GiftHistory struct {
+	Received []GiftInfo `json:"received"`
+	Sent     []GiftInfo `json:"sent"`
+}
+
+type GiftInfo struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	ItemName  string    `json:"type"`
+	Variant   string    `json:"variant,omitempty"`
+	Amount    int       `json:"amount"`
+	Message   string    `json:"message,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+type KudosReaction struct {
+	TransactionID string    `gorm:"column:transaction_id;type:uuid;primaryKey"`
+	UserID        string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Emoji         string    `gorm:"column:emoji;primaryKey"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (KudosReaction) TableName() string {
+	return "kudos_reactions"
+}
+
+type KudosComment struct {
+	ID            string    `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	TransactionID string    `json:"-" gorm:"column:transaction_id;type:uuid"`
+	UserID        string    `json:"-" gorm:"column:user_id;type:uuid"`
+	Username      string    `json:"username" gorm:"->;column:username"`
+	Text          string    `json:"text" gorm:"column:text"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (KudosComment) TableName() string {
+	return "kudos_comments"
+}
+
+type KudosEntry struct {
+	ID            string         `json:"id"`
+	Sender        string         `json:"sender"`
+	Recipient     string         `json:"recipient"`
+	Amount        int            `json:"amount"`
+	Message       string         `json:"message,omitempty"`
+	Reactions     map[string]int `json:"reactions" gorm:"-"`
+	CommentsCount int            `json:"commentsCount"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+type KudosFilter struct {
+	Team   string
+	Limit  int
+	Offset int
+}
+
+type ReactionCount struct {
+	TransactionID string
+	Emoji         string
+	Count         int
+}
+
+type KudosReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+type KudosCommentRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+type PrivacySettings struct {
+	PublicKudos          bool `json:"publicKudos"`
+	HideFromLeaderboards bool `json:"hideFromLeaderboards"`
+}
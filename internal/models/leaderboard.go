@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	LeaderboardGivers    = "givers"
+	LeaderboardReceivers = "receivers"
+	LeaderboardThanked   = "thanked"
+
+	LeaderboardPeriodWeek  = "week"
+	LeaderboardPeriodMonth = "month"
+	LeaderboardPeriodAll   = "all"
+)
+
+type LeaderboardEntry struct {
+	Board       string    `gorm:"column:board;primaryKey"`
+	Period      string    `gorm:"column:period;primaryKey"`
+	UserID      string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Username    string    `gorm:"->;column:username"`
+	Score       int       `gorm:"column:score"`
+	RefreshedAt time.Time `gorm:"column:refreshed_at"`
+}
+
+func (LeaderboardEntry) TableName() string {
+	return "leaderboard_entries"
+}
+
+type Leaderboard struct {
+	Board       string           `json:"board"`
+	Period      string           `json:"period"`
+	RefreshedAt *time.Time       `json:"refreshedAt,omitempty"`
+	Entries     []LeaderboardRow `json:"entries"`
+}
+
+type LeaderboardRow struct {
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
+	Score    int    `json:"score"`
+}
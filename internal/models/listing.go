@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	ListingStatusActive    = "active"
+	ListingStatusSold      = "sold"
+	ListingStatusCancelled = "cancelled"
+	ListingStatusExpired   = "expired"
+)
+
+type Listing struct {
+	ID          string     `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	SellerID    string     `json:"-" gorm:"column:seller_id;type:uuid"`
+	BuyerID     *string    `json:"-" gorm:"column:buyer_id;type:uuid"`
+	InventoryID string     `json:"inventoryId" gorm:"column:inventory_id;type:uuid"`
+	ItemType    string     `json:"type" gorm:"column:item_type"`
+	SKU         string     `json:"sku,omitempty" gorm:"column:sku"`
+	Variant     string     `json:"variant,omitempty" gorm:"column:variant"`
+	Price       int        `json:"price" gorm:"column:price"`
+	Status      string     `json:"status" gorm:"column:status"`
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"column:expires_at"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"column:created_at"`
+	SoldAt      *time.Time `json:"soldAt,omitempty" gorm:"column:sold_at"`
+}
+
+func (Listing) TableName() string {
+	return "listings"
+}
+
+type ListingInfo struct {
+	ID        string    `json:"id"`
+	Seller    string    `json:"seller"`
+	ItemType  string    `json:"type"`
+	SKU       string    `json:"sku,omitempty"`
+	Variant   string    `json:"variant,omitempty"`
+	Price     int       `json:"price"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ListingFilter struct {
+	Query    string
+	Seller   string
+	MinPrice int
+	MaxPrice int
+	Limit    int
+	Offset   int
+}
+
+type CreateListingRequest struct {
+	InventoryID string `json:"inventoryId" binding:"required"`
+	Price       int    `json:"price" binding:"required"`
+	Days        int    `json:"days"`
+}
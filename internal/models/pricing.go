@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+type PriceChange struct {
+	ID          string    `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	ItemName    string    `json:"item" gorm:"column:item_name"`
+	Price       int       `json:"price" gorm:"column:price"`
+	EffectiveAt time.Time `json:"effectiveAt" gorm:"column:effective_at"`
+	CreatedBy   string    `json:"-" gorm:"column:created_by;type:uuid"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (PriceChange) TableName() string {
+	return "price_changes"
+}
+
+type Promotion struct {
+	ID         string    `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	Name       string    `json:"name" gorm:"column:name"`
+	ItemName   string    `json:"item,omitempty" gorm:"column:item_name"`
+	PercentOff int       `json:"percentOff" gorm:"column:percent_off"`
+	StartsAt   time.Time `json:"startsAt" gorm:"column:starts_at"`
+	EndsAt     time.Time `json:"endsAt" gorm:"column:ends_at"`
+	CreatedBy  string    `json:"-" gorm:"column:created_by;type:uuid"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+type PromoCode struct {
+	Code       string     `json:"code" gorm:"column:code;primaryKey"`
+	ItemName   string     `json:"item,omitempty" gorm:"column:item_name"`
+	PercentOff int        `json:"percentOff" gorm:"column:percent_off"`
+	MaxUses    int        `json:"maxUses" gorm:"column:max_uses"`
+	Uses       int        `json:"uses" gorm:"column:uses"`
+	UserID     *string    `json:"-" gorm:"column:user_id;type:uuid"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	CreatedBy  string     `json:"-" gorm:"column:created_by;type:uuid"`
+}
+
+func (PromoCode) TableName() string {
+	return "promo_codes"
+}
+
+type PriceQuote struct {
+	Item      string   `json:"item"`
+	SKU       string   `json:"sku,omitempty"`
+	BasePrice int      `json:"basePrice"`
+	Price     int      `json:"price"`
+	Discounts []string `json:"discounts,omitempty"`
+}
+
+type BuyRequest struct {
+	Item      string
+	SKU       string
+	PromoCode string
+}
+
+type SchedulePriceRequest struct {
+	Price       int    `json:"price" binding:"required"`
+	EffectiveAt string `json:"effectiveAt" binding:"required"`
+}
+
+type CreatePromotionRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Item       string `json:"item"`
+	PercentOff int    `json:"percentOff" binding:"required"`
+	StartsAt   string `json:"startsAt" binding:"required"`
+	EndsAt     string `json:"endsAt" binding:"required"`
+}
+
+type CreatePromoCodeRequest struct {
+	Code       string `json:"code" binding:"required"`
+	Item       string `json:"item"`
+	PercentOff int    `json:"percentOff" binding:"required"`
+	MaxUses    int    `json:"maxUses"`
+	Username   string `json:"username"`
+	ExpiresAt  string `json:"expiresAt"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+const (
+	RaffleStatusOpen      = "open"
+	RaffleStatusDrawn     = "drawn"
+	RaffleStatusCancelled = "cancelled"
+
+	TicketStatusActive   = "active"
+	TicketStatusWon      = "won"
+	TicketStatusRefunded = "refunded"
+)
+
+type Raffle struct {
+	ID                string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	Title             string     `gorm:"column:title"`
+	ItemName          string     `gorm:"column:item_name"`
+	SKU               string     `gorm:"column:sku"`
+	Variant           string     `gorm:"column:variant"`
+	TicketPrice       int        `gorm:"column:ticket_price"`
+	MaxTickets        int        `gorm:"column:max_tickets"`
+	MaxTicketsPerUser int        `gorm:"column:max_tickets_per_user"`
+	Winners           int        `gorm:"column:winners"`
+	TicketsSold       int        `gorm:"column:tickets_sold"`
+	DrawAt            time.Time  `gorm:"column:draw_at"`
+	Status            string     `gorm:"column:status"`
+	Seed              string     `gorm:"column:seed"`
+	SeedHash          string     `gorm:"column:seed_hash"`
+	CreatedBy         string     `gorm:"column:created_by;type:uuid"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	DrawnAt           *time.Time `gorm:"column:drawn_at"`
+}
+
+func (Raffle) TableName() string {
+	return "raffles"
+}
+
+type RaffleTicket struct {
+	ID        string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	RaffleID  string    `gorm:"column:raffle_id;type:uuid"`
+	UserID    string    `gorm:"column:user_id;type:uuid"`
+	Number    int       `gorm:"column:number"`
+	Status    string    `gorm:"column:status"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (RaffleTicket) TableName() string {
+	return "raffle_tickets"
+}
+
+type CreateRaffleRequest struct {
+	Title             string    `json:"title" binding:"required"`
+	Item              string    `json:"item" binding:"required"`
+	SKU               string    `json:"sku"`
+	TicketPrice       int       `json:"ticketPrice" binding:"required"`
+	MaxTickets        int       `json:"maxTickets"`
+	MaxTicketsPerUser int       `json:"maxTicketsPerUser"`
+	Winners           int       `json:"winners"`
+	DrawAt            time.Time `json:"drawAt" binding:"required"`
+}
+
+type BuyTicketsRequest struct {
+	Count int `json:"count" binding:"required"`
+}
+
+type RaffleInfo struct {
+	ID                string         `json:"id"`
+	Title             string         `json:"title"`
+	ItemName          string         `json:"type"`
+	SKU               string         `json:"sku,omitempty"`
+	Variant           string         `json:"variant,omitempty"`
+	TicketPrice       int            `json:"ticketPrice"`
+	MaxTickets        int            `json:"maxTickets,omitempty"`
+	MaxTicketsPerUser int            `json:"maxTicketsPerUser,omitempty"`
+	Winners           int            `json:"winners"`
+	TicketsSold       int            `json:"ticketsSold"`
+	DrawAt            time.Time      `json:"drawAt"`
+	Status            string         `json:"status"`
+	SeedHash          string         `json:"seedHash"`
+	Seed              string         `json:"seed,omitempty"`
+	Results           []RaffleResult `json:"results,omitempty"`
+	DrawnAt           *time.Time     `json:"drawnAt,omitempty"`
+}
+
+type RaffleResult struct {
+	Place        int    `json:"place"`
+	TicketNumber int    `json:"ticketNumber"`
+	Username     string `json:"username"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	ReturnStatusPending  = "pending"
+	ReturnStatusApproved = "approved"
+	ReturnStatusRejected = "rejected"
+)
+
+type ReturnRequest struct {
+	ID          string     `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	InventoryID string     `json:"inventoryId" gorm:"column:inventory_id;type:uuid"`
+	UserID      string     `json:"-" gorm:"column:user_id;type:uuid"`
+	ItemType    string     `json:"type" gorm:"column:item_type"`
+	Quantity    int        `json:"quantity" gorm:"column:quantity"`
+	Amount      int        `json:"amount" gorm:"column:amount"`
+	Reason      string     `json:"reason" gorm:"column:reason"`
+	Status      string     `json:"status" gorm:"column:status"`
+	ReviewedBy  *string    `json:"-" gorm:"column:reviewed_by;type:uuid"`
+	Comment     string     `json:"comment,omitempty" gorm:"column:comment"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"column:created_at"`
+	ReviewedAt  *time.Time `json:"reviewedAt,omitempty" gorm:"column:reviewed_at"`
+}
+
+func (ReturnRequest) TableName() string {
+	return "returns"
+}
+
+type CreateReturnRequest struct {
+	InventoryID string `json:"inventoryId" binding:"required"`
+	Reason      string `json:"reason"`
+}
+
+type ReviewReturnRequest struct {
+	Comment string `json:"comment"`
+}
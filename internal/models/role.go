@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const (
+	RoleEmployee = "employee"
+	RoleStaff    = "staff"
+	RoleAdmin    = "admin"
+	RoleService  = "service"
+)
+
+const (
+	PermStoreManage  = "store:manage"
+	PermCatalogRead  = "catalog:read"
+	PermCatalogEdit  = "catalog:edit"
+	PermCoinsGrant   = "coins:grant"
+	PermCoinsReverse = "coins:reverse"
+	PermUsersManage  = "users:manage"
+	PermAuditView    = "audit:view"
+)
+
+var rolePermissions = map[string][]string{
+	RoleEmployee: {PermCatalogRead},
+	RoleStaff:    {PermCatalogRead, PermStoreManage},
+	RoleAdmin:    {PermCatalogRead, PermStoreManage, PermCatalogEdit, PermCoinsGrant, PermCoinsReverse, PermUsersManage, PermAuditView},
+}
+
+var serviceScopes = []string{PermCatalogRead, PermCatalogEdit, PermCoinsGrant, PermCoinsReverse, PermAuditView}
+
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func ValidScope(scope string) bool {
+	return contains(serviceScopes, scope)
+}
+
+func HasPermission(role, permission string) bool {
+	if role == RoleService {
+		return contains(serviceScopes, permission)
+	}
+	return contains(rolePermissions[role], permission)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SaveItemRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Price       int    `json:"price" binding:"required"`
+	HasVariants bool   `json:"hasVariants"`
+}
+
+type GrantRequest struct {
+	ToUser string `json:"toUser" binding:"required"`
+	Amount int    `json:"amount" binding:"required"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type ReverseRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type AuditEntry struct {
+	ID         string    `json:"id"`
+	Actor      string    `json:"actor,omitempty"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entityType"`
+	EntityID   string    `json:"entityId"`
+	Details    string    `json:"details,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
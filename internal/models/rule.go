@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	EligibilityLimitPerUser   = "limit_per_user_exceeded"
+	EligibilityLimitPerPeriod = "limit_per_period_exceeded"
+	EligibilityDepartment     = "department_not_allowed"
+	EligibilityTenure         = "tenure_too_short"
+)
+
+type ItemRule struct {
+	ItemName           string `json:"item" gorm:"column:item_name;primaryKey"`
+	MaxPerUser         *int   `json:"maxPerUser,omitempty" gorm:"column:max_per_user"`
+	MaxPerPeriod       *int   `json:"maxPerPeriod,omitempty" gorm:"column:max_per_period"`
+	PeriodDays         *int   `json:"periodDays,omitempty" gorm:"column:period_days"`
+	MinTenureMonths    *int   `json:"minTenureMonths,omitempty" gorm:"column:min_tenure_months"`
+	AllowedDepartments string `json:"allowedDepartments,omitempty" gorm:"column:allowed_departments"`
+}
+
+func (ItemRule) TableName() string {
+	return "item_rules"
+}
+
+func (r ItemRule) Departments() []string {
+	var departments []string
+	for _, department := range strings.Split(r.AllowedDepartments, ",") {
+		if department = strings.TrimSpace(department); department != "" {
+			departments = append(departments, department)
+		}
+	}
+	return departments
+}
+
+func (r ItemRule) CheckLimits(count func(since time.Time) (int, error), now time.Time) error {
+	if r.MaxPerUser != nil {
+		total, err := count(time.Time{})
+		if err != nil {
+			return err
+		}
+		if total >= *r.MaxPerUser {
+			return &EligibilityError{
+				Code:    EligibilityLimitPerUser,
+				Message: fmt.Sprintf("можно купить не больше %d шт. этого товара", *r.MaxPerUser),
+			}
+		}
+	}
+
+	if r.MaxPerPeriod != nil && r.PeriodDays != nil {
+		recent, err := count(now.AddDate(0, 0, -*r.PeriodDays))
+		if err != nil {
+			return err
+		}
+		if recent >= *r.MaxPerPeriod {
+			return &EligibilityError{
+				Code:    EligibilityLimitPerPeriod,
+				Message: fmt.Sprintf("можно купить не больше %d шт. за %d дн.", *r.MaxPerPeriod, *r.PeriodDays),
+			}
+		}
+	}
+
+	return nil
+}
+
+type EligibilityError struct {
+	Code    string
+	Message string
+}
+
+func (e *EligibilityError) Error() string {
+	return e.Message
+}
+
+type UpdateUserAttributesRequest struct {
+	Department string `json:"department"`
+	HiredAt    string `json:"hiredAt"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const APIKeyPrefix = "ask_"
+
+type APIKey struct {
+	ID         string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	UserID     string     `gorm:"column:user_id;type:uuid"`
+	Prefix     string     `gorm:"column:prefix"`
+	KeyHash    string     `gorm:"column:key_hash"`
+	Scopes     string     `gorm:"column:scopes"`
+	CreatedBy  string     `gorm:"column:created_by;type:uuid"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	Account    *User      `gorm:"foreignKey:UserID"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+func (k *APIKey) Active(at time.Time) bool {
+	if k.RevokedAt != nil && !k.RevokedAt.After(at) {
+		return false
+	}
+	return k.ExpiresAt == nil || k.ExpiresAt.After(at)
+}
+
+type APIKeyPrincipal struct {
+	KeyID   string
+	Prefix  string
+	Account string
+	Scopes  []string
+}
+
+type CreateServiceAccountRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CreateAPIKeyRequest struct {
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expiresInDays"`
+}
+
+type RotateAPIKeyRequest struct {
+	GraceMinutes int `json:"graceMinutes"`
+}
+
+type APIKeyInfo struct {
+	ID         string     `json:"id"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+type APIKeyResponse struct {
+	APIKeyInfo
+	Key string `json:"key"`
+}
+
+type ServiceAccount struct {
+	Name      string       `json:"name"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Keys      []APIKeyInfo `json:"keys"`
+}
@@ -1,8 +1,12 @@
 package models
 
+import "time"
+
 type Product struct {
-	Name  string `json:"name" gorm:"column:name"`
-	Price int    `json:"price" gorm:"column:price"`
+	Name        string    `json:"name" gorm:"column:name"`
+	Price       int       `json:"price" gorm:"column:price"`
+	HasVariants bool      `json:"hasVariants" gorm:"column:has_variants"`
+	Rule        *ItemRule `json:"rule,omitempty" gorm:"foreignKey:ItemName;references:Name"`
 }
 
 func (Product) TableName() string {
@@ -10,17 +14,62 @@ func (Product) TableName() string {
 }
 
 type Inventory struct {
-	ID       string `gorm:"type:uuid;default:uuid+generate_v4()"`
-	UserID   string `gorm:"column:user_id;type:uuid"`
-	ItemType string `gorm:"column:item_type"`
-	Quantity int    `gorm:"column:quantity;"`
+	ID        string    `gorm:"type:uuid;default:uuid+generate_v4()"`
+	UserID    string    `gorm:"column:user_id;type:uuid"`
+	ItemType  string    `gorm:"column:item_type"`
+	Quantity  int       `gorm:"column:quantity;"`
+	SKU       string    `gorm:"column:sku"`
+	Variant   string    `gorm:"column:variant"`
+	Price     *int      `gorm:"column:price"`
+	NoReturn  bool      `gorm:"column:no_return"`
+	CreatedAt time.Time `gorm:"column:created_at"`
 }
 
 func (Inventory) TableName() string {
 	return "inventory"
 }
 
+type Purchase struct {
+	ID          string     `gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	UserID      string     `gorm:"column:user_id;type:uuid"`
+	PayerID     *string    `gorm:"column:payer_id;type:uuid"`
+	InventoryID *string    `gorm:"column:inventory_id;type:uuid"`
+	ItemType    string     `gorm:"column:item_type"`
+	SKU         string     `gorm:"column:sku"`
+	Price       int        `gorm:"column:price"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	RefundedAt  *time.Time `gorm:"column:refunded_at"`
+}
+
+func (Purchase) TableName() string {
+	return "purchases"
+}
+
+type PurchaseOrder struct {
+	PayerID   string
+	Amount    int
+	PromoCode string
+	Inventory *Inventory
+}
+
 type PurchasedItem struct {
-	ItemName string `json:"type"`
-	Quantity int    `json:"quantity"`
+	ItemName string             `json:"type"`
+	Quantity int                `json:"quantity"`
+	Variants []PurchasedVariant `json:"variants,omitempty"`
+}
+
+type PurchaseLine struct {
+	ID          string    `json:"id"`
+	ItemName    string    `json:"type"`
+	SKU         string    `json:"sku,omitempty"`
+	Variant     string    `json:"variant,omitempty"`
+	Quantity    int       `json:"quantity"`
+	Price       int       `json:"price"`
+	PurchasedAt time.Time `json:"purchasedAt"`
+}
+
+type CatalogItem struct {
+	Name        string `json:"name"`
+	Price       int    `json:"price"`
+	HasVariants bool   `json:"hasVariants"`
 }
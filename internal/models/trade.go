@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+const (
+	TradeStatusPending   = "pending"
+	TradeStatusAccepted  = "accepted"
+	TradeStatusRejected  = "rejected"
+	TradeStatusCancelled = "cancelled"
+)
+
+type Trade struct {
+	ID                string      `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	ProposerID        string      `gorm:"column:proposer_id;type:uuid"`
+	CounterpartyID    string      `gorm:"column:counterparty_id;type:uuid"`
+	ProposerCoins     int         `gorm:"column:proposer_coins"`
+	CounterpartyCoins int         `gorm:"column:counterparty_coins"`
+	Message           string      `gorm:"column:message"`
+	Status            string      `gorm:"column:status"`
+	Items             []TradeItem `gorm:"foreignKey:TradeID"`
+	CreatedAt         time.Time   `gorm:"column:created_at"`
+	ResolvedAt        *time.Time  `gorm:"column:resolved_at"`
+}
+
+func (Trade) TableName() string {
+	return "trades"
+}
+
+type TradeItem struct {
+	ID          string `json:"-" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	TradeID     string `json:"-" gorm:"column:trade_id;type:uuid"`
+	InventoryID string `json:"inventoryId" gorm:"column:inventory_id;type:uuid"`
+	OwnerID     string `json:"-" gorm:"column:owner_id;type:uuid"`
+	ItemType    string `json:"type" gorm:"column:item_type"`
+	SKU         string `json:"sku,omitempty" gorm:"column:sku"`
+	Variant     string `json:"variant,omitempty" gorm:"column:variant"`
+	Quantity    int    `json:"quantity" gorm:"column:quantity"`
+}
+
+func (TradeItem) TableName() string {
+	return "trade_items"
+}
+
+type CreateTradeRequest struct {
+	ToUser       string   `json:"toUser" binding:"required"`
+	OfferItems   []string `json:"offerItems"`
+	OfferCoins   int      `json:"offerCoins"`
+	RequestItems []string `json:"requestItems"`
+	RequestCoins int      `json:"requestCoins"`
+	Message      string   `json:"message"`
+}
+
+type TradeSide struct {
+	Username string      `json:"username"`
+	Items    []TradeItem `json:"items"`
+	Coins    int         `json:"coins"`
+}
+
+type TradeInfo struct {
+	ID         string     `json:"id"`
+	Offer      TradeSide  `json:"offer"`
+	Request    TradeSide  `json:"request"`
+	Message    string     `json:"message,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+type TradeHistory struct {
+	Incoming []TradeInfo `json:"incoming"`
+	Outgoing []TradeInfo `json:"outgoing"`
+}
@@ -1,10 +1,22 @@
 package models
 
+import "time"
+
 type User struct {
-	ID       string `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
-	Username string `json:"username,omitempty" gorm:"column:username"`
-	Password string `json:"password,omitempty" gorm:"column:password"`
-	Balance  int    `gorm:"column:balance"`
+	ID                   string     `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	Username             string     `json:"username,omitempty" gorm:"column:username"`
+	Password             string     `json:"password,omitempty" gorm:"column:password"`
+	Balance              int        `gorm:"column:balance"`
+	Role                 string     `gorm:"column:role"`
+	Email                *string    `gorm:"column:email"`
+	Department           string     `gorm:"column:department"`
+	HiredAt              *time.Time `gorm:"column:hired_at"`
+	PublicKudos          bool       `gorm:"column:public_kudos"`
+	HideFromLeaderboards bool       `gorm:"column:hide_from_leaderboards"`
+	ServiceAccount       bool       `gorm:"column:service_account"`
+	TOTPSecret           string     `json:"-" gorm:"column:totp_secret"`
+	TOTPEnabled          bool       `gorm:"column:totp_enabled"`
+	CreatedAt            time.Time  `gorm:"column:created_at"`
 }
 
 type UserInfo struct {
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+type ItemOption struct {
+	ItemName string `json:"-" gorm:"column:item_name"`
+	Name     string `json:"name" gorm:"column:name"`
+	Value    string `json:"value" gorm:"column:value"`
+}
+
+func (ItemOption) TableName() string {
+	return "item_options"
+}
+
+type SKU struct {
+	ID       string      `json:"-" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	Code     string      `json:"sku" gorm:"column:code"`
+	ItemName string      `json:"-" gorm:"column:item_name"`
+	Stock    int         `json:"stock" gorm:"column:stock"`
+	Price    *int        `json:"price,omitempty" gorm:"column:price"`
+	Options  []SKUOption `json:"options" gorm:"foreignKey:SKUID"`
+}
+
+func (SKU) TableName() string {
+	return "item_skus"
+}
+
+func (s SKU) Description() string {
+	parts := make([]string, 0, len(s.Options))
+	for _, option := range s.Options {
+		parts = append(parts, option.Name+": "+option.Value)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+type SKUOption struct {
+	SKUID string `json:"-" gorm:"column:sku_id;type:uuid"`
+	Name  string `json:"name" gorm:"column:name"`
+	Value string `json:"value" gorm:"column:value"`
+}
+
+func (SKUOption) TableName() string {
+	return "item_sku_options"
+}
+
+type ItemVariants struct {
+	Item    string              `json:"item"`
+	Price   int                 `json:"price"`
+	Options map[string][]string `json:"options"`
+	SKUs    []SKU               `json:"skus"`
+}
+
+type AddItemOptionRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Values []string `json:"values" binding:"required"`
+}
+
+type CreateSKURequest struct {
+	Code    string            `json:"sku" binding:"required"`
+	Options map[string]string `json:"options" binding:"required"`
+	Stock   int               `json:"stock"`
+	Price   *int              `json:"price"`
+}
+
+type AdjustStockRequest struct {
+	Delta int `json:"delta" binding:"required"`
+}
+
+type PurchasedVariant struct {
+	SKU      string `json:"sku"`
+	Variant  string `json:"variant"`
+	Quantity int    `json:"quantity"`
+}
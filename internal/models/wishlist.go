@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+const (
+	WishlistStatusWished  = "wished"
+	WishlistStatusFunding = "funding"
+	WishlistStatusFunded  = "funded"
+	WishlistStatusExpired = "expired"
+
+	ContributionStatusHeld     = "held"
+	ContributionStatusCaptured = "captured"
+	ContributionStatusRefunded = "refunded"
+)
+
+type Wishlist struct {
+	UserID     string `gorm:"column:user_id;type:uuid;primaryKey"`
+	ShareToken string `gorm:"column:share_token"`
+}
+
+func (Wishlist) TableName() string {
+	return "wishlists"
+}
+
+type WishlistItem struct {
+	ID        string     `json:"id" gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	UserID    string     `json:"-" gorm:"column:user_id;type:uuid"`
+	ItemName  string     `json:"type" gorm:"column:item_name"`
+	SKU       string     `json:"sku,omitempty" gorm:"column:sku"`
+	Target    int        `json:"target,omitempty" gorm:"column:target"`
+	Funded    int        `json:"funded" gorm:"column:funded"`
+	Status    string     `json:"status" gorm:"column:status"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (WishlistItem) TableName() string {
+	return "wishlist_items"
+}
+
+type Contribution struct {
+	ID             string    `gorm:"column:id;type:uuid;default:uuid+generate_v4()"`
+	WishlistItemID string    `gorm:"column:wishlist_item_id;type:uuid"`
+	ContributorID  string    `gorm:"column:contributor_id;type:uuid"`
+	Amount         int       `gorm:"column:amount"`
+	Status         string    `gorm:"column:status"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+}
+
+func (Contribution) TableName() string {
+	return "contributions"
+}
+
+type WishlistView struct {
+	Owner      string         `json:"owner"`
+	ShareToken string         `json:"shareToken,omitempty"`
+	Items      []WishlistItem `json:"items"`
+}
+
+type AddWishlistItemRequest struct {
+	Item string `json:"item" binding:"required"`
+	SKU  string `json:"sku"`
+}
+
+type StartCampaignRequest struct {
+	Days int `json:"days" binding:"required"`
+}
+
+type ContributeRequest struct {
+	Amount int `json:"amount" binding:"required"`
+}
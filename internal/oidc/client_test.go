@@ -0,0 +1,130 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"avito-shop-test/internal/oidc/oidctest"
+)
+
+const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func challenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func newClient(idp *oidctest.Server) *Client {
+	return NewClient(Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://shop.local/api/oidc/callback",
+	}, nil)
+}
+
+func TestExchange_Success(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42", Email: "ivan.petrov@corp.example", EmailVerified: true, Name: "Иван Петров"})
+	client := newClient(idp)
+
+	authURL, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+
+	code, state, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", state)
+
+	identity, err := client.Exchange(code, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, idp.Issuer(), identity.Issuer)
+	assert.Equal(t, "emp-42", identity.Subject)
+	assert.Equal(t, "ivan.petrov@corp.example", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "nonce-1", identity.Nonce)
+}
+
+func TestExchange_WrongVerifier(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42"})
+	client := newClient(idp)
+
+	authURL, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	require.NoError(t, err)
+	code, _, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = client.Exchange(code, "another-verifier-another-verifier-another-v")
+	assert.ErrorContains(t, err, "invalid_grant")
+}
+
+func TestExchange_CodeIsSingleUse(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42"})
+	client := newClient(idp)
+
+	authURL, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	require.NoError(t, err)
+	code, _, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = client.Exchange(code, verifier)
+	require.NoError(t, err)
+	_, err = client.Exchange(code, verifier)
+	assert.ErrorContains(t, err, "invalid_grant")
+}
+
+func TestExchange_RejectsForeignAudience(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.Audience = "another-app"
+	idp.SignIn(oidctest.Identity{Subject: "emp-42"})
+	client := newClient(idp)
+
+	authURL, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	require.NoError(t, err)
+	code, _, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = client.Exchange(code, verifier)
+	assert.EqualError(t, err, "id_token выпущен для другого клиента")
+}
+
+func TestExchange_RejectsExpiredIDToken(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.IDTokenTTL = -10 * time.Minute
+	idp.SignIn(oidctest.Identity{Subject: "emp-42"})
+	client := newClient(idp)
+
+	authURL, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	require.NoError(t, err)
+	code, _, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = client.Exchange(code, verifier)
+	assert.ErrorContains(t, err, "срок действия id_token истёк")
+}
+
+func TestAuthCodeURL_RejectsIssuerMismatch(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+
+	client := NewClient(Config{Issuer: idp.Issuer() + "/", ClientID: "shop"}, nil)
+
+	_, err := client.AuthCodeURL("state-1", "nonce-1", challenge(verifier))
+	assert.ErrorContains(t, err, "провайдер назвал себя")
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+const activityMetricsQuery = `
+	SELECT
+		(SELECT COUNT(*) FROM transactions WHERE from_user_id = @user AND budget_id IS NULL) AS transfers_sent,
+		(SELECT COUNT(DISTINCT to_user_id) FROM transactions WHERE from_user_id = @user AND budget_id IS NULL) AS distinct_recipients,
+		(SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE from_user_id = @user AND budget_id IS NULL) AS coins_sent,
+		(SELECT COUNT(*) FROM transactions WHERE to_user_id = @user) AS transfers_received,
+		(SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE to_user_id = @user) AS coins_received,
+		(SELECT COUNT(*) FROM purchases WHERE payer_id = @user AND refunded_at IS NULL) AS items_bought,
+		(SELECT COUNT(DISTINCT purchases.item_type) FROM purchases
+			JOIN items ON items.name = purchases.item_type
+			WHERE purchases.payer_id = @user AND purchases.refunded_at IS NULL) AS distinct_items,
+		(SELECT COUNT(*) FROM items) AS catalog_size`
+
+type AchievementRepository interface {
+	GetMetrics(userID string) (*models.ActivityMetrics, error)
+	AwardBadges(badges []models.UserBadge) error
+	GetBadges(userID string) ([]models.UserBadge, error)
+	GetAllUserIDs() ([]string, error)
+}
+
+type achievementRepository struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepository(db *gorm.DB) AchievementRepository {
+	return &achievementRepository{db: db}
+}
+
+func (r *achievementRepository) GetMetrics(userID string) (*models.ActivityMetrics, error) {
+	var metrics models.ActivityMetrics
+	err := r.db.Raw(activityMetricsQuery, map[string]interface{}{"user": userID}).Scan(&metrics).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table transactions)")
+	}
+	return &metrics, nil
+}
+
+func (r *achievementRepository) AwardBadges(badges []models.UserBadge) error {
+	if len(badges) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&badges).Error; err != nil {
+		return errors.Wrap(err, "database error (table user_badges)")
+	}
+	return nil
+}
+
+func (r *achievementRepository) GetBadges(userID string) ([]models.UserBadge, error) {
+	var badges []models.UserBadge
+	err := r.db.Where("user_id = ?", userID).Order("awarded_at, code").Find(&badges).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table user_badges)")
+	}
+	return badges, nil
+}
+
+func (r *achievementRepository) GetAllUserIDs() ([]string, error) {
+	var ids []string
+	if err := r.db.Model(&models.User{}).Order("created_at").Pluck("id", &ids).Error; err != nil {
+		return nil, errors.Wrap(err, "database error (table users)")
+	}
+	return ids, nil
+}
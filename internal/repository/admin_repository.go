@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type AdminRepository interface {
+	SaveItem(actorID string, item *models.Product) error
+	GrantCoins(actorID, userID string, amount int, reason string) error
+	ReverseTransaction(actorID, transactionID, reason string) (*models.CoinTransaction, error)
+	SetRole(actorID, userID, role string) error
+	LogAction(record *models.AuditRecord) error
+	PromoteUsers(usernames []string, role string, from []string) error
+	GetAuditLog(limit, offset int) ([]models.AuditEntry, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+func (r *adminRepository) SaveItem(actorID string, item *models.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price", "has_variants"}),
+		}).Create(item).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table items)")
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "catalog.save",
+			EntityType: "item",
+			EntityID:   item.Name,
+			Details:    fmt.Sprintf("price %d, variants %t", item.Price, item.HasVariants),
+		}).Error
+	})
+}
+
+func (r *adminRepository) GrantCoins(actorID, userID string, amount int, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := creditBalance(tx, userID, amount); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "coins.grant",
+			EntityType: "user",
+			EntityID:   userID,
+			Details:    fmt.Sprintf("granted %d coins: %s", amount, reason),
+		}).Error
+	})
+}
+
+func (r *adminRepository) ReverseTransaction(actorID, transactionID, reason string) (*models.CoinTransaction, error) {
+	var reversal *models.CoinTransaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var original models.CoinTransaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", transactionID).Take(&original).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("перевод не найден")
+			}
+			return errors.Wrap(err, "database error (table transactions)")
+		}
+		if original.ReversalOf != nil {
+			return errors.New("нельзя отменить отмену перевода")
+		}
+		if original.BudgetID != nil {
+			return errors.New("перевод из бюджета нельзя отменить")
+		}
+
+		if err := debitBalance(tx, original.ToUser, original.Amount, "у получателя недостаточно монет для отмены перевода"); err != nil {
+			return err
+		}
+		if err := creditBalance(tx, original.FromUser, original.Amount); err != nil {
+			return err
+		}
+
+		reversal = &models.CoinTransaction{
+			FromUser:   original.ToUser,
+			ToUser:     original.FromUser,
+			Amount:     original.Amount,
+			Message:    reason,
+			ReversalOf: &original.ID,
+		}
+		if err := tx.Create(reversal).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("перевод уже отменён")
+			}
+			return errors.Wrap(err, "database error (table transactions)")
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "coins.reverse",
+			EntityType: "transaction",
+			EntityID:   original.ID,
+			Details:    fmt.Sprintf("reversed %d coins: %s", original.Amount, reason),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+func (r *adminRepository) SetRole(actorID, userID, role string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(&models.User{}).Where("id = ?", userID).Update("role", role)
+		if updated.Error != nil {
+			return errors.Wrap(updated.Error, "database error (table users)")
+		}
+		if updated.RowsAffected == 0 {
+			return errors.New("пользователь не найден")
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "user.role",
+			EntityType: "user",
+			EntityID:   userID,
+			Details:    role,
+		}).Error
+	})
+}
+
+func (r *adminRepository) LogAction(record *models.AuditRecord) error {
+	if err := r.db.Create(record).Error; err != nil {
+		return errors.Wrap(err, "database error (table audit_log)")
+	}
+	return nil
+}
+
+func (r *adminRepository) PromoteUsers(usernames []string, role string, from []string) error {
+	if len(usernames) == 0 {
+		return nil
+	}
+	err := r.db.Model(&models.User{}).
+		Where("username IN ? AND role IN ?", usernames, from).
+		Update("role", role).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table users)")
+	}
+	return nil
+}
+
+func (r *adminRepository) GetAuditLog(limit, offset int) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := r.db.Table("audit_log").
+		Joins("LEFT JOIN users ON users.id = audit_log.actor_id").
+		Select("audit_log.id, users.username AS actor, audit_log.action, audit_log.entity_type, " +
+			"audit_log.entity_id, audit_log.details, audit_log.created_at").
+		Order("audit_log.created_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table audit_log)")
+	}
+	return entries, nil
+}
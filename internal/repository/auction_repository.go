@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type AuctionRepository interface {
+	CreateAuction(auction *models.Auction) error
+	GetAuction(id string) (*models.Auction, error)
+	GetOpenAuctions() ([]models.Auction, error)
+	GetBids(auctionID string) ([]models.Bid, error)
+	PlaceBid(id, bidderID string, amount int, now time.Time) (*models.Auction, error)
+	CancelAuction(id string) error
+	CloseAuctions(now time.Time) ([]models.Auction, error)
+}
+
+type auctionRepository struct {
+	db *gorm.DB
+}
+
+func NewAuctionRepository(db *gorm.DB) AuctionRepository {
+	return &auctionRepository{db: db}
+}
+
+func (r *auctionRepository) CreateAuction(auction *models.Auction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := takeStock(tx, auction.SKU); err != nil {
+			return err
+		}
+
+		auction.Status = models.AuctionStatusOpen
+		if err := tx.Create(auction).Error; err != nil {
+			return errors.Wrap(err, "database error (table auctions)")
+		}
+		return nil
+	})
+}
+
+func (r *auctionRepository) GetAuction(id string) (*models.Auction, error) {
+	var auction models.Auction
+	err := r.db.Where("id = ?", id).Take(&auction).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table auctions)")
+	}
+	return &auction, nil
+}
+
+func (r *auctionRepository) GetOpenAuctions() ([]models.Auction, error) {
+	var auctions []models.Auction
+	err := r.db.Where("status = ?", models.AuctionStatusOpen).Order("ends_at").Find(&auctions).Error
+	return auctions, err
+}
+
+func (r *auctionRepository) GetBids(auctionID string) ([]models.Bid, error) {
+	var bids []models.Bid
+	err := r.db.Where("auction_id = ?", auctionID).Order("amount DESC").Find(&bids).Error
+	return bids, err
+}
+
+func (r *auctionRepository) PlaceBid(id, bidderID string, amount int, now time.Time) (*models.Auction, error) {
+	var auction models.Auction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&auction).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("аукцион не найден")
+			}
+			return err
+		}
+		if auction.Status != models.AuctionStatusOpen || now.Before(auction.StartsAt) || !now.Before(auction.EndsAt) {
+			return errors.New("аукцион сейчас не принимает ставки")
+		}
+
+		minBid := auction.MinIncrement
+		if auction.TopBidderID != nil {
+			minBid = auction.TopBid + auction.MinIncrement
+		}
+		if amount < minBid {
+			return fmt.Errorf("ставка должна быть не меньше %d", minBid)
+		}
+
+		if err := releaseTopBid(tx, &auction); err != nil {
+			return err
+		}
+
+		if err := debitBalance(tx, bidderID, amount, "недостаточно монет для ставки"); err != nil {
+			return err
+		}
+
+		bid := &models.Bid{
+			AuctionID: auction.ID,
+			BidderID:  bidderID,
+			Amount:    amount,
+			Status:    models.BidStatusHeld,
+		}
+		if err := tx.Create(bid).Error; err != nil {
+			return errors.Wrap(err, "database error (table bids)")
+		}
+
+		auction.TopBid = amount
+		auction.TopBidderID = &bidderID
+		return tx.Model(&models.Auction{}).Where("id = ?", auction.ID).Updates(map[string]interface{}{
+			"top_bid":       amount,
+			"top_bidder_id": bidderID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+func (r *auctionRepository) CancelAuction(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var auction models.Auction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&auction).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("аукцион не найден")
+			}
+			return err
+		}
+		if auction.Status != models.AuctionStatusOpen {
+			return errors.New("аукцион уже завершён")
+		}
+
+		return finishUnsold(tx, &auction, models.AuctionStatusCancelled)
+	})
+}
+
+func (r *auctionRepository) CloseAuctions(now time.Time) ([]models.Auction, error) {
+	var ids []string
+	err := r.db.Model(&models.Auction{}).
+		Where("status = ? AND ends_at <= ?", models.AuctionStatusOpen, now).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var closed []models.Auction
+	for _, id := range ids {
+		var auction models.Auction
+		finished := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND status = ?", id, models.AuctionStatusOpen).Take(&auction).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+
+			finished = true
+			if auction.TopBidderID == nil || auction.TopBid < auction.ReservePrice {
+				return finishUnsold(tx, &auction, models.AuctionStatusUnsold)
+			}
+			return finishSold(tx, &auction)
+		})
+		if err != nil {
+			return closed, err
+		}
+		if finished {
+			closed = append(closed, auction)
+		}
+	}
+	return closed, nil
+}
+
+func releaseTopBid(tx *gorm.DB, auction *models.Auction) error {
+	if auction.TopBidderID == nil {
+		return nil
+	}
+
+	err := tx.Model(&models.Bid{}).
+		Where("auction_id = ? AND status = ?", auction.ID, models.BidStatusHeld).
+		Update("status", models.BidStatusReleased).Error
+	if err != nil {
+		return err
+	}
+	return creditBalance(tx, *auction.TopBidderID, auction.TopBid)
+}
+
+func finishUnsold(tx *gorm.DB, auction *models.Auction, status string) error {
+	if err := releaseTopBid(tx, auction); err != nil {
+		return err
+	}
+	if err := returnStock(tx, auction.SKU); err != nil {
+		return err
+	}
+
+	auction.Status = status
+	return tx.Model(&models.Auction{}).Where("id = ?", auction.ID).Updates(map[string]interface{}{
+		"status":    status,
+		"closed_at": time.Now(),
+	}).Error
+}
+
+func finishSold(tx *gorm.DB, auction *models.Auction) error {
+	inventory := &models.Inventory{
+		UserID:   *auction.TopBidderID,
+		ItemType: auction.ItemName,
+		Quantity: 1,
+		SKU:      auction.SKU,
+		Variant:  auction.Variant,
+		Price:    &auction.TopBid,
+		NoReturn: true,
+	}
+	if err := tx.Create(inventory).Error; err != nil {
+		return errors.Wrap(err, "database error (table inventory)")
+	}
+
+	purchase := &models.Purchase{
+		UserID:      *auction.TopBidderID,
+		PayerID:     auction.TopBidderID,
+		InventoryID: &inventory.ID,
+		ItemType:    auction.ItemName,
+		SKU:         auction.SKU,
+		Price:       auction.TopBid,
+	}
+	if err := logPurchase(tx, purchase); err != nil {
+		return err
+	}
+
+	err := tx.Model(&models.Bid{}).
+		Where("auction_id = ? AND status = ?", auction.ID, models.BidStatusHeld).
+		Update("status", models.BidStatusCaptured).Error
+	if err != nil {
+		return err
+	}
+
+	auction.Status = models.AuctionStatusSold
+	return tx.Model(&models.Auction{}).Where("id = ?", auction.ID).Updates(map[string]interface{}{
+		"status":    models.AuctionStatusSold,
+		"closed_at": time.Now(),
+	}).Error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+func lockUsers(tx *gorm.DB, ids ...string) error {
+	var users []models.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+		Where("id IN ?", ids).Order("id").Find(&users).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table users)")
+	}
+	return nil
+}
+
+func creditBalance(tx *gorm.DB, userID string, amount int) error {
+	return tx.Model(&models.User{}).Where("id = ?", userID).
+		Update("balance", gorm.Expr("balance + ?", amount)).Error
+}
+
+func debitBalance(tx *gorm.DB, userID string, amount int, insufficient string) error {
+	debit := tx.Model(&models.User{}).
+		Where("id = ? AND balance >= ?", userID, amount).
+		Update("balance", gorm.Expr("balance - ?", amount))
+	if debit.Error != nil {
+		return debit.Error
+	}
+	if debit.RowsAffected == 0 {
+		return errors.New(insufficient)
+	}
+	return nil
+}
+
+func takeStock(tx *gorm.DB, skuCode string) error {
+	if skuCode == "" {
+		return nil
+	}
+	taken := tx.Model(&models.SKU{}).
+		Where("code = ? AND stock > 0", skuCode).
+		Update("stock", gorm.Expr("stock - 1"))
+	if taken.Error != nil {
+		return taken.Error
+	}
+	if taken.RowsAffected == 0 {
+		return errors.New("товара нет в наличии")
+	}
+	return nil
+}
+
+func returnStock(tx *gorm.DB, skuCode string) error {
+	if skuCode == "" {
+		return nil
+	}
+	return tx.Model(&models.SKU{}).Where("code = ?", skuCode).
+		Update("stock", gorm.Expr("stock + 1")).Error
+}
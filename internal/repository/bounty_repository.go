@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type BountyRepository interface {
+	CreateBounty(bounty *models.Bounty) error
+	GetBounty(id string) (*models.Bounty, error)
+	GetOpenBounties(now time.Time) ([]models.Bounty, error)
+	GetUserBounties(userID string) ([]models.Bounty, error)
+	ClaimBounty(id, claimantID string, now time.Time) error
+	ReleaseBounty(id, claimantID string) error
+	SubmitBounty(id, claimantID, note string) error
+	RejectBounty(id, creatorID, comment string) error
+	ApproveBounty(id, creatorID, comment string) (*models.Bounty, error)
+	CancelBounty(id, creatorID string) error
+	ExpireBounties(now, claimedBefore time.Time) (int, error)
+}
+
+type bountyRepository struct {
+	db *gorm.DB
+}
+
+func NewBountyRepository(db *gorm.DB) BountyRepository {
+	return &bountyRepository{db: db}
+}
+
+func (r *bountyRepository) CreateBounty(bounty *models.Bounty) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := debitBalance(tx, bounty.CreatorID, bounty.Reward, "недостаточно монет для награды"); err != nil {
+			return err
+		}
+
+		bounty.Status = models.BountyStatusOpen
+		if err := tx.Create(bounty).Error; err != nil {
+			return errors.Wrap(err, "database error (table bounties)")
+		}
+		return nil
+	})
+}
+
+func (r *bountyRepository) GetBounty(id string) (*models.Bounty, error) {
+	var bounty models.Bounty
+	err := r.db.Where("id = ?", id).Take(&bounty).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table bounties)")
+	}
+	return &bounty, nil
+}
+
+func (r *bountyRepository) GetOpenBounties(now time.Time) ([]models.Bounty, error) {
+	var bounties []models.Bounty
+	err := r.db.Where("status = ? AND expires_at > ?", models.BountyStatusOpen, now).
+		Order("reward DESC, created_at").Find(&bounties).Error
+	return bounties, err
+}
+
+func (r *bountyRepository) GetUserBounties(userID string) ([]models.Bounty, error) {
+	var bounties []models.Bounty
+	err := r.db.Where("creator_id = ? OR claimant_id = ?", userID, userID).
+		Order("created_at DESC").Find(&bounties).Error
+	return bounties, err
+}
+
+func (r *bountyRepository) ClaimBounty(id, claimantID string, now time.Time) error {
+	return r.transition(
+		r.db.Where("id = ? AND status = ? AND creator_id <> ? AND expires_at > ?", id, models.BountyStatusOpen, claimantID, now),
+		map[string]interface{}{"status": models.BountyStatusClaimed, "claimant_id": claimantID, "claimed_at": now},
+		"задание недоступно для взятия",
+	)
+}
+
+func (r *bountyRepository) ReleaseBounty(id, claimantID string) error {
+	return r.transition(
+		r.db.Where("id = ? AND status = ? AND claimant_id = ?", id, models.BountyStatusClaimed, claimantID),
+		map[string]interface{}{"status": models.BountyStatusOpen, "claimant_id": nil, "claimed_at": nil, "submission_note": ""},
+		"задание не найдено среди взятых",
+	)
+}
+
+func (r *bountyRepository) SubmitBounty(id, claimantID, note string) error {
+	return r.transition(
+		r.db.Where("id = ? AND status = ? AND claimant_id = ?", id, models.BountyStatusClaimed, claimantID),
+		map[string]interface{}{"status": models.BountyStatusSubmitted, "submission_note": note},
+		"задание не найдено среди взятых",
+	)
+}
+
+func (r *bountyRepository) RejectBounty(id, creatorID, comment string) error {
+	return r.transition(
+		r.db.Where("id = ? AND status = ? AND creator_id = ?", id, models.BountyStatusSubmitted, creatorID),
+		map[string]interface{}{"status": models.BountyStatusClaimed, "review_comment": comment, "claimed_at": time.Now()},
+		"нет результата, ожидающего проверки",
+	)
+}
+
+func (r *bountyRepository) transition(query *gorm.DB, updates map[string]interface{}, notFound string) error {
+	updated := query.Model(&models.Bounty{}).Updates(updates)
+	if updated.Error != nil {
+		return updated.Error
+	}
+	if updated.RowsAffected == 0 {
+		return errors.New(notFound)
+	}
+	return nil
+}
+
+func (r *bountyRepository) ApproveBounty(id, creatorID, comment string) (*models.Bounty, error) {
+	var bounty *models.Bounty
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		bounty, err = lockBounty(tx, "id = ? AND creator_id = ?", id, creatorID)
+		if err != nil {
+			return err
+		}
+		if bounty.Status != models.BountyStatusSubmitted || bounty.ClaimantID == nil {
+			return errors.New("нет результата, ожидающего проверки")
+		}
+
+		if err := creditBalance(tx, *bounty.ClaimantID, bounty.Reward); err != nil {
+			return err
+		}
+
+		payout := &models.CoinTransaction{
+			FromUser: bounty.CreatorID,
+			ToUser:   *bounty.ClaimantID,
+			Amount:   bounty.Reward,
+		}
+		if err := tx.Create(payout).Error; err != nil {
+			return errors.Wrap(err, "database error (table transactions)")
+		}
+
+		return tx.Model(&models.Bounty{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":         models.BountyStatusPaid,
+			"review_comment": comment,
+			"completed_at":   time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bounty, nil
+}
+
+func (r *bountyRepository) CancelBounty(id, creatorID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		bounty, err := lockBounty(tx, "id = ? AND creator_id = ?", id, creatorID)
+		if err != nil {
+			return err
+		}
+		if bounty.Status != models.BountyStatusOpen {
+			return errors.New("отменить можно только задание, которое никто не взял")
+		}
+
+		return refundBounty(tx, bounty, models.BountyStatusCancelled)
+	})
+}
+
+func (r *bountyRepository) ExpireBounties(now, claimedBefore time.Time) (int, error) {
+	var ids []string
+	err := r.db.Model(&models.Bounty{}).
+		Where("(status = ? AND expires_at <= ?) OR (status = ? AND (expires_at <= ? OR claimed_at <= ?))",
+			models.BountyStatusOpen, now, models.BountyStatusClaimed, now, claimedBefore).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		changed := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			bounty, err := lockBounty(tx, "id = ?", id)
+			if err != nil {
+				return err
+			}
+
+			switch bountyExpiry(bounty, now, claimedBefore) {
+			case models.BountyStatusExpired:
+				changed = true
+				return refundBounty(tx, bounty, models.BountyStatusExpired)
+			case models.BountyStatusOpen:
+				changed = true
+				return tx.Model(&models.Bounty{}).Where("id = ?", bounty.ID).Updates(map[string]interface{}{
+					"status":          models.BountyStatusOpen,
+					"claimant_id":     nil,
+					"claimed_at":      nil,
+					"submission_note": "",
+				}).Error
+			}
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+		if changed {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func bountyExpiry(bounty *models.Bounty, now, claimedBefore time.Time) string {
+	switch bounty.Status {
+	case models.BountyStatusOpen:
+		if !now.Before(bounty.ExpiresAt) {
+			return models.BountyStatusExpired
+		}
+	case models.BountyStatusClaimed:
+		if !now.Before(bounty.ExpiresAt) {
+			return models.BountyStatusExpired
+		}
+		if bounty.ClaimedAt != nil && !claimedBefore.Before(*bounty.ClaimedAt) {
+			return models.BountyStatusOpen
+		}
+	}
+	return ""
+}
+
+func lockBounty(tx *gorm.DB, query string, args ...interface{}) (*models.Bounty, error) {
+	var bounty models.Bounty
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).Take(&bounty).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("задание не найдено")
+		}
+		return nil, err
+	}
+	return &bounty, nil
+}
+
+func refundBounty(tx *gorm.DB, bounty *models.Bounty, status string) error {
+	if err := creditBalance(tx, bounty.CreatorID, bounty.Reward); err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Bounty{}).Where("id = ?", bounty.ID).Updates(map[string]interface{}{
+		"status":       status,
+		"completed_at": time.Now(),
+	}).Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"avito-shop-test/internal/models"
+)
+
+func TestBountyExpiry(t *testing.T) {
+	now := time.Now()
+	claimedBefore := now.Add(-7 * 24 * time.Hour)
+	staleClaim := now.Add(-8 * 24 * time.Hour)
+	freshClaim := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		bounty models.Bounty
+		want   string
+	}{
+		{"open before deadline", models.Bounty{Status: models.BountyStatusOpen, ExpiresAt: now.Add(time.Hour)}, ""},
+		{"open after deadline", models.Bounty{Status: models.BountyStatusOpen, ExpiresAt: now}, models.BountyStatusExpired},
+		{"fresh claim", models.Bounty{Status: models.BountyStatusClaimed, ExpiresAt: now.Add(time.Hour), ClaimedAt: &freshClaim}, ""},
+		{"stale claim reopens", models.Bounty{Status: models.BountyStatusClaimed, ExpiresAt: now.Add(time.Hour), ClaimedAt: &staleClaim}, models.BountyStatusOpen},
+		{"claim after deadline is refunded", models.Bounty{Status: models.BountyStatusClaimed, ExpiresAt: now, ClaimedAt: &freshClaim}, models.BountyStatusExpired},
+		{"submitted waits for review", models.Bounty{Status: models.BountyStatusSubmitted, ExpiresAt: now, ClaimedAt: &staleClaim}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bountyExpiry(&tt.bounty, now, claimedBefore))
+		})
+	}
+}
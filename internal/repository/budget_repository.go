@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+type BudgetRepository interface {
+	CreateBudget(budget *models.Budget, holderIDs []string) error
+	GetBudget(id string) (*models.Budget, error)
+	GetHeldBudgets(userID string) ([]models.Budget, error)
+	IsHolder(budgetID, userID string) (bool, error)
+	SpendFromBudget(transaction *models.CoinTransaction) error
+	RefillBudgets(period string, since, now time.Time) (int, error)
+	GetUsage(budgetID, period string) ([]models.BudgetUsage, error)
+}
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+func (r *budgetRepository) budgets() *gorm.DB {
+	return r.db.Table("budgets").
+		Select("budgets.*, users.username AS owner").
+		Joins("LEFT JOIN users ON users.id = budgets.owner_id")
+}
+
+func (r *budgetRepository) CreateBudget(budget *models.Budget, holderIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(budget).Error; err != nil {
+			return errors.Wrap(err, "database error (table budgets)")
+		}
+
+		holders := make([]models.BudgetHolder, 0, len(holderIDs))
+		for _, userID := range holderIDs {
+			holders = append(holders, models.BudgetHolder{BudgetID: budget.ID, UserID: userID})
+		}
+		if err := tx.Create(&holders).Error; err != nil {
+			return errors.Wrap(err, "database error (table budget_holders)")
+		}
+		return nil
+	})
+}
+
+func (r *budgetRepository) GetBudget(id string) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.budgets().Where("budgets.id = ?", id).Take(&budget).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table budgets)")
+	}
+	return &budget, nil
+}
+
+func (r *budgetRepository) GetHeldBudgets(userID string) ([]models.Budget, error) {
+	var budgets []models.Budget
+	err := r.budgets().
+		Joins("JOIN budget_holders ON budget_holders.budget_id = budgets.id").
+		Where("budget_holders.user_id = ?", userID).
+		Order("budgets.name").
+		Scan(&budgets).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table budgets)")
+	}
+	return budgets, nil
+}
+
+func (r *budgetRepository) IsHolder(budgetID, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.BudgetHolder{}).
+		Where("budget_id = ? AND user_id = ?", budgetID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, errors.Wrap(err, "database error (table budget_holders)")
+	}
+	return count > 0, nil
+}
+
+func (r *budgetRepository) SpendFromBudget(transaction *models.CoinTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		debit := tx.Model(&models.Budget{}).
+			Where("id = ? AND balance >= ?", *transaction.BudgetID, transaction.Amount).
+			Update("balance", gorm.Expr("balance - ?", transaction.Amount))
+		if debit.Error != nil {
+			return errors.Wrap(debit.Error, "database error (table budgets)")
+		}
+		if debit.RowsAffected == 0 {
+			return errors.New("недостаточно монет в бюджете")
+		}
+
+		if err := creditBalance(tx, transaction.ToUser, transaction.Amount); err != nil {
+			return err
+		}
+
+		if err := tx.Create(transaction).Error; err != nil {
+			return errors.Wrap(err, "database error (table transactions)")
+		}
+		return nil
+	})
+}
+
+func (r *budgetRepository) RefillBudgets(period string, since, now time.Time) (int, error) {
+	refilled := r.db.Model(&models.Budget{}).
+		Where("refill_period = ? AND refilled_at < ?", period, since).
+		Updates(map[string]interface{}{
+			"balance":     gorm.Expr("refill_amount"),
+			"refilled_at": now,
+		})
+	if refilled.Error != nil {
+		return 0, errors.Wrap(refilled.Error, "database error (table budgets)")
+	}
+	return int(refilled.RowsAffected), nil
+}
+
+func (r *budgetRepository) GetUsage(budgetID, period string) ([]models.BudgetUsage, error) {
+	var usage []models.BudgetUsage
+	err := r.db.Raw(`
+		SELECT date_trunc(@period, created_at) AS period_start,
+			SUM(amount) AS spent,
+			COUNT(*) AS transfers,
+			COUNT(DISTINCT to_user_id) AS recipients
+		FROM transactions
+		WHERE budget_id = @budget
+		GROUP BY 1
+		ORDER BY period_start DESC`,
+		map[string]interface{}{"period": period, "budget": budgetID},
+	).Scan(&usage).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table transactions)")
+	}
+	return usage, nil
+}
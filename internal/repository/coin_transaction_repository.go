@@ -25,6 +25,6 @@ func (r *coinTransactionRepository) RecordTransaction(transaction *models.CoinTr
 
 func (r *coinTransactionRepository) GetTransactionsHistory(userID string) ([]models.CoinTransaction, error) {
 	var transactions []models.CoinTransaction
-	err := r.db.Where("from_user_id = ? OR to_user_id = ?", userID, userID).Find(&transactions).Error
+	err := r.db.Where("(from_user_id = ? AND budget_id IS NULL) OR to_user_id = ?", userID, userID).Find(&transactions).Error
 	return transactions, err
 }
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type GiftRepository interface {
+	CreateGift(gift *models.Gift, order *models.PurchaseOrder) error
+	GetUserGifts(userID string) ([]models.Gift, error)
+	DeclineGift(id, recipientID string) error
+}
+
+type giftRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftRepository(db *gorm.DB) GiftRepository {
+	return &giftRepository{db: db}
+}
+
+func (r *giftRepository) CreateGift(gift *models.Gift, order *models.PurchaseOrder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := placeOrder(tx, order); err != nil {
+			return err
+		}
+
+		gift.InventoryID = order.Inventory.ID
+		if err := tx.Create(gift).Error; err != nil {
+			return errors.Wrap(err, "database error (table gifts)")
+		}
+		return nil
+	})
+}
+
+func (r *giftRepository) GetUserGifts(userID string) ([]models.Gift, error) {
+	var gifts []models.Gift
+	err := r.db.Where("from_user_id = ? OR to_user_id = ?", userID, userID).Order("created_at DESC").Find(&gifts).Error
+	return gifts, err
+}
+
+func (r *giftRepository) DeclineGift(id, recipientID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var gift models.Gift
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND to_user_id = ?", id, recipientID).Take(&gift).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("подарок не найден")
+			}
+			return err
+		}
+		if gift.Status != models.GiftStatusDelivered {
+			return errors.New("подарок уже отклонён")
+		}
+
+		if err := refundPurchase(tx, gift.InventoryID); err != nil {
+			return err
+		}
+
+		removed := tx.Where("id = ? AND user_id = ?", gift.InventoryID, recipientID).Delete(&models.Inventory{})
+		if removed.Error != nil {
+			return removed.Error
+		}
+		if removed.RowsAffected == 0 {
+			return errors.New("подарка уже нет в инвентаре")
+		}
+
+		if err := creditBalance(tx, gift.FromUser, gift.Amount); err != nil {
+			return err
+		}
+
+		if err := returnStock(tx, gift.SKU); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Gift{}).Where("id = ?", gift.ID).Updates(map[string]interface{}{
+			"status":      models.GiftStatusDeclined,
+			"declined_at": time.Now(),
+		}).Error
+	})
+}
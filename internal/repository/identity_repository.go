@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type IdentityRepository interface {
+	GetUserByIdentity(issuer, subject string) (*models.User, error)
+	CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error
+	UpdateIdentityEmail(issuer, subject, email string) error
+	SaveLoginState(state *models.SSOLoginState) error
+	ConsumeLoginState(state string, now time.Time) (*models.SSOLoginState, error)
+	DeleteExpiredLoginStates(now time.Time) error
+}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepository(db *gorm.DB) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+func (r *identityRepository) GetUserByIdentity(issuer, subject string) (*models.User, error) {
+	var user models.User
+	err := r.db.Table("users").
+		Joins("JOIN user_identities ON user_identities.user_id = users.id").
+		Where("user_identities.issuer = ? AND user_identities.subject = ?", issuer, subject).
+		Select("users.*").
+		Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table user_identities)")
+	}
+	return &user, nil
+}
+
+func (r *identityRepository) CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("пользователь уже существует")
+			}
+			return errors.Wrap(err, "database error (table users)")
+		}
+
+		identity.UserID = user.ID
+		if err := tx.Create(identity).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("учётная запись SSO уже привязана")
+			}
+			return errors.Wrap(err, "database error (table user_identities)")
+		}
+		return nil
+	})
+}
+
+func (r *identityRepository) UpdateIdentityEmail(issuer, subject, email string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var identity models.UserIdentity
+		err := tx.Where("issuer = ? AND subject = ?", issuer, subject).Take(&identity).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table user_identities)")
+		}
+		if identity.Email == email {
+			return nil
+		}
+
+		err = tx.Model(&models.UserIdentity{}).Where("issuer = ? AND subject = ?", issuer, subject).
+			Update("email", email).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table user_identities)")
+		}
+		err = tx.Model(&models.User{}).Where("id = ?", identity.UserID).Update("email", email).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table users)")
+		}
+		return nil
+	})
+}
+
+func (r *identityRepository) SaveLoginState(state *models.SSOLoginState) error {
+	if err := r.db.Create(state).Error; err != nil {
+		return errors.Wrap(err, "database error (table sso_login_states)")
+	}
+	return nil
+}
+
+func (r *identityRepository) ConsumeLoginState(state string, now time.Time) (*models.SSOLoginState, error) {
+	var consumed []models.SSOLoginState
+	err := r.db.Clauses(clause.Returning{}).
+		Where("state = ? AND expires_at > ?", state, now).
+		Delete(&consumed).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table sso_login_states)")
+	}
+	if len(consumed) == 0 {
+		return nil, nil
+	}
+	return &consumed[0], nil
+}
+
+func (r *identityRepository) DeleteExpiredLoginStates(now time.Time) error {
+	err := r.db.Where("expires_at <= ?", now).Delete(&models.SSOLoginState{}).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table sso_login_states)")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type KudosRepository interface {
+	GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error)
+	GetReactionCounts(transactionIDs []string) ([]models.ReactionCount, error)
+	IsPublic(transactionID string) (bool, error)
+	AddReaction(reaction *models.KudosReaction) error
+	RemoveReaction(transactionID, userID, emoji string) error
+	AddComment(comment *models.KudosComment) error
+	GetComments(transactionID string) ([]models.KudosComment, error)
+}
+
+type kudosRepository struct {
+	db *gorm.DB
+}
+
+func NewKudosRepository(db *gorm.DB) KudosRepository {
+	return &kudosRepository{db: db}
+}
+
+func (r *kudosRepository) publicTransactions() *gorm.DB {
+	return r.db.Table("transactions").
+		Joins("JOIN users AS sender ON sender.id = transactions.from_user_id").
+		Joins("JOIN users AS recipient ON recipient.id = transactions.to_user_id").
+		Where("transactions.is_public AND sender.public_kudos AND recipient.public_kudos").
+		Where("NOT EXISTS (SELECT 1 FROM transactions AS reversal WHERE reversal.reversal_of = transactions.id)")
+}
+
+func (r *kudosRepository) GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error) {
+	query := r.publicTransactions().
+		Select("transactions.id, sender.username AS sender, recipient.username AS recipient, " +
+			"transactions.amount, transactions.message, transactions.created_at, " +
+			"(SELECT COUNT(*) FROM kudos_comments WHERE kudos_comments.transaction_id = transactions.id) AS comments_count")
+
+	if filter.Team != "" {
+		query = query.Where("sender.department = ? OR recipient.department = ?", filter.Team, filter.Team)
+	}
+
+	var entries []models.KudosEntry
+	err := query.Order("transactions.created_at DESC").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table transactions)")
+	}
+	return entries, nil
+}
+
+func (r *kudosRepository) GetReactionCounts(transactionIDs []string) ([]models.ReactionCount, error) {
+	var counts []models.ReactionCount
+	if len(transactionIDs) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Model(&models.KudosReaction{}).
+		Select("transaction_id, emoji, COUNT(*) AS count").
+		Where("transaction_id IN ?", transactionIDs).
+		Group("transaction_id, emoji").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *kudosRepository) IsPublic(transactionID string) (bool, error) {
+	var count int64
+	err := r.publicTransactions().Where("transactions.id = ?", transactionID).Count(&count).Error
+	if err != nil {
+		return false, errors.Wrap(err, "database error (table transactions)")
+	}
+	return count > 0, nil
+}
+
+func (r *kudosRepository) AddReaction(reaction *models.KudosReaction) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction).Error
+}
+
+func (r *kudosRepository) RemoveReaction(transactionID, userID, emoji string) error {
+	return r.db.Where("transaction_id = ? AND user_id = ? AND emoji = ?", transactionID, userID, emoji).
+		Delete(&models.KudosReaction{}).Error
+}
+
+func (r *kudosRepository) AddComment(comment *models.KudosComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return errors.Wrap(err, "database error (table kudos_comments)")
+	}
+	return nil
+}
+
+func (r *kudosRepository) GetComments(transactionID string) ([]models.KudosComment, error) {
+	var comments []models.KudosComment
+	err := r.db.Table("kudos_comments").
+		Select("kudos_comments.*, users.username").
+		Joins("JOIN users ON users.id = kudos_comments.user_id").
+		Where("kudos_comments.transaction_id = ?", transactionID).
+		Order("kudos_comments.created_at").
+		Scan(&comments).Error
+	return comments, err
+}
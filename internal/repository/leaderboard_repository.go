@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+var leaderboardQueries = map[string]string{
+	models.LeaderboardGivers: `
+		SELECT t.from_user_id AS user_id, SUM(t.amount) AS score
+		FROM transactions t JOIN users u ON u.id = t.from_user_id
+		WHERE NOT u.hide_from_leaderboards AND t.budget_id IS NULL AND t.created_at >= ?
+			AND t.reversal_of IS NULL AND NOT EXISTS (SELECT 1 FROM transactions r WHERE r.reversal_of = t.id)
+		GROUP BY t.from_user_id`,
+	models.LeaderboardReceivers: `
+		SELECT t.to_user_id AS user_id, SUM(t.amount) AS score
+		FROM transactions t JOIN users u ON u.id = t.to_user_id
+		WHERE NOT u.hide_from_leaderboards AND t.created_at >= ?
+			AND t.reversal_of IS NULL AND NOT EXISTS (SELECT 1 FROM transactions r WHERE r.reversal_of = t.id)
+		GROUP BY t.to_user_id`,
+	models.LeaderboardThanked: `
+		SELECT t.from_user_id AS user_id, COUNT(DISTINCT t.to_user_id) AS score
+		FROM transactions t JOIN users u ON u.id = t.from_user_id
+		WHERE NOT u.hide_from_leaderboards AND t.budget_id IS NULL AND t.created_at >= ?
+			AND t.reversal_of IS NULL AND NOT EXISTS (SELECT 1 FROM transactions r WHERE r.reversal_of = t.id)
+		GROUP BY t.from_user_id`,
+}
+
+type LeaderboardRepository interface {
+	RefreshLeaderboards(periods map[string]time.Time, size int, now time.Time) error
+	GetLeaderboard(board, period string) ([]models.LeaderboardEntry, error)
+}
+
+type leaderboardRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardRepository(db *gorm.DB) LeaderboardRepository {
+	return &leaderboardRepository{db: db}
+}
+
+func (r *leaderboardRepository) RefreshLeaderboards(periods map[string]time.Time, size int, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM leaderboard_entries").Error; err != nil {
+			return errors.Wrap(err, "database error (table leaderboard_entries)")
+		}
+
+		for board, query := range leaderboardQueries {
+			for period, since := range periods {
+				err := tx.Exec(
+					"INSERT INTO leaderboard_entries (board, period, user_id, score, refreshed_at) "+
+						"SELECT ?, ?, ranked.user_id, ranked.score, ? FROM ("+query+") AS ranked "+
+						"ORDER BY ranked.score DESC LIMIT ?",
+					board, period, now, since, size,
+				).Error
+				if err != nil {
+					return errors.Wrap(err, "database error (table leaderboard_entries)")
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *leaderboardRepository) GetLeaderboard(board, period string) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	err := r.db.Table("leaderboard_entries").
+		Select("leaderboard_entries.*, users.username").
+		Joins("JOIN users ON users.id = leaderboard_entries.user_id").
+		Where("leaderboard_entries.board = ? AND leaderboard_entries.period = ? AND NOT users.hide_from_leaderboards", board, period).
+		Order("leaderboard_entries.score DESC, users.username").
+		Scan(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+type LoginAttemptRepository interface {
+	GetBlockedUntil(keys []string) (*time.Time, error)
+	RecordLoginFailure(key string, now, windowStart time.Time) (int, error)
+	BlockLogin(key string, until time.Time) error
+	ResetLoginAttempts(keys ...string) error
+	DeleteStaleLoginAttempts(before time.Time) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) GetBlockedUntil(keys []string) (*time.Time, error) {
+	var until *time.Time
+	err := r.db.Model(&models.LoginAttempt{}).
+		Select("MAX(blocked_until)").
+		Where("key IN ?", keys).
+		Scan(&until).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table login_attempts)")
+	}
+	return until, nil
+}
+
+func (r *loginAttemptRepository) RecordLoginFailure(key string, now, windowStart time.Time) (int, error) {
+	var failures int
+	err := r.db.Raw(`
+		INSERT INTO login_attempts (key, failures, last_failure_at) VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures`, key, now, windowStart).
+		Scan(&failures).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "database error (table login_attempts)")
+	}
+	return failures, nil
+}
+
+func (r *loginAttemptRepository) BlockLogin(key string, until time.Time) error {
+	err := r.db.Model(&models.LoginAttempt{}).Where("key = ?", key).Update("blocked_until", until).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table login_attempts)")
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) ResetLoginAttempts(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := r.db.Where("key IN ?", keys).Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table login_attempts)")
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) DeleteStaleLoginAttempts(before time.Time) error {
+	err := r.db.Where("last_failure_at < ? AND (blocked_until IS NULL OR blocked_until < ?)", before, before).
+		Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table login_attempts)")
+	}
+	return nil
+}
+
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*models.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: make(map[string]*models.LoginAttempt)}
+}
+
+func (r *memoryLoginAttemptRepository) GetBlockedUntil(keys []string) (*time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var until *time.Time
+	for _, key := range keys {
+		attempt, ok := r.attempts[key]
+		if !ok || attempt.BlockedUntil == nil {
+			continue
+		}
+		if until == nil || attempt.BlockedUntil.After(*until) {
+			blocked := *attempt.BlockedUntil
+			until = &blocked
+		}
+	}
+	return until, nil
+}
+
+func (r *memoryLoginAttemptRepository) RecordLoginFailure(key string, now, windowStart time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &models.LoginAttempt{Key: key}
+		r.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.Before(windowStart) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	return attempt.Failures, nil
+}
+
+func (r *memoryLoginAttemptRepository) BlockLogin(key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.BlockedUntil = &until
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) ResetLoginAttempts(keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		delete(r.attempts, key)
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) DeleteStaleLoginAttempts(before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, attempt := range r.attempts {
+		if attempt.LastFailureAt.Before(before) && (attempt.BlockedUntil == nil || attempt.BlockedUntil.Before(before)) {
+			delete(r.attempts, key)
+		}
+	}
+	return nil
+}
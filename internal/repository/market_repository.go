@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type MarketRepository interface {
+	CreateListing(listing *models.Listing) error
+	GetListing(id string) (*models.Listing, error)
+	SearchListings(filter models.ListingFilter, now time.Time) ([]models.ListingInfo, error)
+	GetUserListings(userID string) ([]models.Listing, error)
+	CancelListing(id, sellerID string) error
+	BuyListing(id, buyerID string, now time.Time) (*models.Listing, error)
+	ExpireListings(now time.Time) (int, error)
+}
+
+type marketRepository struct {
+	db *gorm.DB
+}
+
+func NewMarketRepository(db *gorm.DB) MarketRepository {
+	return &marketRepository{db: db}
+}
+
+func (r *marketRepository) CreateListing(listing *models.Listing) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var inventory models.Inventory
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", listing.InventoryID, listing.SellerID).Take(&inventory).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("товар не найден в инвентаре")
+			}
+			return errors.Wrap(err, "database error (table inventory)")
+		}
+
+		var pendingReturns int64
+		err = tx.Model(&models.ReturnRequest{}).
+			Where("inventory_id = ? AND status = ?", inventory.ID, models.ReturnStatusPending).
+			Count(&pendingReturns).Error
+		if err != nil {
+			return err
+		}
+		if pendingReturns > 0 {
+			return errors.New("по этому товару оформлен возврат")
+		}
+
+		listing.ItemType = inventory.ItemType
+		listing.SKU = inventory.SKU
+		listing.Variant = inventory.Variant
+		listing.Status = models.ListingStatusActive
+		if err := tx.Create(listing).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("товар уже выставлен на продажу")
+			}
+			return errors.Wrap(err, "database error (table listings)")
+		}
+		return nil
+	})
+}
+
+func (r *marketRepository) GetListing(id string) (*models.Listing, error) {
+	var listing models.Listing
+	err := r.db.Where("id = ?", id).Take(&listing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table listings)")
+	}
+	return &listing, nil
+}
+
+func (r *marketRepository) SearchListings(filter models.ListingFilter, now time.Time) ([]models.ListingInfo, error) {
+	query := r.db.Table("listings").
+		Select("listings.id, users.username AS seller, listings.item_type, listings.sku, listings.variant, "+
+			"listings.price, listings.expires_at, listings.created_at").
+		Joins("JOIN users ON users.id = listings.seller_id").
+		Joins("JOIN inventory ON inventory.id = listings.inventory_id AND inventory.user_id = listings.seller_id").
+		Where("listings.status = ? AND listings.expires_at > ?", models.ListingStatusActive, now)
+
+	if filter.Query != "" {
+		pattern := "%" + filter.Query + "%"
+		query = query.Where("listings.item_type ILIKE ? OR listings.variant ILIKE ?", pattern, pattern)
+	}
+	if filter.Seller != "" {
+		query = query.Where("users.username = ?", filter.Seller)
+	}
+	if filter.MinPrice > 0 {
+		query = query.Where("listings.price >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		query = query.Where("listings.price <= ?", filter.MaxPrice)
+	}
+
+	var listings []models.ListingInfo
+	err := query.Order("listings.price, listings.created_at").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Scan(&listings).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table listings)")
+	}
+	return listings, nil
+}
+
+func (r *marketRepository) GetUserListings(userID string) ([]models.Listing, error) {
+	var listings []models.Listing
+	err := r.db.Where("seller_id = ?", userID).Order("created_at DESC").Find(&listings).Error
+	return listings, err
+}
+
+func (r *marketRepository) CancelListing(id, sellerID string) error {
+	cancelled := r.db.Model(&models.Listing{}).
+		Where("id = ? AND seller_id = ? AND status = ?", id, sellerID, models.ListingStatusActive).
+		Update("status", models.ListingStatusCancelled)
+	if cancelled.Error != nil {
+		return cancelled.Error
+	}
+	if cancelled.RowsAffected == 0 {
+		return errors.New("активное объявление не найдено")
+	}
+	return nil
+}
+
+func (r *marketRepository) BuyListing(id, buyerID string, now time.Time) (*models.Listing, error) {
+	var listing models.Listing
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&listing).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("объявление не найдено")
+			}
+			return err
+		}
+		if listing.Status != models.ListingStatusActive || !now.Before(listing.ExpiresAt) {
+			return errors.New("объявление уже неактивно")
+		}
+		if listing.SellerID == buyerID {
+			return errors.New("нельзя купить собственный товар")
+		}
+
+		moved := tx.Model(&models.Inventory{}).
+			Where("id = ? AND user_id = ?", listing.InventoryID, listing.SellerID).
+			Updates(transferredInventory(buyerID))
+		if moved.Error != nil {
+			return moved.Error
+		}
+		if moved.RowsAffected == 0 {
+			return errors.New("товар больше недоступен")
+		}
+
+		if err := debitBalance(tx, buyerID, listing.Price, "недостаточно монет для покупки"); err != nil {
+			return err
+		}
+		if err := creditBalance(tx, listing.SellerID, listing.Price); err != nil {
+			return err
+		}
+
+		listing.Status = models.ListingStatusSold
+		listing.BuyerID = &buyerID
+		listing.SoldAt = &now
+		return tx.Model(&models.Listing{}).Where("id = ?", listing.ID).Updates(map[string]interface{}{
+			"status":   listing.Status,
+			"buyer_id": buyerID,
+			"sold_at":  now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &listing, nil
+}
+
+func (r *marketRepository) ExpireListings(now time.Time) (int, error) {
+	expired := r.db.Model(&models.Listing{}).
+		Where("status = ? AND expires_at <= ?", models.ListingStatusActive, now).
+		Update("status", models.ListingStatusExpired)
+	return int(expired.RowsAffected), expired.Error
+}
+
+func transferredInventory(ownerID string) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":   ownerID,
+		"price":     0,
+		"no_return": true,
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockAchievementRepository struct {
+	mock.Mock
+}
+
+func (m *MockAchievementRepository) GetMetrics(userID string) (*models.ActivityMetrics, error) {
+	args := m.Called(userID)
+
+	if metrics, ok := args.Get(0).(*models.ActivityMetrics); ok {
+		return metrics, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAchievementRepository) AwardBadges(badges []models.UserBadge) error {
+	return m.Called(badges).Error(0)
+}
+
+func (m *MockAchievementRepository) GetBadges(userID string) ([]models.UserBadge, error) {
+	args := m.Called(userID)
+
+	if badges, ok := args.Get(0).([]models.UserBadge); ok {
+		return badges, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAchievementRepository) GetAllUserIDs() ([]string, error) {
+	args := m.Called()
+
+	if ids, ok := args.Get(0).([]string); ok {
+		return ids, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) SaveItem(actorID string, item *models.Product) error {
+	return m.Called(actorID, item).Error(0)
+}
+
+func (m *MockAdminRepository) GrantCoins(actorID, userID string, amount int, reason string) error {
+	return m.Called(actorID, userID, amount, reason).Error(0)
+}
+
+func (m *MockAdminRepository) ReverseTransaction(actorID, transactionID, reason string) (*models.CoinTransaction, error) {
+	args := m.Called(actorID, transactionID, reason)
+
+	if transaction, ok := args.Get(0).(*models.CoinTransaction); ok {
+		return transaction, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) SetRole(actorID, userID, role string) error {
+	return m.Called(actorID, userID, role).Error(0)
+}
+
+func (m *MockAdminRepository) LogAction(record *models.AuditRecord) error {
+	return m.Called(record).Error(0)
+}
+
+func (m *MockAdminRepository) PromoteUsers(usernames []string, role string, from []string) error {
+	return m.Called(usernames, role, from).Error(0)
+}
+
+func (m *MockAdminRepository) GetAuditLog(limit, offset int) ([]models.AuditEntry, error) {
+	args := m.Called(limit, offset)
+
+	if entries, ok := args.Get(0).([]models.AuditEntry); ok {
+		return entries, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
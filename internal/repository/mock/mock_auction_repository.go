@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockAuctionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuctionRepository) CreateAuction(auction *models.Auction) error {
+	return m.Called(auction).Error(0)
+}
+
+func (m *MockAuctionRepository) GetAuction(id string) (*models.Auction, error) {
+	args := m.Called(id)
+
+	if auction, ok := args.Get(0).(*models.Auction); ok {
+		return auction, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAuctionRepository) GetOpenAuctions() ([]models.Auction, error) {
+	args := m.Called()
+
+	if auctions, ok := args.Get(0).([]models.Auction); ok {
+		return auctions, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAuctionRepository) GetBids(auctionID string) ([]models.Bid, error) {
+	args := m.Called(auctionID)
+
+	if bids, ok := args.Get(0).([]models.Bid); ok {
+		return bids, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAuctionRepository) PlaceBid(id, bidderID string, amount int, now time.Time) (*models.Auction, error) {
+	args := m.Called(id, bidderID, amount, now)
+
+	if auction, ok := args.Get(0).(*models.Auction); ok {
+		return auction, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockAuctionRepository) CancelAuction(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockAuctionRepository) CloseAuctions(now time.Time) ([]models.Auction, error) {
+	args := m.Called(now)
+
+	if auctions, ok := args.Get(0).([]models.Auction); ok {
+		return auctions, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
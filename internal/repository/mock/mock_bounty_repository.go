@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockBountyRepository struct {
+	mock.Mock
+}
+
+func (m *MockBountyRepository) CreateBounty(bounty *models.Bounty) error {
+	return m.Called(bounty).Error(0)
+}
+
+func (m *MockBountyRepository) GetBounty(id string) (*models.Bounty, error) {
+	args := m.Called(id)
+
+	if bounty, ok := args.Get(0).(*models.Bounty); ok {
+		return bounty, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBountyRepository) GetOpenBounties(now time.Time) ([]models.Bounty, error) {
+	args := m.Called(now)
+
+	if bounties, ok := args.Get(0).([]models.Bounty); ok {
+		return bounties, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBountyRepository) GetUserBounties(userID string) ([]models.Bounty, error) {
+	args := m.Called(userID)
+
+	if bounties, ok := args.Get(0).([]models.Bounty); ok {
+		return bounties, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBountyRepository) ClaimBounty(id, claimantID string, now time.Time) error {
+	return m.Called(id, claimantID, now).Error(0)
+}
+
+func (m *MockBountyRepository) ReleaseBounty(id, claimantID string) error {
+	return m.Called(id, claimantID).Error(0)
+}
+
+func (m *MockBountyRepository) SubmitBounty(id, claimantID, note string) error {
+	return m.Called(id, claimantID, note).Error(0)
+}
+
+func (m *MockBountyRepository) RejectBounty(id, creatorID, comment string) error {
+	return m.Called(id, creatorID, comment).Error(0)
+}
+
+func (m *MockBountyRepository) ApproveBounty(id, creatorID, comment string) (*models.Bounty, error) {
+	args := m.Called(id, creatorID, comment)
+
+	if bounty, ok := args.Get(0).(*models.Bounty); ok {
+		return bounty, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBountyRepository) CancelBounty(id, creatorID string) error {
+	return m.Called(id, creatorID).Error(0)
+}
+
+func (m *MockBountyRepository) ExpireBounties(now, claimedBefore time.Time) (int, error) {
+	args := m.Called(now, claimedBefore)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) CreateBudget(budget *models.Budget, holderIDs []string) error {
+	return m.Called(budget, holderIDs).Error(0)
+}
+
+func (m *MockBudgetRepository) GetBudget(id string) (*models.Budget, error) {
+	args := m.Called(id)
+
+	if budget, ok := args.Get(0).(*models.Budget); ok {
+		return budget, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetHeldBudgets(userID string) ([]models.Budget, error) {
+	args := m.Called(userID)
+
+	if budgets, ok := args.Get(0).([]models.Budget); ok {
+		return budgets, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockBudgetRepository) IsHolder(budgetID, userID string) (bool, error) {
+	args := m.Called(budgetID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBudgetRepository) SpendFromBudget(transaction *models.CoinTransaction) error {
+	return m.Called(transaction).Error(0)
+}
+
+func (m *MockBudgetRepository) RefillBudgets(period string, since, now time.Time) (int, error) {
+	args := m.Called(period, since, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetUsage(budgetID, period string) ([]models.BudgetUsage, error) {
+	args := m.Called(budgetID, period)
+
+	if usage, ok := args.Get(0).([]models.BudgetUsage); ok {
+		return usage, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockGiftRepository struct {
+	mock.Mock
+}
+
+func (m *MockGiftRepository) CreateGift(gift *models.Gift, order *models.PurchaseOrder) error {
+	return m.Called(gift, order).Error(0)
+}
+
+func (m *MockGiftRepository) GetUserGifts(userID string) ([]models.Gift, error) {
+	args := m.Called(userID)
+
+	if gifts, ok := args.Get(0).([]models.Gift); ok {
+		return gifts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockGiftRepository) DeclineGift(id, recipientID string) error {
+	return m.Called(id, recipientID).Error(0)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) GetUserByIdentity(issuer, subject string) (*models.User, error) {
+	args := m.Called(issuer, subject)
+
+	if user, ok := args.Get(0).(*models.User); ok {
+		return user, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockIdentityRepository) CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error {
+	return m.Called(user, identity).Error(0)
+}
+
+func (m *MockIdentityRepository) UpdateIdentityEmail(issuer, subject, email string) error {
+	return m.Called(issuer, subject, email).Error(0)
+}
+
+func (m *MockIdentityRepository) SaveLoginState(state *models.SSOLoginState) error {
+	return m.Called(state).Error(0)
+}
+
+func (m *MockIdentityRepository) ConsumeLoginState(state string, now time.Time) (*models.SSOLoginState, error) {
+	args := m.Called(state, now)
+
+	if login, ok := args.Get(0).(*models.SSOLoginState); ok {
+		return login, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockIdentityRepository) DeleteExpiredLoginStates(now time.Time) error {
+	return m.Called(now).Error(0)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockKudosRepository struct {
+	mock.Mock
+}
+
+func (m *MockKudosRepository) GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error) {
+	args := m.Called(filter)
+
+	if entries, ok := args.Get(0).([]models.KudosEntry); ok {
+		return entries, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockKudosRepository) GetReactionCounts(transactionIDs []string) ([]models.ReactionCount, error) {
+	args := m.Called(transactionIDs)
+
+	if counts, ok := args.Get(0).([]models.ReactionCount); ok {
+		return counts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockKudosRepository) IsPublic(transactionID string) (bool, error) {
+	args := m.Called(transactionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKudosRepository) AddReaction(reaction *models.KudosReaction) error {
+	return m.Called(reaction).Error(0)
+}
+
+func (m *MockKudosRepository) RemoveReaction(transactionID, userID, emoji string) error {
+	return m.Called(transactionID, userID, emoji).Error(0)
+}
+
+func (m *MockKudosRepository) AddComment(comment *models.KudosComment) error {
+	return m.Called(comment).Error(0)
+}
+
+func (m *MockKudosRepository) GetComments(transactionID string) ([]models.KudosComment, error) {
+	args := m.Called(transactionID)
+
+	if comments, ok := args.Get(0).([]models.KudosComment); ok {
+		return comments, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
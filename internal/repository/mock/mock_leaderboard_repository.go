@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) RefreshLeaderboards(periods map[string]time.Time, size int, now time.Time) error {
+	return m.Called(periods, size, now).Error(0)
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboard(board, period string) ([]models.LeaderboardEntry, error) {
+	args := m.Called(board, period)
+
+	if entries, ok := args.Get(0).([]models.LeaderboardEntry); ok {
+		return entries, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockMarketRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketRepository) CreateListing(listing *models.Listing) error {
+	return m.Called(listing).Error(0)
+}
+
+func (m *MockMarketRepository) GetListing(id string) (*models.Listing, error) {
+	args := m.Called(id)
+
+	if listing, ok := args.Get(0).(*models.Listing); ok {
+		return listing, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockMarketRepository) SearchListings(filter models.ListingFilter, now time.Time) ([]models.ListingInfo, error) {
+	args := m.Called(filter, now)
+
+	if listings, ok := args.Get(0).([]models.ListingInfo); ok {
+		return listings, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockMarketRepository) GetUserListings(userID string) ([]models.Listing, error) {
+	args := m.Called(userID)
+
+	if listings, ok := args.Get(0).([]models.Listing); ok {
+		return listings, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockMarketRepository) CancelListing(id, sellerID string) error {
+	return m.Called(id, sellerID).Error(0)
+}
+
+func (m *MockMarketRepository) BuyListing(id, buyerID string, now time.Time) (*models.Listing, error) {
+	args := m.Called(id, buyerID, now)
+
+	if listing, ok := args.Get(0).(*models.Listing); ok {
+		return listing, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockMarketRepository) ExpireListings(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockPricingRepository struct {
+	mock.Mock
+}
+
+func (m *MockPricingRepository) GetScheduledPrice(itemName string, at time.Time) (*models.PriceChange, error) {
+	args := m.Called(itemName, at)
+
+	if change, ok := args.Get(0).(*models.PriceChange); ok {
+		return change, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockPricingRepository) GetPriceHistory(itemName string) ([]models.PriceChange, error) {
+	args := m.Called(itemName)
+
+	if changes, ok := args.Get(0).([]models.PriceChange); ok {
+		return changes, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockPricingRepository) SchedulePrice(change *models.PriceChange) error {
+	return m.Called(change).Error(0)
+}
+
+func (m *MockPricingRepository) GetActivePromotions(itemName string, at time.Time) ([]models.Promotion, error) {
+	args := m.Called(itemName, at)
+
+	if promotions, ok := args.Get(0).([]models.Promotion); ok {
+		return promotions, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockPricingRepository) CreatePromotion(promotion *models.Promotion) error {
+	return m.Called(promotion).Error(0)
+}
+
+func (m *MockPricingRepository) GetPromoCode(code string) (*models.PromoCode, error) {
+	args := m.Called(code)
+
+	if promoCode, ok := args.Get(0).(*models.PromoCode); ok {
+		return promoCode, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockPricingRepository) CreatePromoCode(code *models.PromoCode) error {
+	return m.Called(code).Error(0)
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"avito-shop-test/internal/models"
@@ -10,8 +12,8 @@ type MockPurchaseRepository struct {
 	mock.Mock
 }
 
-func (m *MockPurchaseRepository) RecordPurchase(inventory *models.Inventory) error {
-	args := m.Called(inventory)
+func (m *MockPurchaseRepository) RecordPurchase(order *models.PurchaseOrder) error {
+	args := m.Called(order)
 	return args.Error(0)
 }
 
@@ -24,3 +26,18 @@ func (m *MockPurchaseRepository) GetPurchasedItems(userID string) ([]models.Inve
 
 	return nil, args.Error(1)
 }
+
+func (m *MockPurchaseRepository) GetPurchaseByID(id string) (*models.Inventory, error) {
+	args := m.Called(id)
+
+	if purchase, ok := args.Get(0).(*models.Inventory); ok {
+		return purchase, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockPurchaseRepository) CountPurchases(userID, itemType string, since time.Time) (int, error) {
+	args := m.Called(userID, itemType, since)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockRaffleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRaffleRepository) CreateRaffle(raffle *models.Raffle) error {
+	return m.Called(raffle).Error(0)
+}
+
+func (m *MockRaffleRepository) GetRaffle(id string) (*models.Raffle, error) {
+	args := m.Called(id)
+
+	if raffle, ok := args.Get(0).(*models.Raffle); ok {
+		return raffle, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRaffleRepository) GetRaffles() ([]models.Raffle, error) {
+	args := m.Called()
+
+	if raffles, ok := args.Get(0).([]models.Raffle); ok {
+		return raffles, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRaffleRepository) GetDueRaffles(now time.Time) ([]models.Raffle, error) {
+	args := m.Called(now)
+
+	if raffles, ok := args.Get(0).([]models.Raffle); ok {
+		return raffles, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRaffleRepository) GetWinningTickets(raffleID string) ([]models.RaffleTicket, error) {
+	args := m.Called(raffleID)
+
+	if tickets, ok := args.Get(0).([]models.RaffleTicket); ok {
+		return tickets, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRaffleRepository) BuyTickets(id, userID string, count int, now time.Time) ([]models.RaffleTicket, error) {
+	args := m.Called(id, userID, count, now)
+
+	if tickets, ok := args.Get(0).([]models.RaffleTicket); ok {
+		return tickets, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRaffleRepository) CompleteDraw(id string, ticketsSold int, numbers []int) error {
+	return m.Called(id, ticketsSold, numbers).Error(0)
+}
+
+func (m *MockRaffleRepository) CancelRaffle(id string) error {
+	return m.Called(id).Error(0)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockReturnRepository struct {
+	mock.Mock
+}
+
+func (m *MockReturnRepository) CreateReturn(ret *models.ReturnRequest) error {
+	return m.Called(ret).Error(0)
+}
+
+func (m *MockReturnRepository) GetReturnByID(id string) (*models.ReturnRequest, error) {
+	args := m.Called(id)
+
+	if ret, ok := args.Get(0).(*models.ReturnRequest); ok {
+		return ret, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockReturnRepository) GetUserReturns(userID string) ([]models.ReturnRequest, error) {
+	args := m.Called(userID)
+
+	if returns, ok := args.Get(0).([]models.ReturnRequest); ok {
+		return returns, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockReturnRepository) GetPendingReturns() ([]models.ReturnRequest, error) {
+	args := m.Called()
+
+	if returns, ok := args.Get(0).([]models.ReturnRequest); ok {
+		return returns, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockReturnRepository) ApproveReturn(id, reviewerID, comment string) error {
+	return m.Called(id, reviewerID, comment).Error(0)
+}
+
+func (m *MockReturnRepository) RejectReturn(id, reviewerID, comment string) error {
+	return m.Called(id, reviewerID, comment).Error(0)
+}
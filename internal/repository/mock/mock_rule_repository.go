@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) GetRule(itemName string) (*models.ItemRule, error) {
+	args := m.Called(itemName)
+
+	if rule, ok := args.Get(0).(*models.ItemRule); ok {
+		return rule, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockRuleRepository) SaveRule(rule *models.ItemRule) error {
+	return m.Called(rule).Error(0)
+}
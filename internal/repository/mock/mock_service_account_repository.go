@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockServiceAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountRepository) CreateServiceAccount(actorID string, account *models.User) error {
+	return m.Called(actorID, account).Error(0)
+}
+
+func (m *MockServiceAccountRepository) GetServiceAccounts() ([]models.User, error) {
+	args := m.Called()
+
+	if accounts, ok := args.Get(0).([]models.User); ok {
+		return accounts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) GetAPIKeys(userIDs []string) ([]models.APIKey, error) {
+	args := m.Called(userIDs)
+
+	if keys, ok := args.Get(0).([]models.APIKey); ok {
+		return keys, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) GetAPIKey(id string) (*models.APIKey, error) {
+	args := m.Called(id)
+
+	if key, ok := args.Get(0).(*models.APIKey); ok {
+		return key, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) GetAPIKeyByPrefix(prefix string) (*models.APIKey, error) {
+	args := m.Called(prefix)
+
+	if key, ok := args.Get(0).(*models.APIKey); ok {
+		return key, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) CreateAPIKey(actorID string, key *models.APIKey) error {
+	return m.Called(actorID, key).Error(0)
+}
+
+func (m *MockServiceAccountRepository) RotateAPIKey(actorID, oldID string, key *models.APIKey, oldRevokedAt time.Time) error {
+	return m.Called(actorID, oldID, key, oldRevokedAt).Error(0)
+}
+
+func (m *MockServiceAccountRepository) RevokeAPIKey(actorID, id string, at time.Time) error {
+	return m.Called(actorID, id, at).Error(0)
+}
+
+func (m *MockServiceAccountRepository) TouchAPIKey(id string, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
@@ -19,3 +19,13 @@ func (m *MockStoreRepository) GetItemByName(name string) (*models.Product, error
 
 	return nil, args.Error(1)
 }
+
+func (m *MockStoreRepository) GetItems() ([]models.Product, error) {
+	args := m.Called()
+
+	if items, ok := args.Get(0).([]models.Product); ok {
+		return items, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return m.Called(token).Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	args := m.Called(tokenHash)
+
+	if token, ok := args.Get(0).(*models.RefreshToken); ok {
+		return token, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockTokenRepository) UseRefreshToken(tokenHash string, now time.Time) (*models.RefreshToken, bool, error) {
+	args := m.Called(tokenHash, now)
+
+	if token, ok := args.Get(0).(*models.RefreshToken); ok {
+		return token, args.Bool(1), args.Error(2)
+	}
+
+	return nil, args.Bool(1), args.Error(2)
+}
+
+func (m *MockTokenRepository) RevokeFamily(familyID string, now time.Time) error {
+	return m.Called(familyID, now).Error(0)
+}
+
+func (m *MockTokenRepository) RevokeUserTokens(userID string, now time.Time) error {
+	return m.Called(userID, now).Error(0)
+}
+
+func (m *MockTokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return m.Called(jti, expiresAt).Error(0)
+}
+
+func (m *MockTokenRepository) IsRevoked(jti string) (bool, error) {
+	args := m.Called(jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) DeleteExpired(now time.Time) error {
+	return m.Called(now).Error(0)
+}
+
+func (m *MockTokenRepository) CreateSession(session *models.Session) error {
+	return m.Called(session).Error(0)
+}
+
+func (m *MockTokenRepository) ExtendSession(id, ip string, now, expiresAt time.Time) error {
+	return m.Called(id, ip, now, expiresAt).Error(0)
+}
+
+func (m *MockTokenRepository) TouchSession(id, ip string, now time.Time) (bool, error) {
+	args := m.Called(id, ip, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) GetSessions(userID string, now time.Time) ([]models.Session, error) {
+	args := m.Called(userID, now)
+
+	if sessions, ok := args.Get(0).([]models.Session); ok {
+		return sessions, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeSession(userID, id string, now time.Time) (bool, error) {
+	args := m.Called(userID, id, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeOtherSessions(userID, keepID string, now time.Time) error {
+	return m.Called(userID, keepID, now).Error(0)
+}
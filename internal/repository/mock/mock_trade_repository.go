@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockTradeRepository struct {
+	mock.Mock
+}
+
+func (m *MockTradeRepository) CreateTrade(trade *models.Trade) error {
+	return m.Called(trade).Error(0)
+}
+
+func (m *MockTradeRepository) GetUserTrades(userID string) ([]models.Trade, error) {
+	args := m.Called(userID)
+
+	if trades, ok := args.Get(0).([]models.Trade); ok {
+		return trades, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockTradeRepository) AcceptTrade(id, counterpartyID string) error {
+	return m.Called(id, counterpartyID).Error(0)
+}
+
+func (m *MockTradeRepository) RejectTrade(id, counterpartyID string) error {
+	return m.Called(id, counterpartyID).Error(0)
+}
+
+func (m *MockTradeRepository) CancelTrade(id, proposerID string) error {
+	return m.Called(id, proposerID).Error(0)
+}
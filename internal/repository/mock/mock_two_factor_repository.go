@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) SaveTOTPSecret(userID, secret string) error {
+	return m.Called(userID, secret).Error(0)
+}
+
+func (m *MockTwoFactorRepository) EnableTOTP(userID string, step int64, codeHashes []string) error {
+	return m.Called(userID, step, codeHashes).Error(0)
+}
+
+func (m *MockTwoFactorRepository) DisableTOTP(userID string) error {
+	return m.Called(userID).Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseTOTPStep(userID string, step int64) (bool, error) {
+	args := m.Called(userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(userID, codeHash string, at time.Time) (bool, error) {
+	args := m.Called(userID, codeHash, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	return m.Called(userID, codeHashes).Error(0)
+}
+
+func (m *MockTwoFactorRepository) CountRecoveryCodes(userID string) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CreateChallenge(challenge *models.MFAChallenge) error {
+	return m.Called(challenge).Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseChallenge(id string, now time.Time, maxAttempts int) (*models.MFAChallenge, error) {
+	args := m.Called(id, now, maxAttempts)
+
+	if challenge, ok := args.Get(0).(*models.MFAChallenge); ok {
+		return challenge, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) DeleteChallenge(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockTwoFactorRepository) DeleteExpiredChallenges(now time.Time) error {
+	return m.Called(now).Error(0)
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"avito-shop-test/internal/models"
@@ -10,11 +12,6 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-// GetUserByUserID implements usecase.UserRepository.
-func (m *MockUserRepository) GetUserByUserID(userID string) (*models.User, error) {
-	panic("unimplemented")
-}
-
 func (m *MockUserRepository) FindUserByUsername(username string) (*models.User, error) {
 	args := m.Called(username)
 
@@ -25,7 +22,7 @@ func (m *MockUserRepository) FindUserByUsername(username string) (*models.User,
 	return nil, args.Error(1)
 }
 
-func (m *MockPurchaseRepository) GetUserByUserID(userID string) (*models.User, error) {
+func (m *MockUserRepository) GetUserByUserID(userID string) (*models.User, error) {
 	args := m.Called(userID)
 
 	if user, ok := args.Get(0).(*models.User); ok {
@@ -42,3 +39,15 @@ func (m *MockUserRepository) CreateUser(user *models.User) error {
 func (m *MockUserRepository) UpdateUserBalance(username string, amount int) error {
 	return m.Called(username, amount).Error(0)
 }
+
+func (m *MockUserRepository) UpdateUserAttributes(username, department string, hiredAt *time.Time) error {
+	return m.Called(username, department, hiredAt).Error(0)
+}
+
+func (m *MockUserRepository) UpdatePrivacy(username string, settings models.PrivacySettings) error {
+	return m.Called(username, settings).Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(username, hash string) error {
+	return m.Called(username, hash).Error(0)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockVariantRepository struct {
+	mock.Mock
+}
+
+func (m *MockVariantRepository) GetItemOptions(itemName string) ([]models.ItemOption, error) {
+	args := m.Called(itemName)
+
+	if options, ok := args.Get(0).([]models.ItemOption); ok {
+		return options, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) AddItemOptions(options []models.ItemOption) error {
+	return m.Called(options).Error(0)
+}
+
+func (m *MockVariantRepository) GetSKUs(itemName string) ([]models.SKU, error) {
+	args := m.Called(itemName)
+
+	if skus, ok := args.Get(0).([]models.SKU); ok {
+		return skus, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) GetSKUByCode(code string) (*models.SKU, error) {
+	args := m.Called(code)
+
+	if sku, ok := args.Get(0).(*models.SKU); ok {
+		return sku, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) CreateSKU(sku *models.SKU) error {
+	return m.Called(sku).Error(0)
+}
+
+func (m *MockVariantRepository) AdjustStock(code string, delta int) error {
+	return m.Called(code, delta).Error(0)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+)
+
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWishlistRepository) GetWishlist(userID string) (*models.Wishlist, error) {
+	args := m.Called(userID)
+
+	if wishlist, ok := args.Get(0).(*models.Wishlist); ok {
+		return wishlist, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) GetWishlistByToken(token string) (*models.Wishlist, error) {
+	args := m.Called(token)
+
+	if wishlist, ok := args.Get(0).(*models.Wishlist); ok {
+		return wishlist, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) CreateWishlist(wishlist *models.Wishlist) error {
+	return m.Called(wishlist).Error(0)
+}
+
+func (m *MockWishlistRepository) AddItem(item *models.WishlistItem) error {
+	return m.Called(item).Error(0)
+}
+
+func (m *MockWishlistRepository) GetItems(userID string) ([]models.WishlistItem, error) {
+	args := m.Called(userID)
+
+	if items, ok := args.Get(0).([]models.WishlistItem); ok {
+		return items, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) GetItem(id string) (*models.WishlistItem, error) {
+	args := m.Called(id)
+
+	if item, ok := args.Get(0).(*models.WishlistItem); ok {
+		return item, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) RemoveItem(id, userID string) error {
+	return m.Called(id, userID).Error(0)
+}
+
+func (m *MockWishlistRepository) StartCampaign(id, userID string, target int, expiresAt time.Time) error {
+	return m.Called(id, userID, target, expiresAt).Error(0)
+}
+
+func (m *MockWishlistRepository) Contribute(id, contributorID string, amount int) (*models.WishlistItem, error) {
+	args := m.Called(id, contributorID, amount)
+
+	if item, ok := args.Get(0).(*models.WishlistItem); ok {
+		return item, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) ExpireCampaigns(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
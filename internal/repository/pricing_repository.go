@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+type PricingRepository interface {
+	GetScheduledPrice(itemName string, at time.Time) (*models.PriceChange, error)
+	GetPriceHistory(itemName string) ([]models.PriceChange, error)
+	SchedulePrice(change *models.PriceChange) error
+	GetActivePromotions(itemName string, at time.Time) ([]models.Promotion, error)
+	CreatePromotion(promotion *models.Promotion) error
+	GetPromoCode(code string) (*models.PromoCode, error)
+	CreatePromoCode(code *models.PromoCode) error
+}
+
+type pricingRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingRepository(db *gorm.DB) PricingRepository {
+	return &pricingRepository{db: db}
+}
+
+func (r *pricingRepository) GetScheduledPrice(itemName string, at time.Time) (*models.PriceChange, error) {
+	var change models.PriceChange
+	err := r.db.Where("item_name = ? AND effective_at <= ?", itemName, at).
+		Order("effective_at DESC, created_at DESC").Take(&change).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table price_changes)")
+	}
+	return &change, nil
+}
+
+func (r *pricingRepository) GetPriceHistory(itemName string) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	err := r.db.Where("item_name = ?", itemName).Order("effective_at, created_at").Find(&changes).Error
+	return changes, err
+}
+
+func (r *pricingRepository) SchedulePrice(change *models.PriceChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *pricingRepository) GetActivePromotions(itemName string, at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.db.Where("(item_name = ? OR item_name IS NULL OR item_name = '') AND starts_at <= ? AND ends_at > ?", itemName, at, at).
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *pricingRepository) CreatePromotion(promotion *models.Promotion) error {
+	return r.db.Create(promotion).Error
+}
+
+func (r *pricingRepository) GetPromoCode(code string) (*models.PromoCode, error) {
+	var promoCode models.PromoCode
+	err := r.db.Where("code = ?", code).Take(&promoCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table promo_codes)")
+	}
+	return &promoCode, nil
+}
+
+func (r *pricingRepository) CreatePromoCode(code *models.PromoCode) error {
+	if err := r.db.Create(code).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("промокод уже существует")
+		}
+		return errors.Wrap(err, "database error (table promo_codes)")
+	}
+	return nil
+}
+
+func redeemPromoCode(tx *gorm.DB, code, userID string) error {
+	redeemed := tx.Model(&models.PromoCode{}).
+		Where("code = ? AND uses < max_uses", code).
+		Update("uses", gorm.Expr("uses + 1"))
+	if redeemed.Error != nil {
+		return redeemed.Error
+	}
+	if redeemed.RowsAffected == 0 {
+		return errors.New("промокод уже использован")
+	}
+
+	return tx.Exec("INSERT INTO promo_code_redemptions (code, user_id) VALUES (?, ?)", code, userID).Error
+}
@@ -1,14 +1,19 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"avito-shop-test/internal/models"
 )
 
 type PurchaseRepository interface {
-	RecordPurchase(inventory *models.Inventory) error
+	RecordPurchase(order *models.PurchaseOrder) error
 	GetPurchasedItems(userID string) ([]models.Inventory, error)
+	GetPurchaseByID(id string) (*models.Inventory, error)
+	CountPurchases(userID, itemType string, since time.Time) (int, error)
 }
 
 type purchaseRepository struct {
@@ -19,11 +24,43 @@ func NewPurchaseRepository(db *gorm.DB) PurchaseRepository {
 	return &purchaseRepository{db: db}
 }
 
-func (r *purchaseRepository) RecordPurchase(inventory *models.Inventory) error {
-	if err := r.db.Create(&inventory).Error; err != nil {
+func (r *purchaseRepository) RecordPurchase(order *models.PurchaseOrder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return placeOrder(tx, order)
+	})
+}
+
+func placeOrder(tx *gorm.DB, order *models.PurchaseOrder) error {
+	if err := lockUsers(tx, order.PayerID, order.Inventory.UserID); err != nil {
 		return err
 	}
-	return nil
+
+	if err := debitBalance(tx, order.PayerID, order.Amount, "недостаточно монет для покупки"); err != nil {
+		return err
+	}
+
+	if err := takeStock(tx, order.Inventory.SKU); err != nil {
+		return err
+	}
+
+	if order.PromoCode != "" {
+		if err := redeemPromoCode(tx, order.PromoCode, order.PayerID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Create(order.Inventory).Error; err != nil {
+		return errors.Wrap(err, "database error (table inventory)")
+	}
+
+	return recordPurchase(tx, &models.Purchase{
+		UserID:      order.Inventory.UserID,
+		PayerID:     &order.PayerID,
+		InventoryID: &order.Inventory.ID,
+		ItemType:    order.Inventory.ItemType,
+		SKU:         order.Inventory.SKU,
+		Price:       order.Amount,
+	})
 }
 
 func (r *purchaseRepository) GetPurchasedItems(userID string) ([]models.Inventory, error) {
@@ -34,3 +71,73 @@ func (r *purchaseRepository) GetPurchasedItems(userID string) ([]models.Inventor
 	}
 	return purchases, nil
 }
+
+func (r *purchaseRepository) GetPurchaseByID(id string) (*models.Inventory, error) {
+	var purchase models.Inventory
+	err := r.db.Where("id = ?", id).Take(&purchase).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table inventory)")
+	}
+	return &purchase, nil
+}
+
+func (r *purchaseRepository) CountPurchases(userID, itemType string, since time.Time) (int, error) {
+	return countPurchases(r.db, userID, itemType, since)
+}
+
+func countPurchases(db *gorm.DB, userID, itemType string, since time.Time) (int, error) {
+	var count int64
+	err := db.Model(&models.Purchase{}).
+		Where("user_id = ? AND item_type = ? AND created_at >= ? AND refunded_at IS NULL", userID, itemType, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "database error (table purchases)")
+	}
+	return int(count), nil
+}
+
+func recordPurchase(tx *gorm.DB, purchase *models.Purchase) error {
+	if err := lockUsers(tx, purchase.UserID); err != nil {
+		return err
+	}
+
+	purchase.CreatedAt = time.Now()
+	var rule models.ItemRule
+	err := tx.Where("item_name = ?", purchase.ItemType).Take(&rule).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, "database error (table item_rules)")
+	}
+	if err == nil {
+		count := func(since time.Time) (int, error) {
+			return countPurchases(tx, purchase.UserID, purchase.ItemType, since)
+		}
+		if err := rule.CheckLimits(count, purchase.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return logPurchase(tx, purchase)
+}
+
+func logPurchase(tx *gorm.DB, purchase *models.Purchase) error {
+	if purchase.CreatedAt.IsZero() {
+		purchase.CreatedAt = time.Now()
+	}
+	if err := tx.Create(purchase).Error; err != nil {
+		return errors.Wrap(err, "database error (table purchases)")
+	}
+	return nil
+}
+
+func refundPurchase(tx *gorm.DB, inventoryID string) error {
+	err := tx.Model(&models.Purchase{}).
+		Where("inventory_id = ? AND refunded_at IS NULL", inventoryID).
+		Update("refunded_at", time.Now()).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table purchases)")
+	}
+	return nil
+}
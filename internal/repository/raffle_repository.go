@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type RaffleRepository interface {
+	CreateRaffle(raffle *models.Raffle) error
+	GetRaffle(id string) (*models.Raffle, error)
+	GetRaffles() ([]models.Raffle, error)
+	GetDueRaffles(now time.Time) ([]models.Raffle, error)
+	GetWinningTickets(raffleID string) ([]models.RaffleTicket, error)
+	BuyTickets(id, userID string, count int, now time.Time) ([]models.RaffleTicket, error)
+	CompleteDraw(id string, ticketsSold int, numbers []int) error
+	CancelRaffle(id string) error
+}
+
+type raffleRepository struct {
+	db *gorm.DB
+}
+
+func NewRaffleRepository(db *gorm.DB) RaffleRepository {
+	return &raffleRepository{db: db}
+}
+
+func (r *raffleRepository) CreateRaffle(raffle *models.Raffle) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < raffle.Winners; i++ {
+			if err := takeStock(tx, raffle.SKU); err != nil {
+				return err
+			}
+		}
+
+		raffle.Status = models.RaffleStatusOpen
+		if err := tx.Create(raffle).Error; err != nil {
+			return errors.Wrap(err, "database error (table raffles)")
+		}
+		return nil
+	})
+}
+
+func (r *raffleRepository) GetRaffle(id string) (*models.Raffle, error) {
+	var raffle models.Raffle
+	err := r.db.Where("id = ?", id).Take(&raffle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table raffles)")
+	}
+	return &raffle, nil
+}
+
+func (r *raffleRepository) GetRaffles() ([]models.Raffle, error) {
+	var raffles []models.Raffle
+	err := r.db.Order("draw_at DESC").Find(&raffles).Error
+	return raffles, err
+}
+
+func (r *raffleRepository) GetDueRaffles(now time.Time) ([]models.Raffle, error) {
+	var raffles []models.Raffle
+	err := r.db.Where("status = ? AND draw_at <= ?", models.RaffleStatusOpen, now).Find(&raffles).Error
+	return raffles, err
+}
+
+func (r *raffleRepository) GetWinningTickets(raffleID string) ([]models.RaffleTicket, error) {
+	var tickets []models.RaffleTicket
+	err := r.db.Where("raffle_id = ? AND status = ?", raffleID, models.TicketStatusWon).Find(&tickets).Error
+	return tickets, err
+}
+
+func (r *raffleRepository) BuyTickets(id, userID string, count int, now time.Time) ([]models.RaffleTicket, error) {
+	var tickets []models.RaffleTicket
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		raffle, err := lockOpenRaffle(tx, id)
+		if err != nil {
+			return err
+		}
+		if !now.Before(raffle.DrawAt) {
+			return errors.New("продажа билетов закрыта")
+		}
+		if raffle.MaxTickets > 0 && raffle.TicketsSold+count > raffle.MaxTickets {
+			return errors.New("недостаточно свободных билетов")
+		}
+		if raffle.MaxTicketsPerUser > 0 {
+			var owned int64
+			err := tx.Model(&models.RaffleTicket{}).Where("raffle_id = ? AND user_id = ?", id, userID).Count(&owned).Error
+			if err != nil {
+				return err
+			}
+			if int(owned)+count > raffle.MaxTicketsPerUser {
+				return errors.New("превышен лимит билетов на сотрудника")
+			}
+		}
+
+		if err := debitBalance(tx, userID, raffle.TicketPrice*count, "недостаточно монет для покупки билетов"); err != nil {
+			return err
+		}
+
+		for i := 1; i <= count; i++ {
+			tickets = append(tickets, models.RaffleTicket{
+				RaffleID: id,
+				UserID:   userID,
+				Number:   raffle.TicketsSold + i,
+				Status:   models.TicketStatusActive,
+			})
+		}
+		if err := tx.Create(&tickets).Error; err != nil {
+			return errors.Wrap(err, "database error (table raffle_tickets)")
+		}
+
+		return tx.Model(&models.Raffle{}).Where("id = ?", id).
+			Update("tickets_sold", raffle.TicketsSold+count).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *raffleRepository) CompleteDraw(id string, ticketsSold int, numbers []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		raffle, err := lockOpenRaffle(tx, id)
+		if err != nil {
+			return err
+		}
+		if raffle.TicketsSold != ticketsSold {
+			return errors.New("количество билетов изменилось во время розыгрыша")
+		}
+
+		for _, number := range numbers {
+			var ticket models.RaffleTicket
+			if err := tx.Where("raffle_id = ? AND number = ?", id, number).Take(&ticket).Error; err != nil {
+				return errors.Wrap(err, "database error (table raffle_tickets)")
+			}
+
+			err := tx.Model(&models.RaffleTicket{}).Where("id = ?", ticket.ID).
+				Update("status", models.TicketStatusWon).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Create(rafflePrize(raffle, ticket.UserID)).Error; err != nil {
+				return errors.Wrap(err, "database error (table inventory)")
+			}
+		}
+
+		for i := len(numbers); i < raffle.Winners; i++ {
+			if err := returnStock(tx, raffle.SKU); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Raffle{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":   models.RaffleStatusDrawn,
+			"drawn_at": time.Now(),
+		}).Error
+	})
+}
+
+func rafflePrize(raffle *models.Raffle, winnerID string) *models.Inventory {
+	return &models.Inventory{
+		UserID:   winnerID,
+		ItemType: raffle.ItemName,
+		Quantity: 1,
+		SKU:      raffle.SKU,
+		Variant:  raffle.Variant,
+		Price:    &raffle.TicketPrice,
+		NoReturn: true,
+	}
+}
+
+func (r *raffleRepository) CancelRaffle(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		raffle, err := lockOpenRaffle(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var refunds []struct {
+			UserID  string
+			Tickets int
+		}
+		err = tx.Model(&models.RaffleTicket{}).
+			Select("user_id, COUNT(*) AS tickets").
+			Where("raffle_id = ? AND status = ?", id, models.TicketStatusActive).
+			Group("user_id").Scan(&refunds).Error
+		if err != nil {
+			return err
+		}
+		for _, refund := range refunds {
+			if err := creditBalance(tx, refund.UserID, refund.Tickets*raffle.TicketPrice); err != nil {
+				return err
+			}
+		}
+
+		err = tx.Model(&models.RaffleTicket{}).
+			Where("raffle_id = ? AND status = ?", id, models.TicketStatusActive).
+			Update("status", models.TicketStatusRefunded).Error
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < raffle.Winners; i++ {
+			if err := returnStock(tx, raffle.SKU); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Raffle{}).Where("id = ?", id).Update("status", models.RaffleStatusCancelled).Error
+	})
+}
+
+func lockOpenRaffle(tx *gorm.DB, id string) (*models.Raffle, error) {
+	var raffle models.Raffle
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&raffle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("розыгрыш не найден")
+		}
+		return nil, err
+	}
+	if raffle.Status != models.RaffleStatusOpen {
+		return nil, errors.New("розыгрыш уже завершён")
+	}
+	return &raffle, nil
+}
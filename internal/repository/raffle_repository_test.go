@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"avito-shop-test/internal/models"
+)
+
+func TestRafflePrize_NotReturnable(t *testing.T) {
+	raffle := &models.Raffle{ItemName: "hoody", SKU: "hoody-m", Variant: "size: M", TicketPrice: 10}
+
+	prize := rafflePrize(raffle, "user-ID-1")
+
+	assert.Equal(t, "user-ID-1", prize.UserID)
+	assert.Equal(t, 1, prize.Quantity)
+	assert.Equal(t, 10, *prize.Price)
+	assert.True(t, prize.NoReturn)
+}
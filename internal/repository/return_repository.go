@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type ReturnRepository interface {
+	CreateReturn(ret *models.ReturnRequest) error
+	GetReturnByID(id string) (*models.ReturnRequest, error)
+	GetUserReturns(userID string) ([]models.ReturnRequest, error)
+	GetPendingReturns() ([]models.ReturnRequest, error)
+	ApproveReturn(id, reviewerID, comment string) error
+	RejectReturn(id, reviewerID, comment string) error
+}
+
+var errReturnExists = errors.New("по этой покупке уже оформлен возврат")
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) ReturnRepository {
+	return &returnRepository{db: db}
+}
+
+func (r *returnRepository) CreateReturn(ret *models.ReturnRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var active int64
+		err := tx.Model(&models.ReturnRequest{}).
+			Where("inventory_id = ? AND status IN ?", ret.InventoryID, []string{models.ReturnStatusPending, models.ReturnStatusApproved}).
+			Count(&active).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table returns)")
+		}
+		if active > 0 {
+			return errReturnExists
+		}
+
+		if err := tx.Create(ret).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errReturnExists
+			}
+			return errors.Wrap(err, "database error (table returns)")
+		}
+		return nil
+	})
+}
+
+func (r *returnRepository) GetReturnByID(id string) (*models.ReturnRequest, error) {
+	var ret models.ReturnRequest
+	err := r.db.Where("id = ?", id).Take(&ret).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table returns)")
+	}
+	return &ret, nil
+}
+
+func (r *returnRepository) GetUserReturns(userID string) ([]models.ReturnRequest, error) {
+	var returns []models.ReturnRequest
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&returns).Error
+	return returns, err
+}
+
+func (r *returnRepository) GetPendingReturns() ([]models.ReturnRequest, error) {
+	var returns []models.ReturnRequest
+	err := r.db.Where("status = ?", models.ReturnStatusPending).Order("created_at").Find(&returns).Error
+	return returns, err
+}
+
+func (r *returnRepository) ApproveReturn(id, reviewerID, comment string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ret, err := lockPendingReturn(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var inventory models.Inventory
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", ret.InventoryID, ret.UserID).Take(&inventory).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("товар уже отсутствует в инвентаре")
+			}
+			return errors.Wrap(err, "database error (table inventory)")
+		}
+		if inventory.NoReturn {
+			return errors.New("этот товар нельзя вернуть")
+		}
+
+		if err := refundPurchase(tx, inventory.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&inventory).Error; err != nil {
+			return errors.Wrap(err, "database error (table inventory)")
+		}
+
+		if err := creditBalance(tx, ret.UserID, ret.Amount); err != nil {
+			return err
+		}
+
+		if err := returnStock(tx, inventory.SKU); err != nil {
+			return err
+		}
+
+		if err := closeReturn(tx, ret, models.ReturnStatusApproved, reviewerID, comment); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    reviewerID,
+			Action:     "return.approve",
+			EntityType: "return",
+			EntityID:   ret.ID,
+			Details:    fmt.Sprintf("refunded %d coins for %d x %s", ret.Amount, ret.Quantity, ret.ItemType),
+		}).Error
+	})
+}
+
+func (r *returnRepository) RejectReturn(id, reviewerID, comment string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ret, err := lockPendingReturn(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := closeReturn(tx, ret, models.ReturnStatusRejected, reviewerID, comment); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    reviewerID,
+			Action:     "return.reject",
+			EntityType: "return",
+			EntityID:   ret.ID,
+			Details:    comment,
+		}).Error
+	})
+}
+
+func lockPendingReturn(tx *gorm.DB, id string) (*models.ReturnRequest, error) {
+	var ret models.ReturnRequest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&ret).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("заявка на возврат не найдена")
+		}
+		return nil, err
+	}
+	if ret.Status != models.ReturnStatusPending {
+		return nil, errors.New("заявка на возврат уже рассмотрена")
+	}
+	return &ret, nil
+}
+
+func closeReturn(tx *gorm.DB, ret *models.ReturnRequest, status, reviewerID, comment string) error {
+	return tx.Model(&models.ReturnRequest{}).Where("id = ?", ret.ID).Updates(map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewerID,
+		"comment":     comment,
+		"reviewed_at": time.Now(),
+	}).Error
+}
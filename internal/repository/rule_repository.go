@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type RuleRepository interface {
+	GetRule(itemName string) (*models.ItemRule, error)
+	SaveRule(rule *models.ItemRule) error
+}
+
+type ruleRepository struct {
+	db *gorm.DB
+}
+
+func NewRuleRepository(db *gorm.DB) RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (r *ruleRepository) GetRule(itemName string) (*models.ItemRule, error) {
+	var rule models.ItemRule
+	err := r.db.Where("item_name = ?", itemName).Take(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table item_rules)")
+	}
+	return &rule, nil
+}
+
+func (r *ruleRepository) SaveRule(rule *models.ItemRule) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(rule).Error
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type ServiceAccountRepository interface {
+	CreateServiceAccount(actorID string, account *models.User) error
+	GetServiceAccounts() ([]models.User, error)
+	GetAPIKeys(userIDs []string) ([]models.APIKey, error)
+	GetAPIKey(id string) (*models.APIKey, error)
+	GetAPIKeyByPrefix(prefix string) (*models.APIKey, error)
+	CreateAPIKey(actorID string, key *models.APIKey) error
+	RotateAPIKey(actorID, oldID string, key *models.APIKey, oldRevokedAt time.Time) error
+	RevokeAPIKey(actorID, id string, at time.Time) error
+	TouchAPIKey(id string, at time.Time) error
+}
+
+type serviceAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAccountRepository(db *gorm.DB) ServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+func (r *serviceAccountRepository) CreateServiceAccount(actorID string, account *models.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("пользователь уже существует")
+			}
+			return errors.Wrap(err, "database error (table users)")
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "service_account.create",
+			EntityType: "user",
+			EntityID:   account.ID,
+			Details:    account.Username,
+		}).Error
+	})
+}
+
+func (r *serviceAccountRepository) GetServiceAccounts() ([]models.User, error) {
+	var accounts []models.User
+	err := r.db.Where("service_account = ?", true).Order("username").Find(&accounts).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table users)")
+	}
+	return accounts, nil
+}
+
+func (r *serviceAccountRepository) GetAPIKeys(userIDs []string) ([]models.APIKey, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var keys []models.APIKey
+	err := r.db.Where("user_id IN ?", userIDs).Order("created_at").Find(&keys).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table api_keys)")
+	}
+	return keys, nil
+}
+
+func (r *serviceAccountRepository) GetAPIKey(id string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.Where("id = ?", id).Take(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table api_keys)")
+	}
+	return &key, nil
+}
+
+func (r *serviceAccountRepository) GetAPIKeyByPrefix(prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.Preload("Account").Where("prefix = ?", prefix).Take(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table api_keys)")
+	}
+	return &key, nil
+}
+
+func (r *serviceAccountRepository) CreateAPIKey(actorID string, key *models.APIKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createAPIKey(tx, key); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "api_key.create",
+			EntityType: "api_key",
+			EntityID:   key.ID,
+			Details:    key.Scopes,
+		}).Error
+	})
+}
+
+func (r *serviceAccountRepository) RotateAPIKey(actorID, oldID string, key *models.APIKey, oldRevokedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var old models.APIKey
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", oldID).Take(&old).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("ключ не найден")
+		}
+		if err != nil {
+			return errors.Wrap(err, "database error (table api_keys)")
+		}
+		if old.RevokedAt != nil || !old.Active(time.Now()) {
+			return errors.New("ключ отозван или истёк")
+		}
+
+		err = tx.Model(&old).Update("revoked_at", oldRevokedAt).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table api_keys)")
+		}
+
+		key.UserID = old.UserID
+		key.Scopes = old.Scopes
+		if err := createAPIKey(tx, key); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "api_key.rotate",
+			EntityType: "api_key",
+			EntityID:   old.ID,
+			Details:    key.ID,
+		}).Error
+	})
+}
+
+func createAPIKey(tx *gorm.DB, key *models.APIKey) error {
+	if err := tx.Omit(clause.Associations).Create(key).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("не удалось выпустить ключ, повторите попытку")
+		}
+		return errors.Wrap(err, "database error (table api_keys)")
+	}
+	return nil
+}
+
+func (r *serviceAccountRepository) RevokeAPIKey(actorID, id string, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(&models.APIKey{}).
+			Where("id = ? AND (revoked_at IS NULL OR revoked_at > ?)", id, at).
+			Update("revoked_at", at)
+		if updated.Error != nil {
+			return errors.Wrap(updated.Error, "database error (table api_keys)")
+		}
+		if updated.RowsAffected == 0 {
+			return errors.New("ключ не найден или уже отозван")
+		}
+
+		return tx.Create(&models.AuditRecord{
+			ActorID:    actorID,
+			Action:     "api_key.revoke",
+			EntityType: "api_key",
+			EntityID:   id,
+		}).Error
+	})
+}
+
+func (r *serviceAccountRepository) TouchAPIKey(id string, at time.Time) error {
+	err := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-time.Minute)).
+		Update("last_used_at", at).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table api_keys)")
+	}
+	return nil
+}
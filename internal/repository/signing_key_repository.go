@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+type SigningKeyRepository interface {
+	GetSigningKeys(now time.Time) ([]models.SigningKey, error)
+	RotateSigningKey(key *models.SigningKey, rotateBefore time.Time, retention time.Duration) (bool, error)
+	DeleteExpiredSigningKeys(now time.Time) error
+}
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSigningKeyRepository(db *gorm.DB) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) GetSigningKeys(now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	err := r.db.Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("activates_at").Find(&keys).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table signing_keys)")
+	}
+	return keys, nil
+}
+
+func (r *signingKeyRepository) RotateSigningKey(key *models.SigningKey, rotateBefore time.Time, retention time.Duration) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('signing_keys'))").Error; err != nil {
+			return err
+		}
+
+		var latest models.SigningKey
+		err := tx.Where("expires_at IS NULL").Order("activates_at DESC").Take(&latest).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			key.ActivatesAt = key.CreatedAt
+		case err != nil:
+			return errors.Wrap(err, "database error (table signing_keys)")
+		case latest.Algorithm == key.Algorithm && latest.CreatedAt.After(rotateBefore):
+			return nil
+		}
+
+		err = tx.Model(&models.SigningKey{}).Where("expires_at IS NULL").
+			Update("expires_at", key.ActivatesAt.Add(retention)).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table signing_keys)")
+		}
+
+		if err := tx.Create(key).Error; err != nil {
+			return errors.Wrap(err, "database error (table signing_keys)")
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+func (r *signingKeyRepository) DeleteExpiredSigningKeys(now time.Time) error {
+	err := r.db.Where("expires_at <= ?", now).Delete(&models.SigningKey{}).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table signing_keys)")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"avito-shop-test/internal/models"
@@ -8,6 +9,7 @@ import (
 
 type StoreRepository interface {
 	GetItemByName(name string) (*models.Product, error)
+	GetItems() ([]models.Product, error)
 }
 
 type storeRepository struct {
@@ -20,9 +22,18 @@ func NewStoreRepository(db *gorm.DB) StoreRepository {
 
 func (r *storeRepository) GetItemByName(name string) (*models.Product, error) {
 	var item models.Product
-	err := r.db.Where("name = ?", name).First(&item).Error
+	err := r.db.Preload("Rule").Where("name = ?", name).First(&item).Error
 	if err != nil {
 		return nil, err
 	}
 	return &item, nil
 }
+
+func (r *storeRepository) GetItems() ([]models.Product, error) {
+	var items []models.Product
+	err := r.db.Order("name").Find(&items).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table items)")
+	}
+	return items, nil
+}
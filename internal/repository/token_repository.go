@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	UseRefreshToken(tokenHash string, now time.Time) (*models.RefreshToken, bool, error)
+	RevokeFamily(familyID string, now time.Time) error
+	RevokeUserTokens(userID string, now time.Time) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+	DeleteExpired(now time.Time) error
+	CreateSession(session *models.Session) error
+	ExtendSession(id, ip string, now, expiresAt time.Time) error
+	TouchSession(id, ip string, now time.Time) (bool, error)
+	GetSessions(userID string, now time.Time) ([]models.Session, error)
+	RevokeSession(userID, id string, now time.Time) (bool, error)
+	RevokeOtherSessions(userID, keepID string, now time.Time) error
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		return errors.Wrap(err, "database error (table refresh_tokens)")
+	}
+	return nil
+}
+
+func (r *tokenRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).Take(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table refresh_tokens)")
+	}
+	return &token, nil
+}
+
+func (r *tokenRepository) UseRefreshToken(tokenHash string, now time.Time) (*models.RefreshToken, bool, error) {
+	var token *models.RefreshToken
+	fresh := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).Take(&current).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		token = &current
+
+		if current.UsedAt != nil || current.RevokedAt != nil {
+			return nil
+		}
+		if err := tx.Model(&current).Update("used_at", now).Error; err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		fresh = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return token, fresh, nil
+}
+
+func (r *tokenRepository) RevokeFamily(familyID string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		err = tx.Model(&models.Session{}).
+			Where("id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table sessions)")
+		}
+		return nil
+	})
+}
+
+func (r *tokenRepository) RevokeUserTokens(userID string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		err = tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table sessions)")
+		}
+		return nil
+	})
+}
+
+func (r *tokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	revoked := &models.RevokedToken{JTI: jti, ExpiresAt: expiresAt}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(revoked).Error; err != nil {
+		return errors.Wrap(err, "database error (table revoked_tokens)")
+	}
+	return nil
+}
+
+func (r *tokenRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, errors.Wrap(err, "database error (table revoked_tokens)")
+	}
+	return count > 0, nil
+}
+
+func (r *tokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", now).Delete(&models.RevokedToken{}).Error; err != nil {
+			return errors.Wrap(err, "database error (table revoked_tokens)")
+		}
+		if err := tx.Where("expires_at < ?", now).Delete(&models.RefreshToken{}).Error; err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		if err := tx.Where("expires_at < ?", now).Delete(&models.Session{}).Error; err != nil {
+			return errors.Wrap(err, "database error (table sessions)")
+		}
+		return nil
+	})
+}
+
+func (r *tokenRepository) CreateSession(session *models.Session) error {
+	if err := r.db.Create(session).Error; err != nil {
+		return errors.Wrap(err, "database error (table sessions)")
+	}
+	return nil
+}
+
+func (r *tokenRepository) ExtendSession(id, ip string, now, expiresAt time.Time) error {
+	err := r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"ip": ip, "last_seen_at": now, "expires_at": expiresAt}).Error
+	if err != nil {
+		return errors.Wrap(err, "database error (table sessions)")
+	}
+	return nil
+}
+
+func (r *tokenRepository) TouchSession(id, ip string, now time.Time) (bool, error) {
+	var session models.Session
+	err := r.db.Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, now).Take(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "database error (table sessions)")
+	}
+
+	if session.IP == ip && now.Sub(session.LastSeenAt) < time.Minute {
+		return true, nil
+	}
+	err = r.db.Model(&session).Updates(map[string]interface{}{"ip": ip, "last_seen_at": now}).Error
+	if err != nil {
+		return false, errors.Wrap(err, "database error (table sessions)")
+	}
+	return true, nil
+}
+
+func (r *tokenRepository) GetSessions(userID string, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").Find(&sessions).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table sessions)")
+	}
+	return sessions, nil
+}
+
+func (r *tokenRepository) RevokeSession(userID, id string, now time.Time) (bool, error) {
+	revoked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "database error (table sessions)")
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		revoked = true
+
+		err := tx.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
+
+func (r *tokenRepository) RevokeOtherSessions(userID, keepID string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, keepID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table refresh_tokens)")
+		}
+		err = tx.Model(&models.Session{}).
+			Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table sessions)")
+		}
+		return nil
+	})
+}
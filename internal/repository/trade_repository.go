@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type TradeRepository interface {
+	CreateTrade(trade *models.Trade) error
+	GetUserTrades(userID string) ([]models.Trade, error)
+	AcceptTrade(id, counterpartyID string) error
+	RejectTrade(id, counterpartyID string) error
+	CancelTrade(id, proposerID string) error
+}
+
+type tradeRepository struct {
+	db *gorm.DB
+}
+
+func NewTradeRepository(db *gorm.DB) TradeRepository {
+	return &tradeRepository{db: db}
+}
+
+func (r *tradeRepository) CreateTrade(trade *models.Trade) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range trade.Items {
+			item := &trade.Items[i]
+
+			var inventory models.Inventory
+			err := tx.Where("id = ? AND user_id = ?", item.InventoryID, item.OwnerID).Take(&inventory).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("товар для обмена не найден в инвентаре")
+				}
+				return errors.Wrap(err, "database error (table inventory)")
+			}
+
+			item.ItemType = inventory.ItemType
+			item.SKU = inventory.SKU
+			item.Variant = inventory.Variant
+			item.Quantity = inventory.Quantity
+		}
+
+		trade.Status = models.TradeStatusPending
+		if err := tx.Create(trade).Error; err != nil {
+			return errors.Wrap(err, "database error (table trades)")
+		}
+		return nil
+	})
+}
+
+func (r *tradeRepository) GetUserTrades(userID string) ([]models.Trade, error) {
+	var trades []models.Trade
+	err := r.db.Preload("Items").
+		Where("proposer_id = ? OR counterparty_id = ?", userID, userID).
+		Order("created_at DESC").Find(&trades).Error
+	return trades, err
+}
+
+func (r *tradeRepository) AcceptTrade(id, counterpartyID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		trade, err := lockPendingTrade(tx, "id = ? AND counterparty_id = ?", id, counterpartyID)
+		if err != nil {
+			return err
+		}
+
+		if trade.ProposerCoins > 0 {
+			if err := debitBalance(tx, trade.ProposerID, trade.ProposerCoins, "у автора предложения недостаточно монет"); err != nil {
+				return err
+			}
+			if err := creditBalance(tx, trade.CounterpartyID, trade.ProposerCoins); err != nil {
+				return err
+			}
+		}
+		if trade.CounterpartyCoins > 0 {
+			if err := debitBalance(tx, trade.CounterpartyID, trade.CounterpartyCoins, "недостаточно монет для обмена"); err != nil {
+				return err
+			}
+			if err := creditBalance(tx, trade.ProposerID, trade.CounterpartyCoins); err != nil {
+				return err
+			}
+		}
+
+		var inventoryIDs []string
+		for _, item := range trade.Items {
+			newOwner := trade.CounterpartyID
+			if item.OwnerID == trade.CounterpartyID {
+				newOwner = trade.ProposerID
+			}
+
+			moved := tx.Model(&models.Inventory{}).
+				Where("id = ? AND user_id = ? AND quantity = ?", item.InventoryID, item.OwnerID, item.Quantity).
+				Updates(transferredInventory(newOwner))
+			if moved.Error != nil {
+				return moved.Error
+			}
+			if moved.RowsAffected == 0 {
+				return errors.New("товар из обмена больше недоступен")
+			}
+			inventoryIDs = append(inventoryIDs, item.InventoryID)
+		}
+
+		if len(inventoryIDs) > 0 {
+			err = tx.Model(&models.Listing{}).
+				Where("inventory_id IN ? AND status = ?", inventoryIDs, models.ListingStatusActive).
+				Update("status", models.ListingStatusCancelled).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return resolveTrade(tx, trade.ID, models.TradeStatusAccepted)
+	})
+}
+
+func (r *tradeRepository) RejectTrade(id, counterpartyID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPendingTrade(tx, "id = ? AND counterparty_id = ?", id, counterpartyID); err != nil {
+			return err
+		}
+		return resolveTrade(tx, id, models.TradeStatusRejected)
+	})
+}
+
+func (r *tradeRepository) CancelTrade(id, proposerID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPendingTrade(tx, "id = ? AND proposer_id = ?", id, proposerID); err != nil {
+			return err
+		}
+		return resolveTrade(tx, id, models.TradeStatusCancelled)
+	})
+}
+
+func lockPendingTrade(tx *gorm.DB, query string, args ...interface{}) (*models.Trade, error) {
+	var trade models.Trade
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).Take(&trade).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("предложение обмена не найдено")
+		}
+		return nil, err
+	}
+	if trade.Status != models.TradeStatusPending {
+		return nil, errors.New("предложение обмена уже закрыто")
+	}
+
+	if err := tx.Where("trade_id = ?", trade.ID).Find(&trade.Items).Error; err != nil {
+		return nil, err
+	}
+	return &trade, nil
+}
+
+func resolveTrade(tx *gorm.DB, id, status string) error {
+	return tx.Model(&models.Trade{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":      status,
+		"resolved_at": time.Now(),
+	}).Error
+}
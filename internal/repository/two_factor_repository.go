@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type TwoFactorRepository interface {
+	SaveTOTPSecret(userID, secret string) error
+	EnableTOTP(userID string, step int64, codeHashes []string) error
+	DisableTOTP(userID string) error
+	UseTOTPStep(userID string, step int64) (bool, error)
+	UseRecoveryCode(userID, codeHash string, at time.Time) (bool, error)
+	ReplaceRecoveryCodes(userID string, codeHashes []string) error
+	CountRecoveryCodes(userID string) (int, error)
+	CreateChallenge(challenge *models.MFAChallenge) error
+	UseChallenge(id string, now time.Time, maxAttempts int) (*models.MFAChallenge, error)
+	DeleteChallenge(id string) error
+	DeleteExpiredChallenges(now time.Time) error
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) SaveTOTPSecret(userID, secret string) error {
+	updated := r.db.Model(&models.User{}).
+		Where("id = ? AND NOT totp_enabled", userID).
+		Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": nil})
+	if updated.Error != nil {
+		return errors.Wrap(updated.Error, "database error (table users)")
+	}
+	if updated.RowsAffected == 0 {
+		return errors.New("двухфакторная аутентификация уже включена")
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) EnableTOTP(userID string, step int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(&models.User{}).
+			Where("id = ? AND NOT totp_enabled AND totp_secret <> ''", userID).
+			Updates(map[string]interface{}{"totp_enabled": true, "totp_last_step": step})
+		if updated.Error != nil {
+			return errors.Wrap(updated.Error, "database error (table users)")
+		}
+		if updated.RowsAffected == 0 {
+			return errors.New("двухфакторная аутентификация уже включена")
+		}
+
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *twoFactorRepository) DisableTOTP(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_enabled": false, "totp_secret": "", "totp_last_step": nil}).Error
+		if err != nil {
+			return errors.Wrap(err, "database error (table users)")
+		}
+
+		return replaceRecoveryCodes(tx, userID, nil)
+	})
+}
+
+func (r *twoFactorRepository) UseTOTPStep(userID string, step int64) (bool, error) {
+	updated := r.db.Model(&models.User{}).
+		Where("id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)", userID, step).
+		Update("totp_last_step", step)
+	if updated.Error != nil {
+		return false, errors.Wrap(updated.Error, "database error (table users)")
+	}
+	return updated.RowsAffected == 1, nil
+}
+
+func (r *twoFactorRepository) UseRecoveryCode(userID, codeHash string, at time.Time) (bool, error) {
+	updated := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	if updated.Error != nil {
+		return false, errors.Wrap(updated.Error, "database error (table recovery_codes)")
+	}
+	return updated.RowsAffected == 1, nil
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID string, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return errors.Wrap(err, "database error (table recovery_codes)")
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]models.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if err := tx.Create(&codes).Error; err != nil {
+		return errors.Wrap(err, "database error (table recovery_codes)")
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) CountRecoveryCodes(userID string) (int, error) {
+	var count int64
+	err := r.db.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "database error (table recovery_codes)")
+	}
+	return int(count), nil
+}
+
+func (r *twoFactorRepository) CreateChallenge(challenge *models.MFAChallenge) error {
+	if err := r.db.Create(challenge).Error; err != nil {
+		return errors.Wrap(err, "database error (table mfa_challenges)")
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) UseChallenge(id string, now time.Time, maxAttempts int) (*models.MFAChallenge, error) {
+	var challenges []models.MFAChallenge
+	err := r.db.Model(&challenges).Clauses(clause.Returning{}).
+		Where("id = ? AND expires_at > ? AND attempts < ?", id, now, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table mfa_challenges)")
+	}
+	if len(challenges) == 0 {
+		return nil, nil
+	}
+	return &challenges[0], nil
+}
+
+func (r *twoFactorRepository) DeleteChallenge(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.MFAChallenge{}).Error; err != nil {
+		return errors.Wrap(err, "database error (table mfa_challenges)")
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) DeleteExpiredChallenges(now time.Time) error {
+	if err := r.db.Where("expires_at <= ?", now).Delete(&models.MFAChallenge{}).Error; err != nil {
+		return errors.Wrap(err, "database error (table mfa_challenges)")
+	}
+	return nil
+}
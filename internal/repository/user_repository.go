@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/pkg/errors"
@@ -13,6 +15,10 @@ type UserRepository interface {
 	CreateUser(user *models.User) error
 	UpdateUserBalance(username string, amount int) error
 	GetUserByUserID(userID string) (*models.User, error)
+	GetUserIDs(usernames []string) ([]string, error)
+	UpdateUserAttributes(username, department string, hiredAt *time.Time) error
+	UpdatePrivacy(username string, settings models.PrivacySettings) error
+	UpdatePassword(username, hash string) error
 }
 
 type userRepository struct {
@@ -47,9 +53,24 @@ func (userDb *userRepository) GetUserByUserID(userID string) (*models.User, erro
 	return &user, nil
 }
 
+func (userDb *userRepository) GetUserIDs(usernames []string) ([]string, error) {
+	var ids []string
+	if len(usernames) == 0 {
+		return ids, nil
+	}
+	err := userDb.db.Table("users").Where("username IN ?", usernames).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table users)")
+	}
+	return ids, nil
+}
+
 func (userDb *userRepository) CreateUser(user *models.User) error {
 	tx := userDb.db.Create(user)
 	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrDuplicatedKey) {
+			return errors.New("пользователь уже существует")
+		}
 		return errors.Wrap(tx.Error, "database error (table users)")
 	}
 	return nil
@@ -58,3 +79,39 @@ func (userDb *userRepository) CreateUser(user *models.User) error {
 func (r *userRepository) UpdateUserBalance(username string, amount int) error {
 	return r.db.Model(&models.User{}).Where("username = ?", username).Update("Balance", gorm.Expr("Balance + ?", amount)).Error
 }
+
+func (r *userRepository) UpdateUserAttributes(username, department string, hiredAt *time.Time) error {
+	tx := r.db.Model(&models.User{}).Where("username = ?", username).Updates(map[string]interface{}{
+		"department": department,
+		"hired_at":   hiredAt,
+	})
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("пользователь не найден")
+	}
+	return nil
+}
+
+func (r *userRepository) UpdatePrivacy(username string, settings models.PrivacySettings) error {
+	tx := r.db.Model(&models.User{}).Where("username = ?", username).Updates(map[string]interface{}{
+		"public_kudos":           settings.PublicKudos,
+		"hide_from_leaderboards": settings.HideFromLeaderboards,
+	})
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("пользователь не найден")
+	}
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(username, hash string) error {
+	tx := r.db.Model(&models.User{}).Where("username = ?", username).Update("password", hash)
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"avito-shop-test/internal/models"
+)
+
+type VariantRepository interface {
+	GetItemOptions(itemName string) ([]models.ItemOption, error)
+	AddItemOptions(options []models.ItemOption) error
+	GetSKUs(itemName string) ([]models.SKU, error)
+	GetSKUByCode(code string) (*models.SKU, error)
+	CreateSKU(sku *models.SKU) error
+	AdjustStock(code string, delta int) error
+}
+
+type variantRepository struct {
+	db *gorm.DB
+}
+
+func NewVariantRepository(db *gorm.DB) VariantRepository {
+	return &variantRepository{db: db}
+}
+
+func (r *variantRepository) GetItemOptions(itemName string) ([]models.ItemOption, error) {
+	var options []models.ItemOption
+	err := r.db.Where("item_name = ?", itemName).Order("name, value").Find(&options).Error
+	return options, err
+}
+
+func (r *variantRepository) AddItemOptions(options []models.ItemOption) error {
+	if len(options) == 0 {
+		return nil
+	}
+	return r.db.Create(&options).Error
+}
+
+func (r *variantRepository) GetSKUs(itemName string) ([]models.SKU, error) {
+	var skus []models.SKU
+	err := r.db.Preload("Options").Where("item_name = ?", itemName).Order("code").Find(&skus).Error
+	return skus, err
+}
+
+func (r *variantRepository) GetSKUByCode(code string) (*models.SKU, error) {
+	var sku models.SKU
+	err := r.db.Preload("Options").Where("code = ?", code).Take(&sku).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table item_skus)")
+	}
+	return &sku, nil
+}
+
+func (r *variantRepository) CreateSKU(sku *models.SKU) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(sku).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("артикул уже существует")
+			}
+			return errors.Wrap(err, "database error (table item_skus)")
+		}
+
+		return tx.Model(&models.Product{}).Where("name = ?", sku.ItemName).Update("has_variants", true).Error
+	})
+}
+
+func (r *variantRepository) AdjustStock(code string, delta int) error {
+	tx := r.db.Model(&models.SKU{}).
+		Where("code = ? AND stock + ? >= 0", code, delta).
+		Update("stock", gorm.Expr("stock + ?", delta))
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("товара нет в наличии")
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avito-shop-test/internal/models"
+)
+
+type WishlistRepository interface {
+	GetWishlist(userID string) (*models.Wishlist, error)
+	GetWishlistByToken(token string) (*models.Wishlist, error)
+	CreateWishlist(wishlist *models.Wishlist) error
+	AddItem(item *models.WishlistItem) error
+	GetItems(userID string) ([]models.WishlistItem, error)
+	GetItem(id string) (*models.WishlistItem, error)
+	RemoveItem(id, userID string) error
+	StartCampaign(id, userID string, target int, expiresAt time.Time) error
+	Contribute(id, contributorID string, amount int) (*models.WishlistItem, error)
+	ExpireCampaigns(now time.Time) (int, error)
+}
+
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWishlistRepository(db *gorm.DB) WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+func (r *wishlistRepository) GetWishlist(userID string) (*models.Wishlist, error) {
+	return r.findWishlist("user_id = ?", userID)
+}
+
+func (r *wishlistRepository) GetWishlistByToken(token string) (*models.Wishlist, error) {
+	return r.findWishlist("share_token = ?", token)
+}
+
+func (r *wishlistRepository) findWishlist(query string, arg string) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	err := r.db.Where(query, arg).Take(&wishlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table wishlists)")
+	}
+	return &wishlist, nil
+}
+
+func (r *wishlistRepository) CreateWishlist(wishlist *models.Wishlist) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(wishlist).Error
+}
+
+func (r *wishlistRepository) AddItem(item *models.WishlistItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *wishlistRepository) GetItems(userID string) ([]models.WishlistItem, error) {
+	var items []models.WishlistItem
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&items).Error
+	return items, err
+}
+
+func (r *wishlistRepository) GetItem(id string) (*models.WishlistItem, error) {
+	var item models.WishlistItem
+	err := r.db.Where("id = ?", id).Take(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "database error (table wishlist_items)")
+	}
+	return &item, nil
+}
+
+func (r *wishlistRepository) RemoveItem(id, userID string) error {
+	removed := r.db.Where("id = ? AND user_id = ? AND status <> ?", id, userID, models.WishlistStatusFunding).
+		Delete(&models.WishlistItem{})
+	if removed.Error != nil {
+		return removed.Error
+	}
+	if removed.RowsAffected == 0 {
+		return errors.New("нельзя удалить товар со сбором в процессе")
+	}
+	return nil
+}
+
+func (r *wishlistRepository) StartCampaign(id, userID string, target int, expiresAt time.Time) error {
+	started := r.db.Model(&models.WishlistItem{}).
+		Where("id = ? AND user_id = ? AND status IN ?", id, userID, []string{models.WishlistStatusWished, models.WishlistStatusExpired}).
+		Updates(map[string]interface{}{
+			"status":     models.WishlistStatusFunding,
+			"target":     target,
+			"funded":     0,
+			"expires_at": expiresAt,
+		})
+	if started.Error != nil {
+		return started.Error
+	}
+	if started.RowsAffected == 0 {
+		return errors.New("сбор уже идёт или завершён")
+	}
+	return nil
+}
+
+func (r *wishlistRepository) Contribute(id, contributorID string, amount int) (*models.WishlistItem, error) {
+	var item models.WishlistItem
+	var failed error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&item).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("желание не найдено")
+			}
+			return err
+		}
+		if item.Status != models.WishlistStatusFunding || (item.ExpiresAt != nil && !time.Now().Before(*item.ExpiresAt)) {
+			return errors.New("сбор на этот товар не идёт")
+		}
+
+		if remaining := item.Target - item.Funded; amount > remaining {
+			amount = remaining
+		}
+		if amount <= 0 {
+			return errors.New("сбор на этот товар не идёт")
+		}
+
+		if err := debitBalance(tx, contributorID, amount, "недостаточно монет для взноса"); err != nil {
+			return err
+		}
+
+		contribution := &models.Contribution{
+			WishlistItemID: item.ID,
+			ContributorID:  contributorID,
+			Amount:         amount,
+			Status:         models.ContributionStatusHeld,
+		}
+		if err := tx.Create(contribution).Error; err != nil {
+			return err
+		}
+
+		item.Funded += amount
+		if item.Funded < item.Target {
+			return tx.Model(&models.WishlistItem{}).Where("id = ?", item.ID).Update("funded", item.Funded).Error
+		}
+
+		failed = tx.Transaction(func(tx *gorm.DB) error {
+			return completeCampaign(tx, &item)
+		})
+		if failed == nil {
+			return nil
+		}
+		return closeCampaign(tx, &item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failed != nil {
+		return nil, errors.Errorf("сбор закрыт, взносы возвращены: %s", failed.Error())
+	}
+	return &item, nil
+}
+
+func completeCampaign(tx *gorm.DB, item *models.WishlistItem) error {
+	if err := takeStock(tx, item.SKU); err != nil {
+		return err
+	}
+
+	inventory := &models.Inventory{
+		UserID:   item.UserID,
+		ItemType: item.ItemName,
+		Quantity: 1,
+		SKU:      item.SKU,
+		Price:    &item.Target,
+		NoReturn: true,
+	}
+	if item.SKU != "" {
+		var sku models.SKU
+		if err := tx.Preload("Options").Where("code = ?", item.SKU).Take(&sku).Error; err != nil {
+			return err
+		}
+		inventory.Variant = sku.Description()
+	}
+	if err := tx.Create(inventory).Error; err != nil {
+		return errors.Wrap(err, "database error (table inventory)")
+	}
+
+	purchase := &models.Purchase{
+		UserID:      item.UserID,
+		InventoryID: &inventory.ID,
+		ItemType:    item.ItemName,
+		SKU:         item.SKU,
+		Price:       item.Target,
+	}
+	if err := recordPurchase(tx, purchase); err != nil {
+		return err
+	}
+
+	err := tx.Model(&models.Contribution{}).
+		Where("wishlist_item_id = ? AND status = ?", item.ID, models.ContributionStatusHeld).
+		Update("status", models.ContributionStatusCaptured).Error
+	if err != nil {
+		return err
+	}
+
+	item.Status = models.WishlistStatusFunded
+	return tx.Model(&models.WishlistItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"funded": item.Funded,
+		"status": item.Status,
+	}).Error
+}
+
+func (r *wishlistRepository) ExpireCampaigns(now time.Time) (int, error) {
+	var ids []string
+	err := r.db.Model(&models.WishlistItem{}).
+		Where("status = ? AND expires_at <= ?", models.WishlistStatusFunding, now).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		refunded := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var item models.WishlistItem
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND status = ?", id, models.WishlistStatusFunding).Take(&item).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+
+			refunded = true
+			return closeCampaign(tx, &item)
+		})
+		if err != nil {
+			return expired, err
+		}
+		if refunded {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func closeCampaign(tx *gorm.DB, item *models.WishlistItem) error {
+	var contributions []models.Contribution
+	err := tx.Where("wishlist_item_id = ? AND status = ?", item.ID, models.ContributionStatusHeld).Find(&contributions).Error
+	if err != nil {
+		return err
+	}
+	for _, contribution := range contributions {
+		if err := creditBalance(tx, contribution.ContributorID, contribution.Amount); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Model(&models.Contribution{}).
+		Where("wishlist_item_id = ? AND status = ?", item.ID, models.ContributionStatusHeld).
+		Update("status", models.ContributionStatusRefunded).Error
+	if err != nil {
+		return err
+	}
+
+	item.Status = models.WishlistStatusExpired
+	item.Funded = 0
+	return tx.Model(&models.WishlistItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"status": item.Status,
+		"funded": item.Funded,
+	}).Error
+}
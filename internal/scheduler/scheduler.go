@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+func Every(ctx context.Context, name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(); err != nil {
+					log.Printf("Ошибка фоновой задачи %s: %v", name, err)
+				}
+			}
+		}
+	}()
+}
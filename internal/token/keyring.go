@@ -0,0 +1,259 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	DefaultRotation = 30 * 24 * time.Hour
+	rsaKeyBits      = 2048
+	propagation     = 5 * time.Minute
+	clockLeeway     = time.Minute
+)
+
+type KeyStore interface {
+	GetSigningKeys(now time.Time) ([]models.SigningKey, error)
+	RotateSigningKey(key *models.SigningKey, rotateBefore time.Time, retention time.Duration) (bool, error)
+	DeleteExpiredSigningKeys(now time.Time) error
+}
+
+type signingKey struct {
+	kid         string
+	method      jwt.SigningMethod
+	private     crypto.Signer
+	createdAt   time.Time
+	activatesAt time.Time
+}
+
+type Keyring struct {
+	store     KeyStore
+	algorithm string
+	rotation  time.Duration
+	retention time.Duration
+
+	mu   sync.RWMutex
+	keys []signingKey
+}
+
+func NewKeyring(store KeyStore, algorithm string, rotation, tokenTTL time.Duration) (*Keyring, error) {
+	if _, err := signingMethod(algorithm); err != nil {
+		return nil, err
+	}
+	if rotation <= 0 {
+		rotation = DefaultRotation
+	}
+	return &Keyring{
+		store:     store,
+		algorithm: algorithm,
+		rotation:  rotation,
+		retention: tokenTTL + clockLeeway,
+	}, nil
+}
+
+func (k *Keyring) Refresh() error {
+	now := time.Now()
+	if k.rotationDue(now) {
+		key, err := generateKey(k.algorithm, now)
+		if err != nil {
+			return err
+		}
+		if _, err := k.store.RotateSigningKey(key, now.Add(-k.rotation), k.retention); err != nil {
+			return err
+		}
+	}
+
+	if err := k.store.DeleteExpiredSigningKeys(now); err != nil {
+		return err
+	}
+	return k.reload(now)
+}
+
+func (k *Keyring) rotationDue(now time.Time) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if len(k.keys) == 0 {
+		return true
+	}
+	latest := k.keys[len(k.keys)-1]
+	return latest.method.Alg() != k.algorithm || !latest.createdAt.After(now.Add(-k.rotation))
+}
+
+func (k *Keyring) reload(now time.Time) error {
+	stored, err := k.store.GetSigningKeys(now)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]signingKey, 0, len(stored))
+	for _, row := range stored {
+		key, err := parseKey(row)
+		if err != nil {
+			return fmt.Errorf("ключ %s: %w", row.KID, err)
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].activatesAt.Before(keys[j].activatesAt)
+	})
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+	return nil
+}
+
+func (k *Keyring) signer(now time.Time) (*signingKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		if !k.keys[i].activatesAt.After(now) {
+			key := k.keys[i]
+			return &key, nil
+		}
+	}
+	return nil, errors.New("нет активного ключа подписи")
+}
+
+func (k *Keyring) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.kid != kid {
+			continue
+		}
+		if key.method.Alg() != token.Method.Alg() {
+			return nil, errors.New("алгоритм не совпадает с ключом")
+		}
+		return key.private.Public(), nil
+	}
+	return nil, errors.New("неизвестный ключ")
+}
+
+func (k *Keyring) JWKS() models.JWKS {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := models.JWKS{Keys: make([]models.JWK, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwk := models.JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgEdDSA:
+		return SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("неподдерживаемый алгоритм подписи %q", algorithm)
+}
+
+func generateKey(algorithm string, now time.Time) (*models.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case AlgRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("неподдерживаемый алгоритм подписи %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SigningKey{
+		KID:         kid,
+		Algorithm:   algorithm,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:   now,
+		ActivatesAt: now.Add(propagation),
+	}, nil
+}
+
+func parseKey(row models.SigningKey) (signingKey, error) {
+	method, err := signingMethod(row.Algorithm)
+	if err != nil {
+		return signingKey{}, err
+	}
+
+	block, _ := pem.Decode([]byte(row.PrivateKey))
+	if block == nil {
+		return signingKey{}, errors.New("некорректный PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return signingKey{}, err
+	}
+
+	var private crypto.Signer
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if row.Algorithm != AlgRS256 {
+			return signingKey{}, errors.New("тип ключа не совпадает с алгоритмом")
+		}
+		private = key
+	case ed25519.PrivateKey:
+		if row.Algorithm != AlgEdDSA {
+			return signingKey{}, errors.New("тип ключа не совпадает с алгоритмом")
+		}
+		private = key
+	default:
+		return signingKey{}, errors.New("неподдерживаемый тип ключа")
+	}
+
+	return signingKey{
+		kid:         row.KID,
+		method:      method,
+		private:     private,
+		createdAt:   row.CreatedAt,
+		activatesAt: row.ActivatesAt,
+	}, nil
+}
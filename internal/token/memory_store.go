@@ -0,0 +1,70 @@
+package token
+
+import (
+	"sync"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	keys []models.SigningKey
+}
+
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{}
+}
+
+func (s *MemoryKeyStore) GetSigningKeys(now time.Time) ([]models.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []models.SigningKey
+	for _, key := range s.keys {
+		if key.ExpiresAt == nil || key.ExpiresAt.After(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *MemoryKeyStore) RotateSigningKey(key *models.SigningKey, rotateBefore time.Time, retention time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latest *models.SigningKey
+	for i := range s.keys {
+		if s.keys[i].ExpiresAt == nil && (latest == nil || s.keys[i].ActivatesAt.After(latest.ActivatesAt)) {
+			latest = &s.keys[i]
+		}
+	}
+	if latest == nil {
+		key.ActivatesAt = key.CreatedAt
+	} else if latest.Algorithm == key.Algorithm && latest.CreatedAt.After(rotateBefore) {
+		return false, nil
+	}
+
+	expiresAt := key.ActivatesAt.Add(retention)
+	for i := range s.keys {
+		if s.keys[i].ExpiresAt == nil {
+			s.keys[i].ExpiresAt = &expiresAt
+		}
+	}
+	s.keys = append(s.keys, *key)
+	return true, nil
+}
+
+func (s *MemoryKeyStore) DeleteExpiredSigningKeys(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.keys[:0]
+	for _, key := range s.keys {
+		if key.ExpiresAt == nil || key.ExpiresAt.After(now) {
+			kept = append(kept, key)
+		}
+	}
+	s.keys = kept
+	return nil
+}
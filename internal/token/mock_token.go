@@ -6,7 +6,7 @@ type MockTokenGenerator struct {
 	mock.Mock
 }
 
-func (m *MockTokenGenerator) Generate(username string) (string, error) {
-	args := m.Called(username)
+func (m *MockTokenGenerator) Generate(username, role, sessionID string) (string, error) {
+	args := m.Called(username, role, sessionID)
 	return args.String(0), args.Error(1)
 }
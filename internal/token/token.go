@@ -1,26 +1,103 @@
 package token
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
+const (
+	DefaultTTL      = 15 * time.Minute
+	DefaultIssuer   = "avito-shop"
+	DefaultAudience = "avito-shop-api"
+)
+
+var ErrInvalidToken = errors.New("некорректный токен")
+
 type TokenGenerator interface {
-	Generate(username string) (string, error)
+	Generate(username, role, sessionID string) (string, error)
+}
+
+type Claims struct {
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	jwt.StandardClaims
 }
 
 type Generator struct {
-	Secret []byte
+	Keys     *Keyring
+	Issuer   string
+	Audience string
+	TTL      time.Duration
 }
 
-func NewGenerator(secret []byte) *Generator {
-	return &Generator{Secret: secret}
+func NewGenerator(keys *Keyring) *Generator {
+	return &Generator{Keys: keys, Issuer: DefaultIssuer, Audience: DefaultAudience, TTL: DefaultTTL}
 }
-func (g *Generator) Generate(username string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"username": username,
-		"exp":      time.Now().Add(time.Hour * 72).Unix(),
+
+func (g *Generator) Generate(username, role, sessionID string) (string, error) {
+	now := time.Now()
+	key, err := g.Keys.signer(now)
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := randomID()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, Claims{
+		Username:  username,
+		Role:      role,
+		SessionID: sessionID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Issuer:    g.Issuer,
+			Audience:  g.Audience,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(g.TTL).Unix(),
+		},
 	})
-	return token.SignedString(g.Secret)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+type Verifier struct {
+	Keys     *Keyring
+	Issuer   string
+	Audience string
+}
+
+func NewVerifier(keys *Keyring) *Verifier {
+	return &Verifier{Keys: keys, Issuer: DefaultIssuer, Audience: DefaultAudience}
+}
+
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	parser := &jwt.Parser{ValidMethods: []string{AlgRS256, AlgEdDSA}}
+
+	claims := &Claims{}
+	token, err := parser.ParseWithClaims(tokenString, claims, v.Keys.keyfunc)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Id == "" || claims.ExpiresAt == 0 ||
+		!claims.VerifyIssuer(v.Issuer, true) || !claims.VerifyAudience(v.Audience, true) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func randomID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
 }
@@ -1,15 +1,176 @@
 package token
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestKeyring(t *testing.T, algorithm string) *Keyring {
+	keys, err := NewKeyring(NewMemoryKeyStore(), algorithm, time.Hour, DefaultTTL)
+	require.NoError(t, err)
+	require.NoError(t, keys.Refresh())
+	return keys
+}
+
 func TestGenerateToken(t *testing.T) {
-	generator := NewGenerator([]byte("secret"))
-	token, err := generator.Generate("testuser")
+	generator := NewGenerator(newTestKeyring(t, AlgEdDSA))
+	token, err := generator.Generate("testuser", "employee", "")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
+
+func TestGenerateToken_ShortLivedWithJTI(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	generator := NewGenerator(keys)
+	verifier := NewVerifier(keys)
+
+	first, err := generator.Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+	second, err := generator.Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+
+	claims, err := verifier.Verify(first)
+	assert.NoError(t, err)
+	other, err := verifier.Verify(second)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, claims.Id)
+	assert.NotEqual(t, claims.Id, other.Id)
+	lifetime := time.Duration(claims.ExpiresAt-claims.IssuedAt) * time.Second
+	assert.Equal(t, DefaultTTL, lifetime)
+}
+
+func TestGenerateToken_CarriesRole(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	token, err := NewGenerator(keys).Generate("testuser", "admin", "")
+	assert.NoError(t, err)
+
+	claims, err := NewVerifier(keys).Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, DefaultIssuer, claims.Issuer)
+	assert.Equal(t, DefaultAudience, claims.Audience)
+}
+
+func TestGenerateToken_CarriesSessionID(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	token, err := NewGenerator(keys).Generate("testuser", "employee", "session-1")
+	assert.NoError(t, err)
+
+	claims, err := NewVerifier(keys).Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "session-1", claims.SessionID)
+}
+
+func TestVerify_RS256(t *testing.T) {
+	keys := newTestKeyring(t, AlgRS256)
+	token, err := NewGenerator(keys).Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+
+	claims, err := NewVerifier(keys).Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", claims.Username)
+}
+
+func TestVerify_RejectsHS256SignedWithPublicKey(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	jwks := keys.JWKS()
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Username: "testuser",
+		StandardClaims: jwt.StandardClaims{
+			Id:        "forged",
+			Issuer:    DefaultIssuer,
+			Audience:  DefaultAudience,
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	forged.Header["kid"] = jwks.Keys[0].Kid
+	signed, err := forged.SignedString([]byte(jwks.Keys[0].X))
+	assert.NoError(t, err)
+
+	_, err = NewVerifier(keys).Verify(signed)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerify_RejectsUnsignedToken(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	token, err := NewGenerator(keys).Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	header := jwt.EncodeSegment([]byte(`{"alg":"none","typ":"JWT"}`))
+	_, err = NewVerifier(keys).Verify(header + "." + parts[1] + ".")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerify_RejectsWrongIssuerAndAudience(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+
+	foreign := &Generator{Keys: keys, Issuer: "other-service", Audience: DefaultAudience, TTL: DefaultTTL}
+	token, err := foreign.Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+	_, err = NewVerifier(keys).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	foreign = &Generator{Keys: keys, Issuer: DefaultIssuer, Audience: "other-api", TTL: DefaultTTL}
+	token, err = foreign.Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+	_, err = NewVerifier(keys).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerify_RejectsExpiredToken(t *testing.T) {
+	keys := newTestKeyring(t, AlgEdDSA)
+	generator := &Generator{Keys: keys, Issuer: DefaultIssuer, Audience: DefaultAudience, TTL: -time.Minute}
+
+	token, err := generator.Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+
+	_, err = NewVerifier(keys).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerify_RejectsUnknownKey(t *testing.T) {
+	token, err := NewGenerator(newTestKeyring(t, AlgEdDSA)).Generate("testuser", "employee", "")
+	assert.NoError(t, err)
+
+	_, err = NewVerifier(newTestKeyring(t, AlgEdDSA)).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeyring_RotationPublishesBeforeSigning(t *testing.T) {
+	store := NewMemoryKeyStore()
+	keys, err := NewKeyring(store, AlgEdDSA, time.Hour, DefaultTTL)
+	require.NoError(t, err)
+	require.NoError(t, keys.Refresh())
+
+	oldToken, err := NewGenerator(keys).Generate("testuser", "employee", "")
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring(store, AlgRS256, time.Hour, DefaultTTL)
+	require.NoError(t, err)
+	require.NoError(t, rotated.Refresh())
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Len(t, jwks.Keys[0].X, 43)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+
+	_, err = NewVerifier(rotated).Verify(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := NewGenerator(rotated).Generate("testuser", "employee", "")
+	require.NoError(t, err)
+	header, err := jwt.DecodeSegment(strings.Split(newToken, ".")[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(header), `"alg":"EdDSA"`)
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type achievementUseCase struct {
+	achievementRepo AchievementRepository
+	userRepo        UserRepository
+	rules           []models.AchievementRule
+}
+
+func NewAchievementUseCase(achievementRepo AchievementRepository, userRepo UserRepository, rules []models.AchievementRule) AchievementUseCase {
+	return &achievementUseCase{
+		achievementRepo: achievementRepo,
+		userRepo:        userRepo,
+		rules:           rules,
+	}
+}
+
+func LoadAchievementRules(filename string) ([]models.AchievementRule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []models.AchievementRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать правила достижений: %w", err)
+	}
+
+	codes := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Code == "" || rule.Title == "" {
+			return nil, errors.New("у достижения должны быть код и название")
+		}
+		if codes[rule.Code] {
+			return nil, fmt.Errorf("достижение %s описано дважды", rule.Code)
+		}
+		codes[rule.Code] = true
+		if _, ok := metricValue(models.ActivityMetrics{}, rule.Metric); !ok {
+			return nil, fmt.Errorf("неизвестная метрика %s в достижении %s", rule.Metric, rule.Code)
+		}
+		if rule.Threshold <= 0 {
+			return nil, fmt.Errorf("порог достижения %s должен быть положительным", rule.Code)
+		}
+	}
+	return rules, nil
+}
+
+func metricValue(metrics models.ActivityMetrics, metric string) (int, bool) {
+	switch metric {
+	case models.MetricTransfersSent:
+		return metrics.TransfersSent, true
+	case models.MetricDistinctRecipients:
+		return metrics.DistinctRecipients, true
+	case models.MetricCoinsSent:
+		return metrics.CoinsSent, true
+	case models.MetricTransfersReceived:
+		return metrics.TransfersReceived, true
+	case models.MetricCoinsReceived:
+		return metrics.CoinsReceived, true
+	case models.MetricItemsBought:
+		return metrics.ItemsBought, true
+	case models.MetricDistinctItems:
+		return metrics.DistinctItems, true
+	case models.MetricCatalogPercent:
+		if metrics.CatalogSize == 0 {
+			return 0, true
+		}
+		return metrics.DistinctItems * 100 / metrics.CatalogSize, true
+	}
+	return 0, false
+}
+
+func (uc *achievementUseCase) GetBadges(username string) (*models.UserBadges, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	earned, err := uc.achievementRepo.GetBadges(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string]models.AchievementRule, len(uc.rules))
+	for _, rule := range uc.rules {
+		rules[rule.Code] = rule
+	}
+
+	result := &models.UserBadges{
+		Username: user.Username,
+		Badges:   make([]models.BadgeInfo, 0, len(earned)),
+	}
+	for _, badge := range earned {
+		rule, ok := rules[badge.Code]
+		if !ok {
+			continue
+		}
+		result.Badges = append(result.Badges, models.BadgeInfo{
+			Code:        rule.Code,
+			Title:       rule.Title,
+			Description: rule.Description,
+			AwardedAt:   badge.AwardedAt,
+		})
+	}
+	return result, nil
+}
+
+func (uc *achievementUseCase) Evaluate(userID string) error {
+	metrics, err := uc.achievementRepo.GetMetrics(userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var badges []models.UserBadge
+	for _, rule := range uc.rules {
+		if value, _ := metricValue(*metrics, rule.Metric); value >= rule.Threshold {
+			badges = append(badges, models.UserBadge{UserID: userID, Code: rule.Code, AwardedAt: now})
+		}
+	}
+	return uc.achievementRepo.AwardBadges(badges)
+}
+
+func (uc *achievementUseCase) OnActivity(userIDs ...string) {
+	for _, userID := range userIDs {
+		if err := uc.Evaluate(userID); err != nil {
+			log.Printf("Не удалось проверить достижения пользователя %s: %v", userID, err)
+		}
+	}
+}
+
+func (uc *achievementUseCase) Backfill() error {
+	userIDs, err := uc.achievementRepo.GetAllUserIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := uc.Evaluate(userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+var testAchievementRules = []models.AchievementRule{
+	{Code: "first-thanks", Title: "Первое спасибо", Metric: models.MetricTransfersSent, Threshold: 1},
+	{Code: "ten-colleagues", Title: "Душа компании", Metric: models.MetricDistinctRecipients, Threshold: 10},
+	{Code: "collector", Title: "Коллекционер", Metric: models.MetricCatalogPercent, Threshold: 100},
+}
+
+func awardedCodes(badges []models.UserBadge) []string {
+	codes := make([]string, 0, len(badges))
+	for _, badge := range badges {
+		codes = append(codes, badge.Code)
+	}
+	return codes
+}
+
+func TestEvaluate_AwardsReachedThresholds(t *testing.T) {
+	mockAchievementRepo := new(mockRepo.MockAchievementRepository)
+	uc := NewAchievementUseCase(mockAchievementRepo, nil, testAchievementRules)
+
+	mockAchievementRepo.On("GetMetrics", "user1").Return(&models.ActivityMetrics{
+		TransfersSent:      3,
+		DistinctRecipients: 2,
+		DistinctItems:      10,
+		CatalogSize:        10,
+	}, nil)
+	mockAchievementRepo.On("AwardBadges", mock.MatchedBy(func(badges []models.UserBadge) bool {
+		return assert.ObjectsAreEqual([]string{"first-thanks", "collector"}, awardedCodes(badges)) &&
+			badges[0].UserID == "user1" && !badges[0].AwardedAt.IsZero()
+	})).Return(nil)
+
+	err := uc.Evaluate("user1")
+
+	assert.NoError(t, err)
+	mockAchievementRepo.AssertExpectations(t)
+}
+
+func TestEvaluate_EmptyCatalogNeverComplete(t *testing.T) {
+	mockAchievementRepo := new(mockRepo.MockAchievementRepository)
+	uc := NewAchievementUseCase(mockAchievementRepo, nil, testAchievementRules)
+
+	mockAchievementRepo.On("GetMetrics", "user1").Return(&models.ActivityMetrics{}, nil)
+	mockAchievementRepo.On("AwardBadges", []models.UserBadge(nil)).Return(nil)
+
+	err := uc.Evaluate("user1")
+
+	assert.NoError(t, err)
+	mockAchievementRepo.AssertExpectations(t)
+}
+
+func TestBackfill_EvaluatesEveryUser(t *testing.T) {
+	mockAchievementRepo := new(mockRepo.MockAchievementRepository)
+	uc := NewAchievementUseCase(mockAchievementRepo, nil, testAchievementRules)
+
+	mockAchievementRepo.On("GetAllUserIDs").Return([]string{"user1", "user2"}, nil)
+	mockAchievementRepo.On("GetMetrics", "user1").Return(&models.ActivityMetrics{TransfersSent: 1}, nil)
+	mockAchievementRepo.On("GetMetrics", "user2").Return(&models.ActivityMetrics{DistinctRecipients: 12, TransfersSent: 12}, nil)
+	mockAchievementRepo.On("AwardBadges", mock.MatchedBy(func(badges []models.UserBadge) bool {
+		return len(badges) == 1 && badges[0].UserID == "user1"
+	})).Return(nil).Once()
+	mockAchievementRepo.On("AwardBadges", mock.MatchedBy(func(badges []models.UserBadge) bool {
+		return len(badges) == 2 && badges[0].UserID == "user2"
+	})).Return(nil).Once()
+
+	err := uc.Backfill()
+
+	assert.NoError(t, err)
+	mockAchievementRepo.AssertExpectations(t)
+}
+
+func TestBackfill_StopsOnError(t *testing.T) {
+	mockAchievementRepo := new(mockRepo.MockAchievementRepository)
+	uc := NewAchievementUseCase(mockAchievementRepo, nil, testAchievementRules)
+
+	mockAchievementRepo.On("GetAllUserIDs").Return([]string{"user1", "user2"}, nil)
+	mockAchievementRepo.On("GetMetrics", "user1").Return(nil, errors.New("database error"))
+
+	err := uc.Backfill()
+
+	assert.EqualError(t, err, "database error")
+	mockAchievementRepo.AssertNotCalled(t, "GetMetrics", "user2")
+}
+
+func TestGetBadges_SkipsRetiredRules(t *testing.T) {
+	mockAchievementRepo := new(mockRepo.MockAchievementRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAchievementUseCase(mockAchievementRepo, mockUserRepo, testAchievementRules)
+
+	awardedAt := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "id1", Username: "user1"}, nil)
+	mockAchievementRepo.On("GetBadges", "id1").Return([]models.UserBadge{
+		{UserID: "id1", Code: "first-thanks", AwardedAt: awardedAt},
+		{UserID: "id1", Code: "retired", AwardedAt: awardedAt},
+	}, nil)
+
+	badges, err := uc.GetBadges("user1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.BadgeInfo{
+		{Code: "first-thanks", Title: "Первое спасибо", AwardedAt: awardedAt},
+	}, badges.Badges)
+}
+
+func TestLoadAchievementRules_DefaultConfig(t *testing.T) {
+	rules, err := LoadAchievementRules(filepath.Join("..", "..", "config", "achievements.json"))
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rules)
+}
+
+func TestLoadAchievementRules_UnknownMetric(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "achievements.json")
+	err := os.WriteFile(filename, []byte(`[{"code": "rich", "title": "Богач", "metric": "balance", "threshold": 1}]`), 0o600)
+	assert.NoError(t, err)
+
+	_, err = LoadAchievementRules(filename)
+
+	assert.EqualError(t, err, "неизвестная метрика balance в достижении rich")
+}
+
+type recordingListener struct {
+	userIDs []string
+}
+
+func (l *recordingListener) OnActivity(userIDs ...string) {
+	l.userIDs = append(l.userIDs, userIDs...)
+}
+
+func TestSend_NotifiesActivityListeners(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
+	listener := &recordingListener{}
+	uc := NewCoinTransactionUseCase(mockTransactionRepo, mockUserRepo, listener)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "id1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "id2"}, nil)
+	mockTransactionRepo.On("RecordTransaction", mock.Anything).Return(nil)
+	mockUserRepo.On("UpdateUserBalance", "user1", -50).Return(nil)
+	mockUserRepo.On("UpdateUserBalance", "user2", 50).Return(nil)
+
+	err := uc.SendCoins("user1", "user2", 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id1", "id2"}, listener.userIDs)
+}
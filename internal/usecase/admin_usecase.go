@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	maxItemNameLength = 50
+	maxReasonLength   = 500
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+type adminUseCase struct {
+	adminRepo AdminRepository
+	userRepo  UserRepository
+	throttle  LoginThrottle
+	staff     []string
+	admins    []string
+}
+
+func NewAdminUseCase(adminRepo AdminRepository, userRepo UserRepository, throttle LoginThrottle, staff, admins []string) AdminUseCase {
+	return &adminUseCase{
+		adminRepo: adminRepo,
+		userRepo:  userRepo,
+		throttle:  throttle,
+		staff:     staff,
+		admins:    admins,
+	}
+}
+
+func (uc *adminUseCase) actor(username, permission string) (*models.User, error) {
+	return requirePermission(uc.userRepo, username, permission)
+}
+
+func requirePermission(userRepo UserRepository, username, permission string) (*models.User, error) {
+	user, err := userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	if !models.HasPermission(user.Role, permission) {
+		return nil, errors.New("недостаточно прав")
+	}
+	if twoFactorRequired(user) && !user.TOTPEnabled {
+		return nil, errAdminTwoFactorRequired
+	}
+	return user, nil
+}
+
+func validateReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", errors.New("укажите причину")
+	}
+	if len([]rune(reason)) > maxReasonLength {
+		return "", errors.New("причина слишком длинная")
+	}
+	return reason, nil
+}
+
+func (uc *adminUseCase) SaveItem(username string, req models.SaveItemRequest) error {
+	actor, err := uc.actor(username, models.PermCatalogEdit)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxItemNameLength {
+		return errors.New("некорректное название товара")
+	}
+	if req.Price <= 0 {
+		return errors.New("цена должна быть положительной")
+	}
+
+	return uc.adminRepo.SaveItem(actor.ID, &models.Product{Name: name, Price: req.Price, HasVariants: req.HasVariants})
+}
+
+func (uc *adminUseCase) GrantCoins(username string, req models.GrantRequest) error {
+	actor, err := uc.actor(username, models.PermCoinsGrant)
+	if err != nil {
+		return err
+	}
+
+	if req.Amount <= 0 {
+		return errors.New("сумма начисления должна быть положительной")
+	}
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := uc.userRepo.FindUserByUsername(req.ToUser)
+	if err != nil || recipient == nil {
+		return errors.New("получатель не найден")
+	}
+
+	return uc.adminRepo.GrantCoins(actor.ID, recipient.ID, req.Amount, reason)
+}
+
+func (uc *adminUseCase) ReverseTransaction(username, transactionID string, req models.ReverseRequest) error {
+	actor, err := uc.actor(username, models.PermCoinsReverse)
+	if err != nil {
+		return err
+	}
+
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.adminRepo.ReverseTransaction(actor.ID, transactionID, reason)
+	return err
+}
+
+func (uc *adminUseCase) SetRole(username, target string, req models.SetRoleRequest) error {
+	actor, err := uc.actor(username, models.PermUsersManage)
+	if err != nil {
+		return err
+	}
+
+	if !models.ValidRole(req.Role) {
+		return errors.New("неизвестная роль")
+	}
+	if target == username {
+		return errors.New("нельзя изменить собственную роль")
+	}
+	if newStaffSet(uc.staff)[target] || newStaffSet(uc.admins)[target] {
+		return errors.New("роль пользователя задана в конфигурации")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(target)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+	if user.ServiceAccount {
+		return errors.New("права сервисного аккаунта задаются ключами")
+	}
+
+	return uc.adminRepo.SetRole(actor.ID, user.ID, req.Role)
+}
+
+func (uc *adminUseCase) UnlockLogin(username, target string, req models.UnlockLoginRequest) error {
+	actor, err := uc.actor(username, models.PermUsersManage)
+	if err != nil {
+		return err
+	}
+
+	ip := strings.TrimSpace(req.IP)
+	if ip != "" && net.ParseIP(ip) == nil {
+		return errors.New("некорректный IP-адрес")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(target)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	if err := uc.throttle.Unlock(user.Username, ip); err != nil {
+		return err
+	}
+
+	return uc.adminRepo.LogAction(&models.AuditRecord{
+		ActorID:    actor.ID,
+		Action:     "user.unlock",
+		EntityType: "user",
+		EntityID:   user.ID,
+		Details:    ip,
+	})
+}
+
+func (uc *adminUseCase) GetAuditLog(username string, limit, offset int) ([]models.AuditEntry, error) {
+	if _, err := uc.actor(username, models.PermAuditView); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.adminRepo.GetAuditLog(limit, offset)
+}
+
+func (uc *adminUseCase) BootstrapRoles() error {
+	if err := uc.adminRepo.PromoteUsers(uc.staff, models.RoleStaff, []string{models.RoleEmployee}); err != nil {
+		return err
+	}
+	return uc.adminRepo.PromoteUsers(uc.admins, models.RoleAdmin, []string{models.RoleEmployee, models.RoleStaff})
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestGrantCoins_Success(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(mockAdminRepo, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockAdminRepo.On("GrantCoins", "boss-ID", "user-ID-1", 100, "Победа в хакатоне").Return(nil)
+
+	err := uc.GrantCoins("boss", models.GrantRequest{ToUser: "user1", Amount: 100, Reason: "  Победа в хакатоне "})
+
+	assert.NoError(t, err)
+	mockAdminRepo.AssertExpectations(t)
+}
+
+func TestGrantCoins_StaleTokenRoleIsNotTrusted(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(mockAdminRepo, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "former").Return(&models.User{ID: "former-ID", Role: models.RoleStaff}, nil)
+
+	err := uc.GrantCoins("former", models.GrantRequest{ToUser: "user1", Amount: 100, Reason: "бонус"})
+
+	assert.EqualError(t, err, "недостаточно прав")
+	mockAdminRepo.AssertNotCalled(t, "GrantCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGrantCoins_RequiresReason(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.GrantCoins("boss", models.GrantRequest{ToUser: "user1", Amount: 100, Reason: "   "})
+
+	assert.EqualError(t, err, "укажите причину")
+}
+
+func TestReverseTransaction_PassesRepositoryError(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(mockAdminRepo, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+	mockAdminRepo.On("ReverseTransaction", "boss-ID", "tx-1", "ошибочный перевод").Return(nil, errors.New("перевод уже отменён"))
+
+	err := uc.ReverseTransaction("boss", "tx-1", models.ReverseRequest{Reason: "ошибочный перевод"})
+
+	assert.EqualError(t, err, "перевод уже отменён")
+}
+
+func TestSaveItem_InvalidPrice(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.SaveItem("boss", models.SaveItemRequest{Name: "sticker", Price: -5})
+
+	assert.EqualError(t, err, "цена должна быть положительной")
+}
+
+func TestSetRole_CannotChangeOwnRole(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.SetRole("boss", "boss", models.SetRoleRequest{Role: models.RoleEmployee})
+
+	assert.EqualError(t, err, "нельзя изменить собственную роль")
+}
+
+func TestSetRole_UnknownRole(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.SetRole("boss", "user1", models.SetRoleRequest{Role: "superuser"})
+
+	assert.EqualError(t, err, "неизвестная роль")
+}
+
+func TestSetRole_ConfiguredUser(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(mockAdminRepo, mockUserRepo, newTestThrottle(), []string{"lead"}, []string{"boss"})
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.SetRole("boss", "lead", models.SetRoleRequest{Role: models.RoleEmployee})
+
+	assert.EqualError(t, err, "роль пользователя задана в конфигурации")
+	mockAdminRepo.AssertNotCalled(t, "SetRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBootstrapRoles_NeverDemotes(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	uc := NewAdminUseCase(mockAdminRepo, nil, nil, []string{"lead"}, []string{"boss"})
+
+	mockAdminRepo.On("PromoteUsers", []string{"lead"}, models.RoleStaff, []string{models.RoleEmployee}).Return(nil)
+	mockAdminRepo.On("PromoteUsers", []string{"boss"}, models.RoleAdmin, []string{models.RoleEmployee, models.RoleStaff}).Return(nil)
+
+	err := uc.BootstrapRoles()
+
+	assert.NoError(t, err)
+	mockAdminRepo.AssertExpectations(t)
+}
+
+func TestStaffRole_ManagesStoreWithoutEnvList(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewVariantUseCase(mockVariantRepo, nil, mockUserRepo, nil)
+
+	mockUserRepo.On("FindUserByUsername", "lead").Return(&models.User{ID: "lead-ID", Role: models.RoleStaff}, nil)
+	mockVariantRepo.On("AdjustStock", "hoody-s", 5).Return(nil)
+
+	err := uc.AdjustStock("lead", "hoody-s", 5)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type auctionUseCase struct {
+	auctionRepo AuctionRepository
+	userRepo    UserRepository
+	storeRepo   StoreRepository
+	variantRepo VariantRepository
+	staff       staffSet
+	listeners   []ActivityListener
+}
+
+func NewAuctionUseCase(auctionRepo AuctionRepository, userRepo UserRepository, storeRepo StoreRepository, variantRepo VariantRepository, staff []string, listeners ...ActivityListener) AuctionUseCase {
+	return &auctionUseCase{
+		auctionRepo: auctionRepo,
+		userRepo:    userRepo,
+		storeRepo:   storeRepo,
+		variantRepo: variantRepo,
+		staff:       newStaffSet(staff),
+		listeners:   listeners,
+	}
+}
+
+func (uc *auctionUseCase) CreateAuction(username string, req models.CreateAuctionRequest) (*models.AuctionInfo, error) {
+	admin, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.EndsAt.After(req.StartsAt) || !req.EndsAt.After(time.Now()) {
+		return nil, errors.New("неверный период аукциона")
+	}
+	if req.ReservePrice < 0 || req.MinIncrement < 0 {
+		return nil, errors.New("цены аукциона не могут быть отрицательными")
+	}
+	if req.MinIncrement == 0 {
+		req.MinIncrement = 1
+	}
+
+	product, err := uc.storeRepo.GetItemByName(req.Item)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	auction := &models.Auction{
+		ItemName:     product.Name,
+		ReservePrice: req.ReservePrice,
+		MinIncrement: req.MinIncrement,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		CreatedBy:    admin.ID,
+	}
+
+	if product.HasVariants && req.SKU == "" {
+		return nil, errors.New("выберите вариант товара")
+	}
+	if req.SKU != "" {
+		sku, err := uc.variantRepo.GetSKUByCode(req.SKU)
+		if err != nil || sku == nil || sku.ItemName != product.Name {
+			return nil, errors.New("вариант товара не найден")
+		}
+		auction.SKU = sku.Code
+		auction.Variant = sku.Description()
+	}
+
+	if err := uc.auctionRepo.CreateAuction(auction); err != nil {
+		return nil, err
+	}
+
+	return uc.toAuctionInfo(auction, nil), nil
+}
+
+func (uc *auctionUseCase) GetAuctions() ([]models.AuctionInfo, error) {
+	auctions, err := uc.auctionRepo.GetOpenAuctions()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]models.AuctionInfo, 0, len(auctions))
+	for i := range auctions {
+		infos = append(infos, *uc.toAuctionInfo(&auctions[i], nil))
+	}
+	return infos, nil
+}
+
+func (uc *auctionUseCase) GetAuction(id string) (*models.AuctionInfo, error) {
+	auction, err := uc.auctionRepo.GetAuction(id)
+	if err != nil {
+		return nil, err
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+
+	bids, err := uc.auctionRepo.GetBids(auction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toAuctionInfo(auction, bids), nil
+}
+
+func (uc *auctionUseCase) PlaceBid(username, auctionID string, amount int) (*models.AuctionInfo, error) {
+	if amount <= 0 {
+		return nil, errors.New("ставка должна быть положительной")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	auction, err := uc.auctionRepo.PlaceBid(auctionID, user.ID, amount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toAuctionInfo(auction, nil), nil
+}
+
+func (uc *auctionUseCase) CancelAuction(username, auctionID string) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	return uc.auctionRepo.CancelAuction(auctionID)
+}
+
+func (uc *auctionUseCase) CloseAuctions() error {
+	closed, err := uc.auctionRepo.CloseAuctions(time.Now())
+	if len(closed) > 0 {
+		log.Printf("Завершено аукционов: %d", len(closed))
+	}
+
+	for _, auction := range closed {
+		if auction.Status != models.AuctionStatusSold {
+			continue
+		}
+		for _, listener := range uc.listeners {
+			listener.OnActivity(*auction.TopBidderID)
+		}
+	}
+	return err
+}
+
+func (uc *auctionUseCase) toAuctionInfo(auction *models.Auction, bids []models.Bid) *models.AuctionInfo {
+	usernames := make(map[string]string)
+	username := func(userID string) string {
+		if name, ok := usernames[userID]; ok {
+			return name
+		}
+		if user, _ := uc.userRepo.GetUserByUserID(userID); user != nil {
+			usernames[userID] = user.Username
+		}
+		return usernames[userID]
+	}
+
+	info := &models.AuctionInfo{
+		ID:           auction.ID,
+		ItemName:     auction.ItemName,
+		SKU:          auction.SKU,
+		Variant:      auction.Variant,
+		MinIncrement: auction.MinIncrement,
+		StartsAt:     auction.StartsAt,
+		EndsAt:       auction.EndsAt,
+		Status:       auction.Status,
+		TopBid:       auction.TopBid,
+		ReserveMet:   auction.TopBidderID != nil && auction.TopBid >= auction.ReservePrice,
+		ClosedAt:     auction.ClosedAt,
+	}
+	if auction.TopBidderID != nil {
+		info.TopBidder = username(*auction.TopBidderID)
+	}
+	for _, bid := range bids {
+		info.Bids = append(info.Bids, models.BidInfo{
+			Bidder:    username(bid.BidderID),
+			Amount:    bid.Amount,
+			CreatedAt: bid.CreatedAt,
+		})
+	}
+	return info
+}
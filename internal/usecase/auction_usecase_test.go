@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestCreateAuction_Success(t *testing.T) {
+	mockAuctionRepo := new(mockRepo.MockAuctionRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewAuctionUseCase(mockAuctionRepo, mockUserRepo, mockStoreRepo, nil, []string{"admin-ID"})
+
+	now := time.Now()
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "golden-cup").Return(&models.Product{Name: "golden-cup", Price: 1000}, nil)
+	mockAuctionRepo.On("CreateAuction", mock.MatchedBy(func(auction *models.Auction) bool {
+		return auction.ItemName == "golden-cup" && auction.ReservePrice == 800 && auction.MinIncrement == 1 &&
+			auction.CreatedBy == "admin-ID"
+	})).Return(nil)
+
+	auction, err := uc.CreateAuction("admin", models.CreateAuctionRequest{
+		Item:         "golden-cup",
+		ReservePrice: 800,
+		StartsAt:     now,
+		EndsAt:       now.Add(24 * time.Hour),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "golden-cup", auction.ItemName)
+	assert.False(t, auction.ReserveMet)
+	mockAuctionRepo.AssertExpectations(t)
+}
+
+func TestCreateAuction_NotStaff(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAuctionUseCase(nil, mockUserRepo, nil, nil, []string{"admin-ID"})
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Role: models.RoleEmployee}, nil)
+
+	_, err := uc.CreateAuction("user1", models.CreateAuctionRequest{Item: "golden-cup"})
+
+	assert.EqualError(t, err, "недостаточно прав")
+}
+
+func TestCreateAuction_InvalidPeriod(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAuctionUseCase(nil, mockUserRepo, nil, nil, []string{"admin-ID"})
+
+	now := time.Now()
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+
+	_, err := uc.CreateAuction("admin", models.CreateAuctionRequest{
+		Item:     "golden-cup",
+		StartsAt: now,
+		EndsAt:   now.Add(-time.Hour),
+	})
+
+	assert.EqualError(t, err, "неверный период аукциона")
+}
+
+func TestPlaceBid_Success(t *testing.T) {
+	mockAuctionRepo := new(mockRepo.MockAuctionRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAuctionUseCase(mockAuctionRepo, mockUserRepo, nil, nil, nil)
+
+	bidderID := "user-ID-1"
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: bidderID, Username: "user1"}, nil)
+	mockUserRepo.On("GetUserByUserID", bidderID).Return(&models.User{ID: bidderID, Username: "user1"}, nil)
+	mockAuctionRepo.On("PlaceBid", "auction-1", bidderID, 900, mock.Anything).Return(&models.Auction{
+		ID:           "auction-1",
+		ReservePrice: 800,
+		TopBid:       900,
+		TopBidderID:  &bidderID,
+		Status:       models.AuctionStatusOpen,
+	}, nil)
+
+	auction, err := uc.PlaceBid("user1", "auction-1", 900)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", auction.TopBidder)
+	assert.True(t, auction.ReserveMet)
+}
+
+func TestPlaceBid_NonPositiveAmount(t *testing.T) {
+	uc := NewAuctionUseCase(nil, nil, nil, nil, nil)
+
+	_, err := uc.PlaceBid("user1", "auction-1", 0)
+
+	assert.EqualError(t, err, "ставка должна быть положительной")
+}
+
+func TestGetAuction_IncludesBids(t *testing.T) {
+	mockAuctionRepo := new(mockRepo.MockAuctionRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAuctionUseCase(mockAuctionRepo, mockUserRepo, nil, nil, nil)
+
+	topBidder := "user-ID-2"
+	mockAuctionRepo.On("GetAuction", "auction-1").Return(&models.Auction{ID: "auction-1", TopBid: 200, TopBidderID: &topBidder}, nil)
+	mockAuctionRepo.On("GetBids", "auction-1").Return([]models.Bid{
+		{BidderID: "user-ID-2", Amount: 200},
+		{BidderID: "user-ID-1", Amount: 100},
+	}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(&models.User{Username: "user1"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-2").Return(&models.User{Username: "user2"}, nil).Once()
+
+	auction, err := uc.GetAuction("auction-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user2", auction.TopBidder)
+	assert.Len(t, auction.Bids, 2)
+	assert.Equal(t, "user1", auction.Bids[1].Bidder)
+}
+
+func TestCloseAuctions_NotifiesWinners(t *testing.T) {
+	mockAuctionRepo := new(mockRepo.MockAuctionRepository)
+	listener := &recordingListener{}
+	uc := NewAuctionUseCase(mockAuctionRepo, nil, nil, nil, nil, listener)
+
+	winnerID := "user-ID-2"
+	mockAuctionRepo.On("CloseAuctions", mock.Anything).Return([]models.Auction{
+		{ID: "auction-1", Status: models.AuctionStatusSold, TopBidderID: &winnerID},
+		{ID: "auction-2", Status: models.AuctionStatusUnsold},
+	}, nil)
+
+	err := uc.CloseAuctions()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-ID-2"}, listener.userIDs)
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	defaultBountyDays  = 14
+	maxBountyDays      = 90
+	bountyClaimTimeout = 7 * 24 * time.Hour
+)
+
+type bountyUseCase struct {
+	bountyRepo BountyRepository
+	userRepo   UserRepository
+	listeners  []ActivityListener
+}
+
+func NewBountyUseCase(bountyRepo BountyRepository, userRepo UserRepository, listeners ...ActivityListener) BountyUseCase {
+	return &bountyUseCase{
+		bountyRepo: bountyRepo,
+		userRepo:   userRepo,
+		listeners:  listeners,
+	}
+}
+
+func (uc *bountyUseCase) CreateBounty(username string, req models.CreateBountyRequest) (*models.BountyInfo, error) {
+	if req.Reward <= 0 {
+		return nil, errors.New("награда должна быть положительной")
+	}
+	if req.Days == 0 {
+		req.Days = defaultBountyDays
+	}
+	if req.Days < 0 || req.Days > maxBountyDays {
+		return nil, errors.New("задание можно разместить на срок от 1 до 90 дней")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	if user.Balance < req.Reward {
+		return nil, errors.New("недостаточно монет для награды")
+	}
+
+	bounty := &models.Bounty{
+		CreatorID:   user.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Reward:      req.Reward,
+		ExpiresAt:   time.Now().AddDate(0, 0, req.Days),
+	}
+	if err := uc.bountyRepo.CreateBounty(bounty); err != nil {
+		return nil, err
+	}
+
+	return uc.toBountyInfo(bounty), nil
+}
+
+func (uc *bountyUseCase) GetOpenBounties() ([]models.BountyInfo, error) {
+	bounties, err := uc.bountyRepo.GetOpenBounties(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return uc.toBountyInfos(bounties), nil
+}
+
+func (uc *bountyUseCase) GetUserBounties(username string) ([]models.BountyInfo, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	bounties, err := uc.bountyRepo.GetUserBounties(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toBountyInfos(bounties), nil
+}
+
+func (uc *bountyUseCase) ClaimBounty(username, bountyID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.bountyRepo.ClaimBounty(bountyID, user.ID, time.Now())
+}
+
+func (uc *bountyUseCase) ReleaseBounty(username, bountyID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.bountyRepo.ReleaseBounty(bountyID, user.ID)
+}
+
+func (uc *bountyUseCase) SubmitBounty(username, bountyID, note string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.bountyRepo.SubmitBounty(bountyID, user.ID, note)
+}
+
+func (uc *bountyUseCase) ApproveBounty(username, bountyID, comment string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	bounty, err := uc.bountyRepo.ApproveBounty(bountyID, user.ID, comment)
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range uc.listeners {
+		listener.OnActivity(bounty.CreatorID, *bounty.ClaimantID)
+	}
+	return nil
+}
+
+func (uc *bountyUseCase) RejectBounty(username, bountyID, comment string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.bountyRepo.RejectBounty(bountyID, user.ID, comment)
+}
+
+func (uc *bountyUseCase) CancelBounty(username, bountyID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.bountyRepo.CancelBounty(bountyID, user.ID)
+}
+
+func (uc *bountyUseCase) ExpireBounties() error {
+	now := time.Now()
+	expired, err := uc.bountyRepo.ExpireBounties(now, now.Add(-bountyClaimTimeout))
+	if expired > 0 {
+		log.Printf("Просрочено заданий: %d", expired)
+	}
+	return err
+}
+
+func (uc *bountyUseCase) toBountyInfos(bounties []models.Bounty) []models.BountyInfo {
+	infos := make([]models.BountyInfo, 0, len(bounties))
+	for i := range bounties {
+		infos = append(infos, *uc.toBountyInfo(&bounties[i]))
+	}
+	return infos
+}
+
+func (uc *bountyUseCase) toBountyInfo(bounty *models.Bounty) *models.BountyInfo {
+	info := &models.BountyInfo{
+		ID:             bounty.ID,
+		Title:          bounty.Title,
+		Description:    bounty.Description,
+		Reward:         bounty.Reward,
+		Status:         bounty.Status,
+		SubmissionNote: bounty.SubmissionNote,
+		ReviewComment:  bounty.ReviewComment,
+		ExpiresAt:      bounty.ExpiresAt,
+		CreatedAt:      bounty.CreatedAt,
+		CompletedAt:    bounty.CompletedAt,
+	}
+	if creator, _ := uc.userRepo.GetUserByUserID(bounty.CreatorID); creator != nil {
+		info.Creator = creator.Username
+	}
+	if bounty.ClaimantID != nil {
+		if claimant, _ := uc.userRepo.GetUserByUserID(*bounty.ClaimantID); claimant != nil {
+			info.Claimant = claimant.Username
+		}
+	}
+	return info
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestCreateBounty_Success(t *testing.T) {
+	mockBountyRepo := new(mockRepo.MockBountyRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBountyUseCase(mockBountyRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1", Balance: 500}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockBountyRepo.On("CreateBounty", mock.MatchedBy(func(bounty *models.Bounty) bool {
+		return bounty.CreatorID == "user-ID-1" && bounty.Reward == 200 && !bounty.ExpiresAt.IsZero()
+	})).Return(nil)
+
+	bounty, err := uc.CreateBounty("user1", models.CreateBountyRequest{Title: "Написать ранбук", Reward: 200})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", bounty.Creator)
+	mockBountyRepo.AssertExpectations(t)
+}
+
+func TestCreateBounty_InsufficientBalance(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBountyUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 50}, nil)
+
+	_, err := uc.CreateBounty("user1", models.CreateBountyRequest{Title: "Написать ранбук", Reward: 200})
+
+	assert.EqualError(t, err, "недостаточно монет для награды")
+}
+
+func TestCreateBounty_InvalidReward(t *testing.T) {
+	uc := NewBountyUseCase(nil, nil)
+
+	_, err := uc.CreateBounty("user1", models.CreateBountyRequest{Title: "Написать ранбук", Reward: -1})
+
+	assert.EqualError(t, err, "награда должна быть положительной")
+}
+
+func TestClaimBounty_Success(t *testing.T) {
+	mockBountyRepo := new(mockRepo.MockBountyRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBountyUseCase(mockBountyRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockBountyRepo.On("ClaimBounty", "bounty-1", "user-ID-2", mock.Anything).Return(nil)
+
+	err := uc.ClaimBounty("user2", "bounty-1")
+
+	assert.NoError(t, err)
+	mockBountyRepo.AssertExpectations(t)
+}
+
+func TestApproveBounty_Success(t *testing.T) {
+	mockBountyRepo := new(mockRepo.MockBountyRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	listener := &recordingListener{}
+	uc := NewBountyUseCase(mockBountyRepo, mockUserRepo, listener)
+
+	claimantID := "user-ID-2"
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockBountyRepo.On("ApproveBounty", "bounty-1", "user-ID-1", "спасибо").
+		Return(&models.Bounty{ID: "bounty-1", CreatorID: "user-ID-1", ClaimantID: &claimantID}, nil)
+
+	err := uc.ApproveBounty("user1", "bounty-1", "спасибо")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-ID-1", "user-ID-2"}, listener.userIDs)
+	mockBountyRepo.AssertExpectations(t)
+}
+
+func TestGetUserBounties_ResolvesUsernames(t *testing.T) {
+	mockBountyRepo := new(mockRepo.MockBountyRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBountyUseCase(mockBountyRepo, mockUserRepo)
+
+	claimant := "user-ID-2"
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(&models.User{Username: "user1"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-2").Return(&models.User{Username: "user2"}, nil)
+	mockBountyRepo.On("GetUserBounties", "user-ID-1").Return([]models.Bounty{
+		{ID: "bounty-1", CreatorID: "user-ID-1", ClaimantID: &claimant, Status: models.BountyStatusSubmitted},
+	}, nil)
+
+	bounties, err := uc.GetUserBounties("user1")
+
+	assert.NoError(t, err)
+	assert.Len(t, bounties, 1)
+	assert.Equal(t, "user1", bounties[0].Creator)
+	assert.Equal(t, "user2", bounties[0].Claimant)
+}
+
+func TestExpireBounties_ReleasesStaleClaims(t *testing.T) {
+	mockBountyRepo := new(mockRepo.MockBountyRepository)
+	uc := NewBountyUseCase(mockBountyRepo, nil)
+
+	mockBountyRepo.On("ExpireBounties", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		now := args.Get(0).(time.Time)
+		claimedBefore := args.Get(1).(time.Time)
+		assert.Equal(t, bountyClaimTimeout, now.Sub(claimedBefore))
+	}).Return(1, nil)
+
+	err := uc.ExpireBounties()
+
+	assert.NoError(t, err)
+	mockBountyRepo.AssertExpectations(t)
+}
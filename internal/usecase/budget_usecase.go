@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type budgetUseCase struct {
+	budgetRepo BudgetRepository
+	userRepo   UserRepository
+	staff      staffSet
+	listeners  []ActivityListener
+}
+
+func NewBudgetUseCase(budgetRepo BudgetRepository, userRepo UserRepository, staff []string, listeners ...ActivityListener) BudgetUseCase {
+	return &budgetUseCase{
+		budgetRepo: budgetRepo,
+		userRepo:   userRepo,
+		staff:      newStaffSet(staff),
+		listeners:  listeners,
+	}
+}
+
+func (uc *budgetUseCase) CreateBudget(username string, req models.CreateBudgetRequest) (*models.BudgetInfo, error) {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return nil, err
+	}
+	if req.RefillAmount <= 0 {
+		return nil, errors.New("сумма пополнения должна быть положительной")
+	}
+	if req.RefillPeriod == "" {
+		req.RefillPeriod = models.BudgetPeriodMonth
+	}
+	if req.RefillPeriod != models.BudgetPeriodWeek && req.RefillPeriod != models.BudgetPeriodMonth {
+		return nil, errors.New("неизвестный период пополнения")
+	}
+	if (req.Owner == "") == (req.Team == "") {
+		return nil, errors.New("укажите владельца бюджета или команду")
+	}
+
+	now := time.Now()
+	budget := &models.Budget{
+		Name:         req.Name,
+		Balance:      req.RefillAmount,
+		RefillAmount: req.RefillAmount,
+		RefillPeriod: req.RefillPeriod,
+		RefilledAt:   now,
+	}
+
+	holders := req.Holders
+	if req.Owner != "" {
+		holders = append([]string{req.Owner}, holders...)
+	} else {
+		team := req.Team
+		budget.Team = &team
+	}
+	if len(holders) == 0 {
+		return nil, errors.New("у командного бюджета должен быть хотя бы один распорядитель")
+	}
+
+	holderIDs := make([]string, 0, len(holders))
+	seen := make(map[string]bool, len(holders))
+	for _, holder := range holders {
+		user, err := uc.userRepo.FindUserByUsername(holder)
+		if err != nil || user == nil {
+			return nil, errors.New("пользователь " + holder + " не найден")
+		}
+		if seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+		holderIDs = append(holderIDs, user.ID)
+		if holder == req.Owner {
+			budget.OwnerID = &user.ID
+			budget.Owner = user.Username
+		}
+	}
+
+	if err := uc.budgetRepo.CreateBudget(budget, holderIDs); err != nil {
+		return nil, err
+	}
+
+	info := toBudgetInfo(*budget)
+	return &info, nil
+}
+
+func (uc *budgetUseCase) GetBudgets(username string) ([]models.BudgetInfo, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	budgets, err := uc.budgetRepo.GetHeldBudgets(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]models.BudgetInfo, 0, len(budgets))
+	for _, budget := range budgets {
+		infos = append(infos, toBudgetInfo(budget))
+	}
+	return infos, nil
+}
+
+func (uc *budgetUseCase) SendFromBudget(username, budgetID string, req models.SendCoinRequest) error {
+	holder, recipient, err := prepareTransfer(uc.userRepo, username, req)
+	if err != nil {
+		return err
+	}
+	if holder.ID == recipient.ID {
+		return errors.New("нельзя перевести монеты самому себе")
+	}
+
+	budget, err := uc.heldBudget(holder.ID, budgetID)
+	if err != nil {
+		return err
+	}
+	if budget.Balance < req.Amount {
+		return errors.New("недостаточно монет в бюджете")
+	}
+
+	transaction := &models.CoinTransaction{
+		FromUser: holder.ID,
+		ToUser:   recipient.ID,
+		Amount:   req.Amount,
+		Message:  req.Message,
+		Public:   req.Public,
+		BudgetID: &budget.ID,
+	}
+	if err := uc.budgetRepo.SpendFromBudget(transaction); err != nil {
+		return err
+	}
+
+	for _, listener := range uc.listeners {
+		listener.OnActivity(recipient.ID)
+	}
+	return nil
+}
+
+func (uc *budgetUseCase) GetUsage(username, budgetID, period string) (*models.BudgetReport, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	var budget *models.Budget
+	if uc.staff.allows(user) {
+		budget, err = uc.budgetRepo.GetBudget(budgetID)
+		if err == nil && budget == nil {
+			err = errors.New("бюджет не найден")
+		}
+	} else {
+		budget, err = uc.heldBudget(user.ID, budgetID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if period == "" {
+		period = budget.RefillPeriod
+	}
+	if period != models.BudgetPeriodWeek && period != models.BudgetPeriodMonth {
+		return nil, errors.New("неизвестный период отчёта")
+	}
+
+	usage, err := uc.budgetRepo.GetUsage(budget.ID, period)
+	if err != nil {
+		return nil, err
+	}
+	if usage == nil {
+		usage = []models.BudgetUsage{}
+	}
+
+	return &models.BudgetReport{
+		Budget: toBudgetInfo(*budget),
+		Period: period,
+		Usage:  usage,
+	}, nil
+}
+
+func (uc *budgetUseCase) RefillBudgets() error {
+	now := time.Now()
+	periods := leaderboardPeriods(now)
+
+	refilled := 0
+	for _, period := range []string{models.BudgetPeriodWeek, models.BudgetPeriodMonth} {
+		count, err := uc.budgetRepo.RefillBudgets(period, periods[period], now)
+		if err != nil {
+			return err
+		}
+		refilled += count
+	}
+	if refilled > 0 {
+		log.Printf("Пополнено бюджетов: %d", refilled)
+	}
+	return nil
+}
+
+func (uc *budgetUseCase) heldBudget(userID, budgetID string) (*models.Budget, error) {
+	holder, err := uc.budgetRepo.IsHolder(budgetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !holder {
+		return nil, errors.New("бюджет не найден")
+	}
+
+	budget, err := uc.budgetRepo.GetBudget(budgetID)
+	if err != nil {
+		return nil, err
+	}
+	if budget == nil {
+		return nil, errors.New("бюджет не найден")
+	}
+	return budget, nil
+}
+
+func toBudgetInfo(budget models.Budget) models.BudgetInfo {
+	info := models.BudgetInfo{
+		ID:           budget.ID,
+		Name:         budget.Name,
+		Owner:        budget.Owner,
+		Balance:      budget.Balance,
+		RefillAmount: budget.RefillAmount,
+		RefillPeriod: budget.RefillPeriod,
+		RefilledAt:   budget.RefilledAt,
+	}
+	if budget.Team != nil {
+		info.Team = *budget.Team
+	}
+	return info
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestCreateBudget_OwnerBecomesHolder(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(mockBudgetRepo, mockUserRepo, []string{"admin-id"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-id", Username: "admin"}, nil)
+	mockUserRepo.On("FindUserByUsername", "manager").Return(&models.User{ID: "manager-id", Username: "manager"}, nil)
+	mockBudgetRepo.On("CreateBudget", mock.MatchedBy(func(budget *models.Budget) bool {
+		return *budget.OwnerID == "manager-id" && budget.Team == nil &&
+			budget.Balance == 500 && budget.RefillPeriod == models.BudgetPeriodMonth
+	}), []string{"manager-id"}).Return(nil)
+
+	info, err := uc.CreateBudget("admin", models.CreateBudgetRequest{
+		Name:         "Поощрения",
+		Owner:        "manager",
+		Holders:      []string{"manager"},
+		RefillAmount: 500,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "manager", info.Owner)
+	assert.Equal(t, 500, info.Balance)
+	mockBudgetRepo.AssertExpectations(t)
+}
+
+func TestCreateBudget_TeamNeedsHolders(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(nil, mockUserRepo, []string{"admin-id"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-id"}, nil)
+
+	_, err := uc.CreateBudget("admin", models.CreateBudgetRequest{Name: "Команда", Team: "engineering", RefillAmount: 500})
+
+	assert.EqualError(t, err, "у командного бюджета должен быть хотя бы один распорядитель")
+}
+
+func TestCreateBudget_RequiresStaff(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(nil, mockUserRepo, nil)
+	mockUserRepo.On("FindUserByUsername", "manager").Return(&models.User{ID: "manager-ID", Role: models.RoleEmployee}, nil)
+
+	_, err := uc.CreateBudget("manager", models.CreateBudgetRequest{Name: "Свой", Owner: "manager", RefillAmount: 500})
+
+	assert.EqualError(t, err, "недостаточно прав")
+}
+
+func TestSendFromBudget_LeavesWalletUntouched(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	listener := &recordingListener{}
+	uc := NewBudgetUseCase(mockBudgetRepo, mockUserRepo, nil, listener)
+
+	mockUserRepo.On("FindUserByUsername", "manager").Return(&models.User{ID: "manager-id", Balance: 0}, nil)
+	mockUserRepo.On("FindUserByUsername", "report").Return(&models.User{ID: "report-id"}, nil)
+	mockBudgetRepo.On("IsHolder", "budget-1", "manager-id").Return(true, nil)
+	mockBudgetRepo.On("GetBudget", "budget-1").Return(&models.Budget{ID: "budget-1", Balance: 300}, nil)
+	mockBudgetRepo.On("SpendFromBudget", mock.MatchedBy(func(transaction *models.CoinTransaction) bool {
+		return transaction.FromUser == "manager-id" && transaction.ToUser == "report-id" &&
+			transaction.Amount == 200 && *transaction.BudgetID == "budget-1"
+	})).Return(nil)
+
+	err := uc.SendFromBudget("manager", "budget-1", models.SendCoinRequest{ToUser: "report", Amount: 200})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"report-id"}, listener.userIDs)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserBalance", mock.Anything, mock.Anything)
+	mockBudgetRepo.AssertExpectations(t)
+}
+
+func TestSendFromBudget_NotHolder(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(mockBudgetRepo, mockUserRepo, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "id1"}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "id2"}, nil)
+	mockBudgetRepo.On("IsHolder", "budget-1", "id1").Return(false, nil)
+
+	err := uc.SendFromBudget("user1", "budget-1", models.SendCoinRequest{ToUser: "user2", Amount: 10})
+
+	assert.EqualError(t, err, "бюджет не найден")
+}
+
+func TestSendFromBudget_SharesTransferValidation(t *testing.T) {
+	uc := NewBudgetUseCase(nil, nil, nil)
+
+	err := uc.SendFromBudget("manager", "budget-1", models.SendCoinRequest{ToUser: "report", Amount: -10})
+
+	assert.EqualError(t, err, "сумма перевода должна быть положительной")
+}
+
+func TestSendFromBudget_ToSelf(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(mockBudgetRepo, mockUserRepo, nil)
+
+	mockUserRepo.On("FindUserByUsername", "manager").Return(&models.User{ID: "manager-id"}, nil)
+
+	err := uc.SendFromBudget("manager", "budget-1", models.SendCoinRequest{ToUser: "manager", Amount: 10})
+
+	assert.EqualError(t, err, "нельзя перевести монеты самому себе")
+	mockBudgetRepo.AssertNotCalled(t, "SpendFromBudget", mock.Anything)
+}
+
+func TestGetUsage_DefaultsToRefillPeriod(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewBudgetUseCase(mockBudgetRepo, mockUserRepo, nil)
+
+	periodStart := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	mockUserRepo.On("FindUserByUsername", "manager").Return(&models.User{ID: "manager-id"}, nil)
+	mockBudgetRepo.On("IsHolder", "budget-1", "manager-id").Return(true, nil)
+	mockBudgetRepo.On("GetBudget", "budget-1").Return(&models.Budget{ID: "budget-1", RefillPeriod: models.BudgetPeriodWeek}, nil)
+	mockBudgetRepo.On("GetUsage", "budget-1", models.BudgetPeriodWeek).Return([]models.BudgetUsage{
+		{PeriodStart: periodStart, Spent: 250, Transfers: 3, Recipients: 2},
+	}, nil)
+
+	report, err := uc.GetUsage("manager", "budget-1", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BudgetPeriodWeek, report.Period)
+	assert.Equal(t, 250, report.Usage[0].Spent)
+}
+
+func TestRefillBudgets_UsesCalendarPeriods(t *testing.T) {
+	mockBudgetRepo := new(mockRepo.MockBudgetRepository)
+	uc := NewBudgetUseCase(mockBudgetRepo, nil, nil)
+
+	mockBudgetRepo.On("RefillBudgets", models.BudgetPeriodWeek, mock.MatchedBy(func(since time.Time) bool {
+		return since.Weekday() == time.Monday
+	}), mock.Anything).Return(1, nil)
+	mockBudgetRepo.On("RefillBudgets", models.BudgetPeriodMonth, mock.MatchedBy(func(since time.Time) bool {
+		return since.Day() == 1
+	}), mock.Anything).Return(0, nil)
+
+	err := uc.RefillBudgets()
+
+	assert.NoError(t, err)
+	mockBudgetRepo.AssertExpectations(t)
+}
@@ -9,25 +9,47 @@ import (
 type coinTransactionUseCase struct {
 	coinTransactionRepo CoinTransactionRepository
 	userRepo            UserRepository
+	listeners           []ActivityListener
 }
 
-func NewCoinTransactionUseCase(coinTransactionRepo CoinTransactionRepository, userRepo UserRepository) CoinTransactionUseCase {
+func NewCoinTransactionUseCase(coinTransactionRepo CoinTransactionRepository, userRepo UserRepository, listeners ...ActivityListener) CoinTransactionUseCase {
 	return &coinTransactionUseCase{
 		coinTransactionRepo: coinTransactionRepo,
 		userRepo:            userRepo,
+		listeners:           listeners,
 	}
 }
 
 func (uc *coinTransactionUseCase) SendCoins(fromUser, toUser string, amount int) error {
+	return uc.Send(fromUser, models.SendCoinRequest{ToUser: toUser, Amount: amount})
+}
+
+func prepareTransfer(userRepo UserRepository, fromUser string, req models.SendCoinRequest) (*models.User, *models.User, error) {
+	if req.Amount <= 0 {
+		return nil, nil, errors.New("сумма перевода должна быть положительной")
+	}
+	if len([]rune(req.Message)) > maxKudosMessageLength {
+		return nil, nil, errors.New("сообщение слишком длинное")
+	}
 
-	userFrom, err := uc.userRepo.FindUserByUsername(fromUser)
+	userFrom, err := userRepo.FindUserByUsername(fromUser)
 	if err != nil || userFrom == nil {
-		return errors.New("отправитель не найден")
+		return nil, nil, errors.New("отправитель не найден")
 	}
 
-	userTo, err := uc.userRepo.FindUserByUsername(toUser)
+	userTo, err := userRepo.FindUserByUsername(req.ToUser)
 	if err != nil || userTo == nil {
-		return errors.New("получатель не найден")
+		return nil, nil, errors.New("получатель не найден")
+	}
+
+	return userFrom, userTo, nil
+}
+
+func (uc *coinTransactionUseCase) Send(fromUser string, req models.SendCoinRequest) error {
+	toUser, amount := req.ToUser, req.Amount
+	userFrom, userTo, err := prepareTransfer(uc.userRepo, fromUser, req)
+	if err != nil {
+		return err
 	}
 
 	if userFrom.Balance < amount {
@@ -38,6 +60,8 @@ func (uc *coinTransactionUseCase) SendCoins(fromUser, toUser string, amount int)
 		FromUser: userFrom.ID,
 		ToUser:   userTo.ID,
 		Amount:   amount,
+		Message:  req.Message,
+		Public:   req.Public,
 	}
 
 	err = uc.coinTransactionRepo.RecordTransaction(transaction)
@@ -52,5 +76,9 @@ func (uc *coinTransactionUseCase) SendCoins(fromUser, toUser string, amount int)
 		return err
 	}
 
+	for _, listener := range uc.listeners {
+		listener.OnActivity(userFrom.ID, userTo.ID)
+	}
+
 	return nil
 }
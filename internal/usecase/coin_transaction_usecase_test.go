@@ -142,3 +142,22 @@ func TestSendCoins_UpdateReceiverBalanceError(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 	mockTransactionRepo.AssertExpectations(t)
 }
+
+func TestSend_RecordsPublicMessage(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
+	uc := NewCoinTransactionUseCase(mockTransactionRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user2", Balance: 50}, nil)
+	mockTransactionRepo.On("RecordTransaction", mock.MatchedBy(func(transaction *models.CoinTransaction) bool {
+		return transaction.Message == "Спасибо за релиз!" && transaction.Public
+	})).Return(nil)
+	mockUserRepo.On("UpdateUserBalance", "user1", -50).Return(nil)
+	mockUserRepo.On("UpdateUserBalance", "user2", 50).Return(nil)
+
+	err := uc.Send("user1", models.SendCoinRequest{ToUser: "user2", Amount: 50, Message: "Спасибо за релиз!", Public: true})
+
+	assert.NoError(t, err)
+	mockTransactionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type giftUseCase struct {
+	giftRepo     GiftRepository
+	userRepo     UserRepository
+	storeRepo    StoreRepository
+	variantRepo  VariantRepository
+	pricingRepo  PricingRepository
+	purchaseRepo PurchaseRepository
+	listeners    []ActivityListener
+}
+
+func NewGiftUseCase(giftRepo GiftRepository, userRepo UserRepository, storeRepo StoreRepository, variantRepo VariantRepository, pricingRepo PricingRepository, purchaseRepo PurchaseRepository, listeners ...ActivityListener) GiftUseCase {
+	return &giftUseCase{
+		giftRepo:     giftRepo,
+		userRepo:     userRepo,
+		storeRepo:    storeRepo,
+		variantRepo:  variantRepo,
+		pricingRepo:  pricingRepo,
+		purchaseRepo: purchaseRepo,
+		listeners:    listeners,
+	}
+}
+
+func (uc *giftUseCase) SendGift(fromUser string, req models.SendGiftRequest) (*models.Gift, error) {
+	if fromUser == req.ToUser {
+		return nil, errors.New("нельзя подарить товар самому себе")
+	}
+
+	buyer, err := uc.userRepo.FindUserByUsername(fromUser)
+	if err != nil || buyer == nil {
+		return nil, errors.New("отправитель не найден")
+	}
+
+	recipient, err := uc.userRepo.FindUserByUsername(req.ToUser)
+	if err != nil || recipient == nil {
+		return nil, errors.New("получатель не найден")
+	}
+
+	now := time.Now()
+	buyRequest := models.BuyRequest{Item: req.Item, SKU: req.SKU, PromoCode: req.PromoCode}
+	quote, err := quotePurchase(uc.storeRepo, uc.variantRepo, uc.pricingRepo, buyer, buyRequest, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEligibility(uc.purchaseRepo, recipient, quote.product, now); err != nil {
+		return nil, err
+	}
+
+	if buyer.Balance < quote.price {
+		return nil, errors.New("недостаточно монет для покупки")
+	}
+
+	inventory := quote.inventoryFor(recipient.ID)
+	inventory.NoReturn = true
+	gift := &models.Gift{
+		FromUser: buyer.ID,
+		ToUser:   recipient.ID,
+		ItemType: inventory.ItemType,
+		SKU:      inventory.SKU,
+		Variant:  inventory.Variant,
+		Amount:   quote.price,
+		Message:  req.Message,
+		Status:   models.GiftStatusDelivered,
+	}
+
+	order := &models.PurchaseOrder{
+		PayerID:   buyer.ID,
+		Amount:    quote.price,
+		PromoCode: quote.promoCode,
+		Inventory: inventory,
+	}
+	if err := uc.giftRepo.CreateGift(gift, order); err != nil {
+		return nil, err
+	}
+
+	for _, listener := range uc.listeners {
+		listener.OnActivity(buyer.ID, recipient.ID)
+	}
+
+	return gift, nil
+}
+
+func (uc *giftUseCase) DeclineGift(username, giftID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.giftRepo.DeclineGift(giftID, user.ID)
+}
+
+func (uc *giftUseCase) GetGiftHistory(username string) (*models.GiftHistory, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	gifts, err := uc.giftRepo.GetUserGifts(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.GiftHistory{}
+	for _, gift := range gifts {
+		info := models.GiftInfo{
+			ID:        gift.ID,
+			ItemName:  gift.ItemType,
+			Variant:   gift.Variant,
+			Amount:    gift.Amount,
+			Message:   gift.Message,
+			Status:    gift.Status,
+			CreatedAt: gift.CreatedAt,
+		}
+
+		if gift.FromUser == user.ID {
+			if other, _ := uc.userRepo.GetUserByUserID(gift.ToUser); other != nil {
+				info.Username = other.Username
+			}
+			history.Sent = append(history.Sent, info)
+			continue
+		}
+
+		if other, _ := uc.userRepo.GetUserByUserID(gift.FromUser); other != nil {
+			info.Username = other.Username
+		}
+		history.Received = append(history.Received, info)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestSendGift_Success(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2", Balance: 0}, nil)
+	mockStoreRepo.On("GetItemByName", "cup").Return(&models.Product{Name: "cup", Price: 20}, nil)
+	mockGiftRepo.On("CreateGift", mock.MatchedBy(func(gift *models.Gift) bool {
+		return gift.FromUser == "user-ID-1" && gift.ToUser == "user-ID-2" && gift.Amount == 20 && gift.Message == "спасибо"
+	}), &models.PurchaseOrder{
+		PayerID:   "user-ID-1",
+		Amount:    20,
+		Inventory: &models.Inventory{UserID: "user-ID-2", ItemType: "cup", Quantity: 1, Price: intPtr(20), NoReturn: true},
+	}).Return(nil)
+
+	gift, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "cup", Message: "спасибо"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.GiftStatusDelivered, gift.Status)
+	mockGiftRepo.AssertExpectations(t)
+}
+
+func TestSendGift_RecipientCannotReturn(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, nil, newBasePricingRepo(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "cup").Return(&models.Product{Name: "cup", Price: 20}, nil)
+	var gifted *models.Inventory
+	mockGiftRepo.On("CreateGift", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		gifted = args.Get(1).(*models.PurchaseOrder).Inventory
+		gifted.ID = "inv-1"
+		gifted.CreatedAt = time.Now()
+	}).Return(nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "cup"})
+	assert.NoError(t, err)
+
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(gifted, nil)
+	returns := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, mockStoreRepo, 24*time.Hour, nil)
+
+	_, err = returns.RequestReturn("user2", "inv-1", "")
+
+	assert.EqualError(t, err, "этот товар нельзя вернуть")
+	mockReturnRepo.AssertNotCalled(t, "CreateReturn", mock.Anything)
+}
+
+func TestSendGift_NotifiesActivityListeners(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	listener := &recordingListener{}
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, nil, newBasePricingRepo(), new(mockRepo.MockPurchaseRepository), listener)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "cup").Return(&models.Product{Name: "cup", Price: 20}, nil)
+	mockGiftRepo.On("CreateGift", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "cup"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-ID-1", "user-ID-2"}, listener.userIDs)
+}
+
+func TestSendGift_ToSelf(t *testing.T) {
+	uc := NewGiftUseCase(nil, nil, nil, nil, nil, nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user1", Item: "cup"})
+
+	assert.Equal(t, "нельзя подарить товар самому себе", err.Error())
+}
+
+func TestSendGift_InsufficientBalance(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, nil, newBasePricingRepo(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 10}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "cup").Return(&models.Product{Name: "cup", Price: 20}, nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "cup"})
+
+	assert.Equal(t, "недостаточно монет для покупки", err.Error())
+	mockGiftRepo.AssertNotCalled(t, "CreateGift", mock.Anything, mock.Anything)
+}
+
+func TestSendGift_TakesStockInsideTransaction(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 500}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300, HasVariants: true}, nil)
+	mockVariantRepo.On("GetSKUByCode", "hoody-m").Return(&models.SKU{Code: "hoody-m", ItemName: "hoody"}, nil)
+	mockGiftRepo.On("CreateGift", mock.Anything, mock.MatchedBy(func(order *models.PurchaseOrder) bool {
+		return order.PayerID == "user-ID-1" && order.Inventory.UserID == "user-ID-2" && order.Inventory.SKU == "hoody-m"
+	})).Return(errors.New("товара нет в наличии"))
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "hoody", SKU: "hoody-m"})
+
+	assert.EqualError(t, err, "товара нет в наличии")
+	mockVariantRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything)
+}
+
+func TestSendGift_RedeemsPromoCode(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockPricingRepo := newBasePricingRepo()
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, nil, mockPricingRepo, new(mockRepo.MockPurchaseRepository))
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "book").Return(&models.Product{Name: "book", Price: 50}, nil)
+	mockPricingRepo.On("GetPromoCode", "BOOK20").Return(&models.PromoCode{Code: "BOOK20", PercentOff: 20, MaxUses: 1}, nil)
+	mockGiftRepo.On("CreateGift", mock.MatchedBy(func(gift *models.Gift) bool {
+		return gift.Amount == 40
+	}), &models.PurchaseOrder{
+		PayerID:   "user-ID-1",
+		Amount:    40,
+		PromoCode: "BOOK20",
+		Inventory: &models.Inventory{UserID: "user-ID-2", ItemType: "book", Quantity: 1, Price: intPtr(40), NoReturn: true},
+	}).Return(nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "book", PromoCode: "BOOK20"})
+
+	assert.NoError(t, err)
+	mockGiftRepo.AssertExpectations(t)
+}
+
+func TestSendGift_RecipientLimitReached(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, mockStoreRepo, nil, newBasePricingRepo(), mockPurchaseRepo)
+
+	limit := 1
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 1000}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockStoreRepo.On("GetItemByName", "pink-hoody").Return(&models.Product{
+		Name:  "pink-hoody",
+		Price: 500,
+		Rule:  &models.ItemRule{ItemName: "pink-hoody", MaxPerUser: &limit},
+	}, nil)
+	mockPurchaseRepo.On("CountPurchases", "user-ID-2", "pink-hoody", mock.Anything).Return(1, nil)
+
+	_, err := uc.SendGift("user1", models.SendGiftRequest{ToUser: "user2", Item: "pink-hoody"})
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityLimitPerUser, eligibilityErr.Code)
+	mockGiftRepo.AssertNotCalled(t, "CreateGift", mock.Anything, mock.Anything)
+}
+
+func TestDeclineGift_Success(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockGiftRepo.On("DeclineGift", "gift-1", "user-ID-2").Return(nil)
+
+	err := uc.DeclineGift("user2", "gift-1")
+
+	assert.NoError(t, err)
+	mockGiftRepo.AssertExpectations(t)
+}
+
+func TestGetGiftHistory_SplitsDirections(t *testing.T) {
+	mockGiftRepo := new(mockRepo.MockGiftRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewGiftUseCase(mockGiftRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-2").Return(&models.User{ID: "user-ID-2", Username: "user2"}, nil)
+	mockGiftRepo.On("GetUserGifts", "user-ID-1").Return([]models.Gift{
+		{ID: "gift-1", FromUser: "user-ID-1", ToUser: "user-ID-2", ItemType: "cup", Message: "спасибо"},
+		{ID: "gift-2", FromUser: "user-ID-2", ToUser: "user-ID-1", ItemType: "pen"},
+	}, nil)
+
+	history, err := uc.GetGiftHistory("user1")
+
+	assert.NoError(t, err)
+	assert.Len(t, history.Sent, 1)
+	assert.Len(t, history.Received, 1)
+	assert.Equal(t, "user2", history.Sent[0].Username)
+	assert.Equal(t, "спасибо", history.Sent[0].Message)
+	assert.Equal(t, "pen", history.Received[0].ItemName)
+}
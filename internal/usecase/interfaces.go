@@ -1,6 +1,10 @@
 package usecase
 
-import "avito-shop-test/internal/models"
+import (
+	"time"
+
+	"avito-shop-test/internal/models"
+)
 
 type CoinTransactionRepository interface {
 	RecordTransaction(transaction *models.CoinTransaction) error
@@ -9,6 +13,7 @@ type CoinTransactionRepository interface {
 
 type CoinTransactionUseCase interface {
 	SendCoins(fromUser, toUser string, amount int) error
+	Send(fromUser string, req models.SendCoinRequest) error
 }
 
 type UserRepository interface {
@@ -16,26 +21,431 @@ type UserRepository interface {
 	CreateUser(user *models.User) error
 	UpdateUserBalance(username string, amount int) error
 	GetUserByUserID(userID string) (*models.User, error)
+	UpdateUserAttributes(username, department string, hiredAt *time.Time) error
+	UpdatePrivacy(username string, settings models.PrivacySettings) error
+	UpdatePassword(username, hash string) error
 }
 
 type UserUseCase interface {
-	Authenticate(username, password string) (string, error)
+	Authenticate(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	Register(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	Login(username, password string, client models.ClientInfo) (*models.AuthResponse, error)
+	VerifyTwoFactor(challenge, code string, client models.ClientInfo) (*models.AuthResponse, error)
+	Refresh(refreshToken string, client models.ClientInfo) (*models.AuthResponse, error)
+	Logout(username, jti string, expiresAt time.Time, req models.LogoutRequest) error
+	PurgeExpiredTokens() error
+	IssueTokens(user *models.User, client models.ClientInfo) (*models.AuthResponse, error)
+	GetSessions(username, currentID string) ([]models.SessionInfo, error)
+	RevokeSession(username, sessionID string) error
+	RevokeAllSessions(username, currentID string, req models.RevokeSessionsRequest) error
 	GetUserInfo(username string) (*models.UserInfo, error)
 	GetCoinHistory(userID string) (models.CoinHistory, error)
 	GetPurchasedItems(userID string) ([]models.PurchasedItem, error)
 }
 
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	UseRefreshToken(tokenHash string, now time.Time) (*models.RefreshToken, bool, error)
+	RevokeFamily(familyID string, now time.Time) error
+	RevokeUserTokens(userID string, now time.Time) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+	DeleteExpired(now time.Time) error
+	CreateSession(session *models.Session) error
+	ExtendSession(id, ip string, now, expiresAt time.Time) error
+	TouchSession(id, ip string, now time.Time) (bool, error)
+	GetSessions(userID string, now time.Time) ([]models.Session, error)
+	RevokeSession(userID, id string, now time.Time) (bool, error)
+	RevokeOtherSessions(userID, keepID string, now time.Time) error
+}
+
 type PurchaseRepository interface {
-	RecordPurchase(inventory *models.Inventory) error
+	RecordPurchase(order *models.PurchaseOrder) error
 	GetPurchasedItems(userID string) ([]models.Inventory, error)
+	GetPurchaseByID(id string) (*models.Inventory, error)
+	CountPurchases(userID, itemType string, since time.Time) (int, error)
 }
 
 type PurchaseUseCase interface {
 	BuyItem(username string, itemName string) error
+	Buy(username string, req models.BuyRequest) error
+	GetPurchaseLines(username string) ([]models.PurchaseLine, error)
+}
+
+type ReturnRepository interface {
+	CreateReturn(ret *models.ReturnRequest) error
+	GetReturnByID(id string) (*models.ReturnRequest, error)
+	GetUserReturns(userID string) ([]models.ReturnRequest, error)
+	GetPendingReturns() ([]models.ReturnRequest, error)
+	ApproveReturn(id, reviewerID, comment string) error
+	RejectReturn(id, reviewerID, comment string) error
+}
+
+type ReturnUseCase interface {
+	RequestReturn(username, inventoryID, reason string) (*models.ReturnRequest, error)
+	GetUserReturns(username string) ([]models.ReturnRequest, error)
+	GetPendingReturns(username string) ([]models.ReturnRequest, error)
+	ApproveReturn(username, returnID, comment string) error
+	RejectReturn(username, returnID, comment string) error
 }
 
 type StoreRepository interface {
 	GetItemByName(name string) (*models.Product, error)
+	GetItems() ([]models.Product, error)
+}
+
+type VariantRepository interface {
+	GetItemOptions(itemName string) ([]models.ItemOption, error)
+	AddItemOptions(options []models.ItemOption) error
+	GetSKUs(itemName string) ([]models.SKU, error)
+	GetSKUByCode(code string) (*models.SKU, error)
+	CreateSKU(sku *models.SKU) error
+	AdjustStock(code string, delta int) error
+}
+
+type VariantUseCase interface {
+	GetVariants(itemName string) (*models.ItemVariants, error)
+	AddOption(username, itemName string, req models.AddItemOptionRequest) error
+	CreateSKU(username, itemName string, req models.CreateSKURequest) (*models.SKU, error)
+	AdjustStock(username, code string, delta int) error
+}
+
+type GiftRepository interface {
+	CreateGift(gift *models.Gift, order *models.PurchaseOrder) error
+	GetUserGifts(userID string) ([]models.Gift, error)
+	DeclineGift(id, recipientID string) error
+}
+
+type GiftUseCase interface {
+	SendGift(fromUser string, req models.SendGiftRequest) (*models.Gift, error)
+	DeclineGift(username, giftID string) error
+	GetGiftHistory(username string) (*models.GiftHistory, error)
+}
+
+type RuleRepository interface {
+	GetRule(itemName string) (*models.ItemRule, error)
+	SaveRule(rule *models.ItemRule) error
+}
+
+type RuleUseCase interface {
+	GetRule(itemName string) (*models.ItemRule, error)
+	SaveRule(username, itemName string, rule models.ItemRule) error
+	UpdateUserAttributes(username, targetUsername string, req models.UpdateUserAttributesRequest) error
+}
+
+type PricingRepository interface {
+	GetScheduledPrice(itemName string, at time.Time) (*models.PriceChange, error)
+	GetPriceHistory(itemName string) ([]models.PriceChange, error)
+	SchedulePrice(change *models.PriceChange) error
+	GetActivePromotions(itemName string, at time.Time) ([]models.Promotion, error)
+	CreatePromotion(promotion *models.Promotion) error
+	GetPromoCode(code string) (*models.PromoCode, error)
+	CreatePromoCode(code *models.PromoCode) error
+}
+
+type PricingUseCase interface {
+	GetCatalog() ([]models.CatalogItem, error)
+	GetPrice(username string, req models.BuyRequest) (*models.PriceQuote, error)
+	GetPriceHistory(itemName string) ([]models.PriceChange, error)
+	SchedulePrice(username, itemName string, req models.SchedulePriceRequest) (*models.PriceChange, error)
+	CreatePromotion(username string, req models.CreatePromotionRequest) (*models.Promotion, error)
+	CreatePromoCode(username string, req models.CreatePromoCodeRequest) (*models.PromoCode, error)
+}
+
+type WishlistRepository interface {
+	GetWishlist(userID string) (*models.Wishlist, error)
+	GetWishlistByToken(token string) (*models.Wishlist, error)
+	CreateWishlist(wishlist *models.Wishlist) error
+	AddItem(item *models.WishlistItem) error
+	GetItems(userID string) ([]models.WishlistItem, error)
+	GetItem(id string) (*models.WishlistItem, error)
+	RemoveItem(id, userID string) error
+	StartCampaign(id, userID string, target int, expiresAt time.Time) error
+	Contribute(id, contributorID string, amount int) (*models.WishlistItem, error)
+	ExpireCampaigns(now time.Time) (int, error)
+}
+
+type WishlistUseCase interface {
+	GetWishlist(username string) (*models.WishlistView, error)
+	GetSharedWishlist(token string) (*models.WishlistView, error)
+	AddItem(username string, req models.AddWishlistItemRequest) (*models.WishlistItem, error)
+	RemoveItem(username, itemID string) error
+	StartCampaign(username, itemID string, days int) (*models.WishlistItem, error)
+	Contribute(username, itemID string, amount int) (*models.WishlistItem, error)
+	ExpireCampaigns() error
+}
+
+type MarketRepository interface {
+	CreateListing(listing *models.Listing) error
+	GetListing(id string) (*models.Listing, error)
+	SearchListings(filter models.ListingFilter, now time.Time) ([]models.ListingInfo, error)
+	GetUserListings(userID string) ([]models.Listing, error)
+	CancelListing(id, sellerID string) error
+	BuyListing(id, buyerID string, now time.Time) (*models.Listing, error)
+	ExpireListings(now time.Time) (int, error)
+}
+
+type MarketUseCase interface {
+	CreateListing(username string, req models.CreateListingRequest) (*models.Listing, error)
+	SearchListings(filter models.ListingFilter) ([]models.ListingInfo, error)
+	GetUserListings(username string) ([]models.Listing, error)
+	CancelListing(username, listingID string) error
+	BuyListing(username, listingID string) (*models.Listing, error)
+	ExpireListings() error
+}
+
+type TradeRepository interface {
+	CreateTrade(trade *models.Trade) error
+	GetUserTrades(userID string) ([]models.Trade, error)
+	AcceptTrade(id, counterpartyID string) error
+	RejectTrade(id, counterpartyID string) error
+	CancelTrade(id, proposerID string) error
+}
+
+type TradeUseCase interface {
+	ProposeTrade(username string, req models.CreateTradeRequest) (*models.TradeInfo, error)
+	GetTrades(username string) (*models.TradeHistory, error)
+	AcceptTrade(username, tradeID string) error
+	RejectTrade(username, tradeID string) error
+	CancelTrade(username, tradeID string) error
+}
+
+type AuctionRepository interface {
+	CreateAuction(auction *models.Auction) error
+	GetAuction(id string) (*models.Auction, error)
+	GetOpenAuctions() ([]models.Auction, error)
+	GetBids(auctionID string) ([]models.Bid, error)
+	PlaceBid(id, bidderID string, amount int, now time.Time) (*models.Auction, error)
+	CancelAuction(id string) error
+	CloseAuctions(now time.Time) ([]models.Auction, error)
+}
+
+type AuctionUseCase interface {
+	CreateAuction(username string, req models.CreateAuctionRequest) (*models.AuctionInfo, error)
+	GetAuctions() ([]models.AuctionInfo, error)
+	GetAuction(id string) (*models.AuctionInfo, error)
+	PlaceBid(username, auctionID string, amount int) (*models.AuctionInfo, error)
+	CancelAuction(username, auctionID string) error
+	CloseAuctions() error
+}
+
+type RaffleRepository interface {
+	CreateRaffle(raffle *models.Raffle) error
+	GetRaffle(id string) (*models.Raffle, error)
+	GetRaffles() ([]models.Raffle, error)
+	GetDueRaffles(now time.Time) ([]models.Raffle, error)
+	GetWinningTickets(raffleID string) ([]models.RaffleTicket, error)
+	BuyTickets(id, userID string, count int, now time.Time) ([]models.RaffleTicket, error)
+	CompleteDraw(id string, ticketsSold int, numbers []int) error
+	CancelRaffle(id string) error
+}
+
+type RaffleUseCase interface {
+	CreateRaffle(username string, req models.CreateRaffleRequest) (*models.RaffleInfo, error)
+	GetRaffles() ([]models.RaffleInfo, error)
+	GetRaffle(id string) (*models.RaffleInfo, error)
+	BuyTickets(username, raffleID string, count int) ([]int, error)
+	CancelRaffle(username, raffleID string) error
+	DrawRaffles() error
+}
+
+type BountyRepository interface {
+	CreateBounty(bounty *models.Bounty) error
+	GetBounty(id string) (*models.Bounty, error)
+	GetOpenBounties(now time.Time) ([]models.Bounty, error)
+	GetUserBounties(userID string) ([]models.Bounty, error)
+	ClaimBounty(id, claimantID string, now time.Time) error
+	ReleaseBounty(id, claimantID string) error
+	SubmitBounty(id, claimantID, note string) error
+	RejectBounty(id, creatorID, comment string) error
+	ApproveBounty(id, creatorID, comment string) (*models.Bounty, error)
+	CancelBounty(id, creatorID string) error
+	ExpireBounties(now, claimedBefore time.Time) (int, error)
+}
+
+type BountyUseCase interface {
+	CreateBounty(username string, req models.CreateBountyRequest) (*models.BountyInfo, error)
+	GetOpenBounties() ([]models.BountyInfo, error)
+	GetUserBounties(username string) ([]models.BountyInfo, error)
+	ClaimBounty(username, bountyID string) error
+	ReleaseBounty(username, bountyID string) error
+	SubmitBounty(username, bountyID, note string) error
+	ApproveBounty(username, bountyID, comment string) error
+	RejectBounty(username, bountyID, comment string) error
+	CancelBounty(username, bountyID string) error
+	ExpireBounties() error
+}
+
+type KudosRepository interface {
+	GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error)
+	GetReactionCounts(transactionIDs []string) ([]models.ReactionCount, error)
+	IsPublic(transactionID string) (bool, error)
+	AddReaction(reaction *models.KudosReaction) error
+	RemoveReaction(transactionID, userID, emoji string) error
+	AddComment(comment *models.KudosComment) error
+	GetComments(transactionID string) ([]models.KudosComment, error)
+}
+
+type KudosUseCase interface {
+	GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error)
+	AddReaction(username, transactionID, emoji string) error
+	RemoveReaction(username, transactionID, emoji string) error
+	AddComment(username, transactionID, text string) (*models.KudosComment, error)
+	GetComments(transactionID string) ([]models.KudosComment, error)
+	GetPrivacy(username string) (*models.PrivacySettings, error)
+	UpdatePrivacy(username string, settings models.PrivacySettings) error
+}
+
+type LeaderboardRepository interface {
+	RefreshLeaderboards(periods map[string]time.Time, size int, now time.Time) error
+	GetLeaderboard(board, period string) ([]models.LeaderboardEntry, error)
+}
+
+type LeaderboardUseCase interface {
+	GetLeaderboard(board, period string) (*models.Leaderboard, error)
+	RefreshLeaderboards() error
+}
+
+type AchievementRepository interface {
+	GetMetrics(userID string) (*models.ActivityMetrics, error)
+	AwardBadges(badges []models.UserBadge) error
+	GetBadges(userID string) ([]models.UserBadge, error)
+	GetAllUserIDs() ([]string, error)
+}
+
+type AchievementUseCase interface {
+	GetBadges(username string) (*models.UserBadges, error)
+	Evaluate(userID string) error
+	OnActivity(userIDs ...string)
+	Backfill() error
+}
+
+type ActivityListener interface {
+	OnActivity(userIDs ...string)
+}
+
+type BudgetRepository interface {
+	CreateBudget(budget *models.Budget, holderIDs []string) error
+	GetBudget(id string) (*models.Budget, error)
+	GetHeldBudgets(userID string) ([]models.Budget, error)
+	IsHolder(budgetID, userID string) (bool, error)
+	SpendFromBudget(transaction *models.CoinTransaction) error
+	RefillBudgets(period string, since, now time.Time) (int, error)
+	GetUsage(budgetID, period string) ([]models.BudgetUsage, error)
+}
+
+type BudgetUseCase interface {
+	CreateBudget(username string, req models.CreateBudgetRequest) (*models.BudgetInfo, error)
+	GetBudgets(username string) ([]models.BudgetInfo, error)
+	SendFromBudget(username, budgetID string, req models.SendCoinRequest) error
+	GetUsage(username, budgetID, period string) (*models.BudgetReport, error)
+	RefillBudgets() error
+}
+
+type AdminRepository interface {
+	SaveItem(actorID string, item *models.Product) error
+	GrantCoins(actorID, userID string, amount int, reason string) error
+	ReverseTransaction(actorID, transactionID, reason string) (*models.CoinTransaction, error)
+	SetRole(actorID, userID, role string) error
+	LogAction(record *models.AuditRecord) error
+	PromoteUsers(usernames []string, role string, from []string) error
+	GetAuditLog(limit, offset int) ([]models.AuditEntry, error)
+}
+
+type AdminUseCase interface {
+	SaveItem(username string, req models.SaveItemRequest) error
+	GrantCoins(username string, req models.GrantRequest) error
+	ReverseTransaction(username, transactionID string, req models.ReverseRequest) error
+	SetRole(username, target string, req models.SetRoleRequest) error
+	UnlockLogin(username, target string, req models.UnlockLoginRequest) error
+	GetAuditLog(username string, limit, offset int) ([]models.AuditEntry, error)
+	BootstrapRoles() error
+}
+
+type IdentityRepository interface {
+	GetUserByIdentity(issuer, subject string) (*models.User, error)
+	CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error
+	UpdateIdentityEmail(issuer, subject, email string) error
+	SaveLoginState(state *models.SSOLoginState) error
+	ConsumeLoginState(state string, now time.Time) (*models.SSOLoginState, error)
+	DeleteExpiredLoginStates(now time.Time) error
+}
+
+type OIDCProvider interface {
+	Issuer() string
+	AuthCodeURL(state, nonce, codeChallenge string) (string, error)
+	Exchange(code, codeVerifier string) (*models.ExternalIdentity, error)
+}
+
+type SessionIssuer interface {
+	IssueTokens(user *models.User, client models.ClientInfo) (*models.AuthResponse, error)
+}
+
+type SSOUseCase interface {
+	StartLogin() (*models.SSOLoginResponse, error)
+	CompleteLogin(state, code string, client models.ClientInfo) (*models.AuthResponse, error)
+	PurgeExpiredStates() error
+}
+
+type LoginAttemptRepository interface {
+	GetBlockedUntil(keys []string) (*time.Time, error)
+	RecordLoginFailure(key string, now, windowStart time.Time) (int, error)
+	BlockLogin(key string, until time.Time) error
+	ResetLoginAttempts(keys ...string) error
+	DeleteStaleLoginAttempts(before time.Time) error
+}
+
+type LoginThrottle interface {
+	Check(username, ip string) error
+	RecordFailure(username, ip string)
+	RecordSuccess(username string)
+	Unlock(username, ip string) error
+	PurgeStale() error
+}
+
+type ServiceAccountRepository interface {
+	CreateServiceAccount(actorID string, account *models.User) error
+	GetServiceAccounts() ([]models.User, error)
+	GetAPIKeys(userIDs []string) ([]models.APIKey, error)
+	GetAPIKey(id string) (*models.APIKey, error)
+	GetAPIKeyByPrefix(prefix string) (*models.APIKey, error)
+	CreateAPIKey(actorID string, key *models.APIKey) error
+	RotateAPIKey(actorID, oldID string, key *models.APIKey, oldRevokedAt time.Time) error
+	RevokeAPIKey(actorID, id string, at time.Time) error
+	TouchAPIKey(id string, at time.Time) error
+}
+
+type ServiceAccountUseCase interface {
+	CreateServiceAccount(username string, req models.CreateServiceAccountRequest) (*models.ServiceAccount, error)
+	GetServiceAccounts(username string) ([]models.ServiceAccount, error)
+	CreateAPIKey(username, accountName string, req models.CreateAPIKeyRequest) (*models.APIKeyResponse, error)
+	RotateAPIKey(username, keyID string, req models.RotateAPIKeyRequest) (*models.APIKeyResponse, error)
+	RevokeAPIKey(username, keyID string) error
+	AuthenticateAPIKey(secret string) (*models.APIKeyPrincipal, error)
+}
+
+type TwoFactorRepository interface {
+	SaveTOTPSecret(userID, secret string) error
+	EnableTOTP(userID string, step int64, codeHashes []string) error
+	DisableTOTP(userID string) error
+	UseTOTPStep(userID string, step int64) (bool, error)
+	UseRecoveryCode(userID, codeHash string, at time.Time) (bool, error)
+	ReplaceRecoveryCodes(userID string, codeHashes []string) error
+	CountRecoveryCodes(userID string) (int, error)
+	CreateChallenge(challenge *models.MFAChallenge) error
+	UseChallenge(id string, now time.Time, maxAttempts int) (*models.MFAChallenge, error)
+	DeleteChallenge(id string) error
+	DeleteExpiredChallenges(now time.Time) error
+}
+
+type TwoFactorUseCase interface {
+	GetStatus(username string) (*models.TwoFactorStatus, error)
+	Enroll(username, sessionID string) (*models.TOTPEnrollment, error)
+	Confirm(username, sessionID string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error)
+	Disable(username string, req models.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(username string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error)
+	PurgeExpiredChallenges() error
 }
 
 type StoreUseCase interface {
@@ -43,5 +453,11 @@ type StoreUseCase interface {
 }
 
 type TokenGenerator interface {
-	Generate(username string) (string, error)
+	Generate(username, role, sessionID string) (string, error)
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) bool
+	NeedsRehash(hash string) bool
 }
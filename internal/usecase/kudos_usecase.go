@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	maxKudosMessageLength = 500
+	maxCommentLength      = 500
+	maxEmojiLength        = 8
+	defaultFeedLimit      = 20
+	maxFeedLimit          = 100
+)
+
+type kudosUseCase struct {
+	kudosRepo KudosRepository
+	userRepo  UserRepository
+}
+
+func NewKudosUseCase(kudosRepo KudosRepository, userRepo UserRepository) KudosUseCase {
+	return &kudosUseCase{
+		kudosRepo: kudosRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (uc *kudosUseCase) GetFeed(filter models.KudosFilter) ([]models.KudosEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultFeedLimit
+	}
+	if filter.Limit > maxFeedLimit {
+		filter.Limit = maxFeedLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	entries, err := uc.kudosRepo.GetFeed(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	byID := make(map[string]*models.KudosEntry, len(entries))
+	for i := range entries {
+		entries[i].Reactions = make(map[string]int)
+		ids = append(ids, entries[i].ID)
+		byID[entries[i].ID] = &entries[i]
+	}
+
+	counts, err := uc.kudosRepo.GetReactionCounts(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		if entry, ok := byID[count.TransactionID]; ok {
+			entry.Reactions[count.Emoji] = count.Count
+		}
+	}
+
+	if entries == nil {
+		entries = []models.KudosEntry{}
+	}
+	return entries, nil
+}
+
+func (uc *kudosUseCase) AddReaction(username, transactionID, emoji string) error {
+	if !isEmoji(emoji) {
+		return errors.New("реакция должна быть эмодзи")
+	}
+
+	user, err := uc.findVisible(username, transactionID)
+	if err != nil {
+		return err
+	}
+
+	return uc.kudosRepo.AddReaction(&models.KudosReaction{
+		TransactionID: transactionID,
+		UserID:        user.ID,
+		Emoji:         emoji,
+	})
+}
+
+func (uc *kudosUseCase) RemoveReaction(username, transactionID, emoji string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.kudosRepo.RemoveReaction(transactionID, user.ID, emoji)
+}
+
+func (uc *kudosUseCase) AddComment(username, transactionID, text string) (*models.KudosComment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxCommentLength {
+		return nil, errors.New("комментарий должен содержать от 1 до 500 символов")
+	}
+
+	user, err := uc.findVisible(username, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.KudosComment{
+		TransactionID: transactionID,
+		UserID:        user.ID,
+		Text:          text,
+	}
+	if err := uc.kudosRepo.AddComment(comment); err != nil {
+		return nil, err
+	}
+	comment.Username = user.Username
+
+	return comment, nil
+}
+
+func (uc *kudosUseCase) GetComments(transactionID string) ([]models.KudosComment, error) {
+	public, err := uc.kudosRepo.IsPublic(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !public {
+		return nil, errors.New("запись не найдена в ленте")
+	}
+
+	comments, err := uc.kudosRepo.GetComments(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if comments == nil {
+		comments = []models.KudosComment{}
+	}
+	return comments, nil
+}
+
+func (uc *kudosUseCase) GetPrivacy(username string) (*models.PrivacySettings, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	return &models.PrivacySettings{
+		PublicKudos:          user.PublicKudos,
+		HideFromLeaderboards: user.HideFromLeaderboards,
+	}, nil
+}
+
+func (uc *kudosUseCase) UpdatePrivacy(username string, settings models.PrivacySettings) error {
+	return uc.userRepo.UpdatePrivacy(username, settings)
+}
+
+func (uc *kudosUseCase) findVisible(username, transactionID string) (*models.User, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	public, err := uc.kudosRepo.IsPublic(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !public {
+		return nil, errors.New("запись не найдена в ленте")
+	}
+
+	return user, nil
+}
+
+func isEmoji(value string) bool {
+	if value == "" || utf8.RuneCountInString(value) > maxEmojiLength {
+		return false
+	}
+	for _, r := range value {
+		if r < 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestGetFeed_AttachesReactions(t *testing.T) {
+	mockKudosRepo := new(mockRepo.MockKudosRepository)
+	uc := NewKudosUseCase(mockKudosRepo, nil)
+
+	mockKudosRepo.On("GetFeed", models.KudosFilter{Team: "engineering", Limit: defaultFeedLimit}).Return([]models.KudosEntry{
+		{ID: "tx-1", Sender: "user1", Recipient: "user2", Amount: 50},
+		{ID: "tx-2", Sender: "user2", Recipient: "user3", Amount: 10},
+	}, nil)
+	mockKudosRepo.On("GetReactionCounts", []string{"tx-1", "tx-2"}).Return([]models.ReactionCount{
+		{TransactionID: "tx-1", Emoji: "🎉", Count: 3},
+	}, nil)
+
+	feed, err := uc.GetFeed(models.KudosFilter{Team: "engineering"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"🎉": 3}, feed[0].Reactions)
+	assert.Empty(t, feed[1].Reactions)
+}
+
+func TestAddReaction_Success(t *testing.T) {
+	mockKudosRepo := new(mockRepo.MockKudosRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewKudosUseCase(mockKudosRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockKudosRepo.On("IsPublic", "tx-1").Return(true, nil)
+	mockKudosRepo.On("AddReaction", mock.MatchedBy(func(reaction *models.KudosReaction) bool {
+		return reaction.UserID == "user-ID-1" && reaction.Emoji == "👏"
+	})).Return(nil)
+
+	err := uc.AddReaction("user1", "tx-1", "👏")
+
+	assert.NoError(t, err)
+	mockKudosRepo.AssertExpectations(t)
+}
+
+func TestAddReaction_NotEmoji(t *testing.T) {
+	uc := NewKudosUseCase(nil, nil)
+
+	err := uc.AddReaction("user1", "tx-1", "lol")
+
+	assert.EqualError(t, err, "реакция должна быть эмодзи")
+}
+
+func TestAddComment_PrivateTransaction(t *testing.T) {
+	mockKudosRepo := new(mockRepo.MockKudosRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewKudosUseCase(mockKudosRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockKudosRepo.On("IsPublic", "tx-1").Return(false, nil)
+
+	_, err := uc.AddComment("user1", "tx-1", "Заслуженно!")
+
+	assert.EqualError(t, err, "запись не найдена в ленте")
+	mockKudosRepo.AssertNotCalled(t, "AddComment", mock.Anything)
+}
+
+func TestAddComment_Success(t *testing.T) {
+	mockKudosRepo := new(mockRepo.MockKudosRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewKudosUseCase(mockKudosRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockKudosRepo.On("IsPublic", "tx-1").Return(true, nil)
+	mockKudosRepo.On("AddComment", mock.MatchedBy(func(comment *models.KudosComment) bool {
+		return comment.Text == "Заслуженно!"
+	})).Return(nil)
+
+	comment, err := uc.AddComment("user1", "tx-1", "  Заслуженно!  ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", comment.Username)
+}
+
+func TestGetPrivacy_DefaultsToPrivate(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewKudosUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+
+	settings, err := uc.GetPrivacy("user1")
+
+	assert.NoError(t, err)
+	assert.False(t, settings.PublicKudos)
+}
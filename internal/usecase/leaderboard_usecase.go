@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const leaderboardSize = 100
+
+type leaderboardUseCase struct {
+	leaderboardRepo LeaderboardRepository
+}
+
+func NewLeaderboardUseCase(leaderboardRepo LeaderboardRepository) LeaderboardUseCase {
+	return &leaderboardUseCase{
+		leaderboardRepo: leaderboardRepo,
+	}
+}
+
+func (uc *leaderboardUseCase) GetLeaderboard(board, period string) (*models.Leaderboard, error) {
+	switch board {
+	case models.LeaderboardGivers, models.LeaderboardReceivers, models.LeaderboardThanked:
+	default:
+		return nil, errors.New("неизвестный рейтинг")
+	}
+	if period == "" {
+		period = models.LeaderboardPeriodWeek
+	}
+	if _, ok := leaderboardPeriods(time.Now())[period]; !ok {
+		return nil, errors.New("неизвестный период рейтинга")
+	}
+
+	entries, err := uc.leaderboardRepo.GetLeaderboard(board, period)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := &models.Leaderboard{
+		Board:   board,
+		Period:  period,
+		Entries: make([]models.LeaderboardRow, 0, len(entries)),
+	}
+	for i, entry := range entries {
+		rank := i + 1
+		if i > 0 && entry.Score == entries[i-1].Score {
+			rank = leaderboard.Entries[i-1].Rank
+		}
+		leaderboard.Entries = append(leaderboard.Entries, models.LeaderboardRow{
+			Rank:     rank,
+			Username: entry.Username,
+			Score:    entry.Score,
+		})
+		if leaderboard.RefreshedAt == nil {
+			refreshedAt := entry.RefreshedAt
+			leaderboard.RefreshedAt = &refreshedAt
+		}
+	}
+
+	return leaderboard, nil
+}
+
+func (uc *leaderboardUseCase) RefreshLeaderboards() error {
+	now := time.Now()
+	return uc.leaderboardRepo.RefreshLeaderboards(leaderboardPeriods(now), leaderboardSize, now)
+}
+
+func leaderboardPeriods(now time.Time) map[string]time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+
+	return map[string]time.Time{
+		models.LeaderboardPeriodWeek:  today.AddDate(0, 0, -daysSinceMonday),
+		models.LeaderboardPeriodMonth: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		models.LeaderboardPeriodAll:   time.Time{},
+	}
+}
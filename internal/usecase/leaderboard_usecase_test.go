@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestGetLeaderboard_SharedRanks(t *testing.T) {
+	mockLeaderboardRepo := new(mockRepo.MockLeaderboardRepository)
+	uc := NewLeaderboardUseCase(mockLeaderboardRepo)
+
+	refreshedAt := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	mockLeaderboardRepo.On("GetLeaderboard", models.LeaderboardGivers, models.LeaderboardPeriodWeek).Return([]models.LeaderboardEntry{
+		{Username: "user1", Score: 300, RefreshedAt: refreshedAt},
+		{Username: "user2", Score: 300, RefreshedAt: refreshedAt},
+		{Username: "user3", Score: 100, RefreshedAt: refreshedAt},
+	}, nil)
+
+	leaderboard, err := uc.GetLeaderboard(models.LeaderboardGivers, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.LeaderboardPeriodWeek, leaderboard.Period)
+	assert.Equal(t, refreshedAt, *leaderboard.RefreshedAt)
+	assert.Equal(t, []models.LeaderboardRow{
+		{Rank: 1, Username: "user1", Score: 300},
+		{Rank: 1, Username: "user2", Score: 300},
+		{Rank: 3, Username: "user3", Score: 100},
+	}, leaderboard.Entries)
+}
+
+func TestGetLeaderboard_UnknownBoard(t *testing.T) {
+	uc := NewLeaderboardUseCase(nil)
+
+	_, err := uc.GetLeaderboard("richest", models.LeaderboardPeriodAll)
+
+	assert.EqualError(t, err, "неизвестный рейтинг")
+}
+
+func TestGetLeaderboard_UnknownPeriod(t *testing.T) {
+	uc := NewLeaderboardUseCase(nil)
+
+	_, err := uc.GetLeaderboard(models.LeaderboardThanked, "decade")
+
+	assert.EqualError(t, err, "неизвестный период рейтинга")
+}
+
+func TestLeaderboardPeriods_CalendarBoundaries(t *testing.T) {
+	periods := leaderboardPeriods(time.Date(2025, 6, 5, 15, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), periods[models.LeaderboardPeriodWeek])
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), periods[models.LeaderboardPeriodMonth])
+	assert.True(t, periods[models.LeaderboardPeriodAll].IsZero())
+}
+
+func TestRefreshLeaderboards(t *testing.T) {
+	mockLeaderboardRepo := new(mockRepo.MockLeaderboardRepository)
+	uc := NewLeaderboardUseCase(mockLeaderboardRepo)
+
+	mockLeaderboardRepo.On("RefreshLeaderboards", mock.Anything, leaderboardSize, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.RefreshLeaderboards())
+	mockLeaderboardRepo.AssertExpectations(t)
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	loginBaseDelay     = time.Second
+	loginMaxDelay      = time.Minute
+	loginFreeAttempts  = 3
+	ipFreeAttempts     = 10
+	loginFailureWindow = time.Hour
+	maxBackoffExponent = 16
+)
+
+type throttlePolicy struct {
+	freeAttempts    int
+	lockoutAfter    int
+	lockoutDuration time.Duration
+}
+
+type loginThrottle struct {
+	attemptRepo LoginAttemptRepository
+	user        throttlePolicy
+	ip          throttlePolicy
+	window      time.Duration
+}
+
+func NewLoginThrottle(attemptRepo LoginAttemptRepository, maxFailures, maxIPFailures int, lockout time.Duration) LoginThrottle {
+	window := loginFailureWindow
+	if lockout > window {
+		window = lockout
+	}
+	return &loginThrottle{
+		attemptRepo: attemptRepo,
+		user:        throttlePolicy{freeAttempts: loginFreeAttempts, lockoutAfter: maxFailures, lockoutDuration: lockout},
+		ip:          throttlePolicy{freeAttempts: ipFreeAttempts, lockoutAfter: maxIPFailures, lockoutDuration: lockout},
+		window:      window,
+	}
+}
+
+func usernameThrottleKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (t *loginThrottle) Check(username, ip string) error {
+	keys := []string{usernameThrottleKey(username)}
+	if ip != "" {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+
+	until, err := t.attemptRepo.GetBlockedUntil(keys)
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		if wait := time.Until(*until); wait > 0 {
+			return &models.LoginThrottledError{RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+func (t *loginThrottle) RecordFailure(username, ip string) {
+	now := time.Now()
+	t.record(usernameThrottleKey(username), t.user, now)
+	if ip != "" {
+		t.record(ipThrottleKey(ip), t.ip, now)
+	}
+}
+
+func (t *loginThrottle) record(key string, policy throttlePolicy, now time.Time) {
+	failures, err := t.attemptRepo.RecordLoginFailure(key, now, now.Add(-t.window))
+	if err != nil {
+		log.Printf("Не удалось учесть неудачный вход %s: %v", key, err)
+		return
+	}
+
+	if delay := policy.delay(failures); delay > 0 {
+		if err := t.attemptRepo.BlockLogin(key, now.Add(delay)); err != nil {
+			log.Printf("Не удалось ограничить вход %s: %v", key, err)
+		}
+	}
+}
+
+func (p throttlePolicy) delay(failures int) time.Duration {
+	if p.lockoutAfter > 0 && failures >= p.lockoutAfter {
+		return p.lockoutDuration
+	}
+	if failures <= p.freeAttempts {
+		return 0
+	}
+
+	exponent := failures - p.freeAttempts - 1
+	if exponent > maxBackoffExponent {
+		exponent = maxBackoffExponent
+	}
+	delay := loginBaseDelay << uint(exponent)
+	if delay > loginMaxDelay {
+		delay = loginMaxDelay
+	}
+	return delay
+}
+
+func (t *loginThrottle) RecordSuccess(username string) {
+	if err := t.attemptRepo.ResetLoginAttempts(usernameThrottleKey(username)); err != nil {
+		log.Printf("Не удалось сбросить счётчик входа %s: %v", username, err)
+	}
+}
+
+func (t *loginThrottle) Unlock(username, ip string) error {
+	keys := []string{usernameThrottleKey(username)}
+	if ip != "" {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+	return t.attemptRepo.ResetLoginAttempts(keys...)
+}
+
+func (t *loginThrottle) PurgeStale() error {
+	return t.attemptRepo.DeleteStaleLoginAttempts(time.Now().Add(-t.window))
+}
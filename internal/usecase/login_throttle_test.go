@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"avito-shop-test/internal/hasher"
+	"avito-shop-test/internal/models"
+	"avito-shop-test/internal/repository"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestLoginThrottle_FreeAttemptsAreNotDelayed(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 10, 100, 15*time.Minute)
+
+	for i := 0; i < loginFreeAttempts; i++ {
+		throttle.RecordFailure("user1", "10.0.0.1")
+	}
+
+	assert.NoError(t, throttle.Check("user1", "10.0.0.1"))
+}
+
+func TestLoginThrottle_BackoffGrowsExponentially(t *testing.T) {
+	policy := throttlePolicy{freeAttempts: 3, lockoutAfter: 10, lockoutDuration: 15 * time.Minute}
+
+	assert.Equal(t, time.Duration(0), policy.delay(3))
+	assert.Equal(t, time.Second, policy.delay(4))
+	assert.Equal(t, 2*time.Second, policy.delay(5))
+	assert.Equal(t, 4*time.Second, policy.delay(6))
+	assert.Equal(t, 15*time.Minute, policy.delay(10))
+
+	unlimited := throttlePolicy{freeAttempts: 3}
+	assert.Equal(t, loginMaxDelay, unlimited.delay(50))
+}
+
+func TestLoginThrottle_DelaysAfterFreeAttempts(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 10, 100, 15*time.Minute)
+
+	for i := 0; i <= loginFreeAttempts; i++ {
+		throttle.RecordFailure("User1", "10.0.0.1")
+	}
+
+	err := throttle.Check("user1", "10.0.0.2")
+	var throttled *models.LoginThrottledError
+	require.True(t, errors.As(err, &throttled))
+	assert.True(t, throttled.RetryAfter > 0 && throttled.RetryAfter <= time.Second)
+}
+
+func TestLoginThrottle_LocksOutAfterMaxFailures(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 5, 100, 15*time.Minute)
+
+	for i := 0; i < 5; i++ {
+		throttle.RecordFailure("user1", "")
+	}
+
+	err := throttle.Check("user1", "")
+	var throttled *models.LoginThrottledError
+	require.True(t, errors.As(err, &throttled))
+	assert.True(t, throttled.RetryAfter > 14*time.Minute)
+}
+
+func TestLoginThrottle_BlocksByIPAcrossUsernames(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 100, 20, 15*time.Minute)
+
+	for i := 0; i < 20; i++ {
+		throttle.RecordFailure("user"+string(rune('a'+i)), "10.0.0.1")
+	}
+
+	assert.Error(t, throttle.Check("victim", "10.0.0.1"))
+	assert.NoError(t, throttle.Check("victim", "10.0.0.2"))
+}
+
+func TestLoginThrottle_SuccessResetsOnlyUsername(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 100, 20, 15*time.Minute)
+
+	for i := 0; i < 20; i++ {
+		throttle.RecordFailure("user1", "10.0.0.1")
+	}
+	throttle.RecordSuccess("user1")
+
+	assert.NoError(t, throttle.Check("user1", ""))
+	assert.Error(t, throttle.Check("user1", "10.0.0.1"))
+}
+
+func TestLoginThrottle_Unlock(t *testing.T) {
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 5, 5, 15*time.Minute)
+
+	for i := 0; i < 5; i++ {
+		throttle.RecordFailure("user1", "10.0.0.1")
+	}
+	require.Error(t, throttle.Check("user1", "10.0.0.1"))
+
+	require.NoError(t, throttle.Unlock("user1", "10.0.0.1"))
+
+	assert.NoError(t, throttle.Check("user1", "10.0.0.1"))
+}
+
+func TestLogin_ThrottledAfterRepeatedFailures(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	hash, _ := passwordHasher.Hash("password")
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), nil, passwordHasher, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-ID", Username: "testuser", Password: hash}, nil)
+
+	for i := 0; i <= loginFreeAttempts; i++ {
+		_, err := uc.Login("testuser", "wrongpassword", testClient)
+		require.EqualError(t, err, "неавторизован")
+	}
+
+	_, err := uc.Login("testuser", "password", testClient)
+
+	var throttled *models.LoginThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	mockUserRepo.AssertNumberOfCalls(t, "FindUserByUsername", loginFreeAttempts+1)
+}
+
+func TestUnlockLogin_Success(t *testing.T) {
+	mockAdminRepo := new(mockRepo.MockAdminRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 5, 100, 15*time.Minute)
+	uc := NewAdminUseCase(mockAdminRepo, mockUserRepo, throttle, nil, nil)
+
+	for i := 0; i < 5; i++ {
+		throttle.RecordFailure("user1", "10.0.0.1")
+	}
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockAdminRepo.On("LogAction", mock.MatchedBy(func(record *models.AuditRecord) bool {
+		return record.ActorID == "boss-ID" && record.Action == "user.unlock" && record.EntityID == "user-ID-1"
+	})).Return(nil)
+
+	err := uc.UnlockLogin("boss", "user1", models.UnlockLoginRequest{})
+
+	assert.NoError(t, err)
+	assert.NoError(t, throttle.Check("user1", "10.0.0.1"))
+	mockAdminRepo.AssertExpectations(t)
+}
+
+func TestUnlockLogin_InvalidIP(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+
+	err := uc.UnlockLogin("boss", "user1", models.UnlockLoginRequest{IP: "not-an-ip"})
+
+	assert.EqualError(t, err, "некорректный IP-адрес")
+}
+
+func TestUnlockLogin_RequiresUsersManage(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "helper").Return(&models.User{ID: "helper-ID", Role: models.RoleStaff}, nil)
+
+	err := uc.UnlockLogin("helper", "user1", models.UnlockLoginRequest{})
+
+	assert.EqualError(t, err, "недостаточно прав")
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	defaultListingDays = 7
+	maxListingDays     = 30
+	defaultSearchLimit = 50
+	maxSearchLimit     = 100
+)
+
+type marketUseCase struct {
+	marketRepo MarketRepository
+	userRepo   UserRepository
+}
+
+func NewMarketUseCase(marketRepo MarketRepository, userRepo UserRepository) MarketUseCase {
+	return &marketUseCase{
+		marketRepo: marketRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *marketUseCase) CreateListing(username string, req models.CreateListingRequest) (*models.Listing, error) {
+	if req.Price <= 0 {
+		return nil, errors.New("цена должна быть положительной")
+	}
+	if req.Days == 0 {
+		req.Days = defaultListingDays
+	}
+	if req.Days < 0 || req.Days > maxListingDays {
+		return nil, errors.New("объявление можно разместить на срок от 1 до 30 дней")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	listing := &models.Listing{
+		SellerID:    user.ID,
+		InventoryID: req.InventoryID,
+		Price:       req.Price,
+		ExpiresAt:   time.Now().AddDate(0, 0, req.Days),
+	}
+	if err := uc.marketRepo.CreateListing(listing); err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+func (uc *marketUseCase) SearchListings(filter models.ListingFilter) ([]models.ListingInfo, error) {
+	if filter.MinPrice < 0 || filter.MaxPrice < 0 || (filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice) {
+		return nil, errors.New("неверный диапазон цен")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	listings, err := uc.marketRepo.SearchListings(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if listings == nil {
+		listings = []models.ListingInfo{}
+	}
+	return listings, nil
+}
+
+func (uc *marketUseCase) GetUserListings(username string) ([]models.Listing, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	return uc.marketRepo.GetUserListings(user.ID)
+}
+
+func (uc *marketUseCase) CancelListing(username, listingID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.marketRepo.CancelListing(listingID, user.ID)
+}
+
+func (uc *marketUseCase) BuyListing(username, listingID string) (*models.Listing, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	listing, err := uc.marketRepo.GetListing(listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing == nil {
+		return nil, errors.New("объявление не найдено")
+	}
+	if listing.SellerID == user.ID {
+		return nil, errors.New("нельзя купить собственный товар")
+	}
+	if user.Balance < listing.Price {
+		return nil, errors.New("недостаточно монет для покупки")
+	}
+
+	return uc.marketRepo.BuyListing(listingID, user.ID, time.Now())
+}
+
+func (uc *marketUseCase) ExpireListings() error {
+	expired, err := uc.marketRepo.ExpireListings(time.Now())
+	if expired > 0 {
+		log.Printf("Снято просроченных объявлений: %d", expired)
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestCreateListing_Success(t *testing.T) {
+	mockMarketRepo := new(mockRepo.MockMarketRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewMarketUseCase(mockMarketRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "seller").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockMarketRepo.On("CreateListing", mock.MatchedBy(func(listing *models.Listing) bool {
+		return listing.SellerID == "user-ID-1" && listing.InventoryID == "inv-1" && listing.Price == 300
+	})).Return(nil)
+
+	listing, err := uc.CreateListing("seller", models.CreateListingRequest{InventoryID: "inv-1", Price: 300})
+
+	assert.NoError(t, err)
+	assert.False(t, listing.ExpiresAt.IsZero())
+	mockMarketRepo.AssertExpectations(t)
+}
+
+func TestCreateListing_InvalidPrice(t *testing.T) {
+	uc := NewMarketUseCase(nil, nil)
+
+	_, err := uc.CreateListing("seller", models.CreateListingRequest{InventoryID: "inv-1", Price: -5})
+
+	assert.EqualError(t, err, "цена должна быть положительной")
+}
+
+func TestCreateListing_InvalidDuration(t *testing.T) {
+	uc := NewMarketUseCase(nil, nil)
+
+	_, err := uc.CreateListing("seller", models.CreateListingRequest{InventoryID: "inv-1", Price: 100, Days: 45})
+
+	assert.Error(t, err)
+}
+
+func TestSearchListings_DefaultLimit(t *testing.T) {
+	mockMarketRepo := new(mockRepo.MockMarketRepository)
+	uc := NewMarketUseCase(mockMarketRepo, nil)
+
+	mockMarketRepo.On("SearchListings", models.ListingFilter{Query: "cup", Limit: defaultSearchLimit}, mock.Anything).Return(nil, nil)
+
+	listings, err := uc.SearchListings(models.ListingFilter{Query: "cup"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, listings)
+	assert.Empty(t, listings)
+}
+
+func TestSearchListings_InvalidPriceRange(t *testing.T) {
+	uc := NewMarketUseCase(nil, nil)
+
+	_, err := uc.SearchListings(models.ListingFilter{MinPrice: 500, MaxPrice: 100})
+
+	assert.EqualError(t, err, "неверный диапазон цен")
+}
+
+func TestBuyListing_Success(t *testing.T) {
+	mockMarketRepo := new(mockRepo.MockMarketRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewMarketUseCase(mockMarketRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "buyer").Return(&models.User{ID: "user-ID-2", Balance: 1000}, nil)
+	mockMarketRepo.On("GetListing", "listing-1").Return(&models.Listing{ID: "listing-1", SellerID: "user-ID-1", Price: 300}, nil)
+	mockMarketRepo.On("BuyListing", "listing-1", "user-ID-2", mock.Anything).
+		Return(&models.Listing{ID: "listing-1", Status: models.ListingStatusSold}, nil)
+
+	listing, err := uc.BuyListing("buyer", "listing-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ListingStatusSold, listing.Status)
+}
+
+func TestBuyListing_OwnListing(t *testing.T) {
+	mockMarketRepo := new(mockRepo.MockMarketRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewMarketUseCase(mockMarketRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "seller").Return(&models.User{ID: "user-ID-1", Balance: 1000}, nil)
+	mockMarketRepo.On("GetListing", "listing-1").Return(&models.Listing{ID: "listing-1", SellerID: "user-ID-1", Price: 300}, nil)
+
+	_, err := uc.BuyListing("seller", "listing-1")
+
+	assert.EqualError(t, err, "нельзя купить собственный товар")
+	mockMarketRepo.AssertNotCalled(t, "BuyListing", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBuyListing_InsufficientBalance(t *testing.T) {
+	mockMarketRepo := new(mockRepo.MockMarketRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewMarketUseCase(mockMarketRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "buyer").Return(&models.User{ID: "user-ID-2", Balance: 100}, nil)
+	mockMarketRepo.On("GetListing", "listing-1").Return(&models.Listing{ID: "listing-1", SellerID: "user-ID-1", Price: 300}, nil)
+
+	_, err := uc.BuyListing("buyer", "listing-1")
+
+	assert.EqualError(t, err, "недостаточно монет для покупки")
+}
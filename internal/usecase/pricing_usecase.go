@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type pricingUseCase struct {
+	pricingRepo PricingRepository
+	storeRepo   StoreRepository
+	variantRepo VariantRepository
+	userRepo    UserRepository
+	staff       staffSet
+}
+
+func NewPricingUseCase(pricingRepo PricingRepository, storeRepo StoreRepository, variantRepo VariantRepository, userRepo UserRepository, staff []string) PricingUseCase {
+	return &pricingUseCase{
+		pricingRepo: pricingRepo,
+		storeRepo:   storeRepo,
+		variantRepo: variantRepo,
+		userRepo:    userRepo,
+		staff:       newStaffSet(staff),
+	}
+}
+
+func (uc *pricingUseCase) GetCatalog() ([]models.CatalogItem, error) {
+	items, err := uc.storeRepo.GetItems()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	catalog := make([]models.CatalogItem, 0, len(items))
+	for _, item := range items {
+		price := item.Price
+		scheduled, err := uc.pricingRepo.GetScheduledPrice(item.Name, now)
+		if err != nil {
+			return nil, err
+		}
+		if scheduled != nil {
+			price = scheduled.Price
+		}
+		catalog = append(catalog, models.CatalogItem{Name: item.Name, Price: price, HasVariants: item.HasVariants})
+	}
+	return catalog, nil
+}
+
+func (uc *pricingUseCase) GetPrice(username string, req models.BuyRequest) (*models.PriceQuote, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	product, err := uc.storeRepo.GetItemByName(req.Item)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	var sku *models.SKU
+	if req.SKU != "" {
+		sku, err = uc.variantRepo.GetSKUByCode(req.SKU)
+		if err != nil || sku == nil || sku.ItemName != product.Name {
+			return nil, errors.New("вариант товара не найден")
+		}
+	}
+
+	return effectivePrice(uc.pricingRepo, user, product, sku, req.PromoCode, time.Now())
+}
+
+func (uc *pricingUseCase) GetPriceHistory(itemName string) ([]models.PriceChange, error) {
+	if _, err := uc.storeRepo.GetItemByName(itemName); err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	return uc.pricingRepo.GetPriceHistory(itemName)
+}
+
+func (uc *pricingUseCase) SchedulePrice(username, itemName string, req models.SchedulePriceRequest) (*models.PriceChange, error) {
+	staff, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.storeRepo.GetItemByName(itemName); err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	if req.Price <= 0 {
+		return nil, errors.New("цена должна быть положительной")
+	}
+
+	effectiveAt, err := time.Parse(time.RFC3339, req.EffectiveAt)
+	if err != nil {
+		return nil, errors.New("дата должна быть в формате RFC3339")
+	}
+
+	change := &models.PriceChange{
+		ItemName:    itemName,
+		Price:       req.Price,
+		EffectiveAt: effectiveAt,
+		CreatedBy:   staff.ID,
+	}
+	if err := uc.pricingRepo.SchedulePrice(change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func (uc *pricingUseCase) CreatePromotion(username string, req models.CreatePromotionRequest) (*models.Promotion, error) {
+	staff, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Item != "" {
+		if _, err := uc.storeRepo.GetItemByName(req.Item); err != nil {
+			return nil, errors.New("товар не найден")
+		}
+	}
+
+	if req.PercentOff <= 0 || req.PercentOff > 100 {
+		return nil, errors.New("скидка должна быть от 1 до 100 процентов")
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if err != nil {
+		return nil, errors.New("дата должна быть в формате RFC3339")
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil {
+		return nil, errors.New("дата должна быть в формате RFC3339")
+	}
+	if !endsAt.After(startsAt) {
+		return nil, errors.New("акция должна заканчиваться позже, чем начинается")
+	}
+
+	promotion := &models.Promotion{
+		Name:       req.Name,
+		ItemName:   req.Item,
+		PercentOff: req.PercentOff,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		CreatedBy:  staff.ID,
+	}
+	if err := uc.pricingRepo.CreatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (uc *pricingUseCase) CreatePromoCode(username string, req models.CreatePromoCodeRequest) (*models.PromoCode, error) {
+	staff, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Item != "" {
+		if _, err := uc.storeRepo.GetItemByName(req.Item); err != nil {
+			return nil, errors.New("товар не найден")
+		}
+	}
+
+	if req.PercentOff <= 0 || req.PercentOff > 100 {
+		return nil, errors.New("скидка должна быть от 1 до 100 процентов")
+	}
+
+	code := &models.PromoCode{
+		Code:       req.Code,
+		ItemName:   req.Item,
+		PercentOff: req.PercentOff,
+		MaxUses:    req.MaxUses,
+		CreatedBy:  staff.ID,
+	}
+	if code.MaxUses <= 0 {
+		code.MaxUses = 1
+	}
+
+	if req.Username != "" {
+		owner, err := uc.userRepo.FindUserByUsername(req.Username)
+		if err != nil || owner == nil {
+			return nil, errors.New("пользователь не найден")
+		}
+		code.UserID = &owner.ID
+	}
+
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, errors.New("дата должна быть в формате RFC3339")
+		}
+		code.ExpiresAt = &expiresAt
+	}
+
+	if err := uc.pricingRepo.CreatePromoCode(code); err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+func effectivePrice(pricingRepo PricingRepository, user *models.User, product *models.Product, sku *models.SKU, promoCode string, at time.Time) (*models.PriceQuote, error) {
+	base := product.Price
+
+	scheduled, err := pricingRepo.GetScheduledPrice(product.Name, at)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled != nil {
+		base = scheduled.Price
+	}
+	if sku != nil && sku.Price != nil {
+		base = *sku.Price
+	}
+
+	quote := &models.PriceQuote{Item: product.Name, BasePrice: base, Price: base}
+	if sku != nil {
+		quote.SKU = sku.Code
+	}
+
+	promotions, err := pricingRepo.GetActivePromotions(product.Name, at)
+	if err != nil {
+		return nil, err
+	}
+	var best *models.Promotion
+	for i := range promotions {
+		if best == nil || promotions[i].PercentOff > best.PercentOff {
+			best = &promotions[i]
+		}
+	}
+	if best != nil {
+		quote.Price = applyDiscount(quote.Price, best.PercentOff)
+		quote.Discounts = append(quote.Discounts, fmt.Sprintf("%s: -%d%%", best.Name, best.PercentOff))
+	}
+
+	if promoCode == "" {
+		return quote, nil
+	}
+
+	code, err := pricingRepo.GetPromoCode(promoCode)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil {
+		return nil, errors.New("промокод не найден")
+	}
+	if code.ExpiresAt != nil && !at.Before(*code.ExpiresAt) {
+		return nil, errors.New("срок действия промокода истёк")
+	}
+	if code.ItemName != "" && code.ItemName != product.Name {
+		return nil, errors.New("промокод не действует на этот товар")
+	}
+	if code.UserID != nil && *code.UserID != user.ID {
+		return nil, errors.New("промокод выдан другому сотруднику")
+	}
+	if code.Uses >= code.MaxUses {
+		return nil, errors.New("промокод уже использован")
+	}
+
+	quote.Price = applyDiscount(quote.Price, code.PercentOff)
+	quote.Discounts = append(quote.Discounts, fmt.Sprintf("%s: -%d%%", code.Code, code.PercentOff))
+
+	return quote, nil
+}
+
+func applyDiscount(price, percentOff int) int {
+	return price * (100 - percentOff) / 100
+}
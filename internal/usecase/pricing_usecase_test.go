@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func newBasePricingRepo() *mockRepo.MockPricingRepository {
+	pricingRepo := new(mockRepo.MockPricingRepository)
+	pricingRepo.On("GetScheduledPrice", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	pricingRepo.On("GetActivePromotions", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return pricingRepo
+}
+
+func TestEffectivePrice_ScheduledPriceAndPromotion(t *testing.T) {
+	mockPricingRepo := new(mockRepo.MockPricingRepository)
+	at := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	product := &models.Product{Name: "umbrella", Price: 200}
+
+	mockPricingRepo.On("GetScheduledPrice", "umbrella", at).Return(&models.PriceChange{Price: 250}, nil)
+	mockPricingRepo.On("GetActivePromotions", "umbrella", at).Return([]models.Promotion{
+		{Name: "spring", PercentOff: 10},
+		{Name: "rainy week", PercentOff: 20},
+	}, nil)
+
+	quote, err := effectivePrice(mockPricingRepo, &models.User{ID: "user-ID-1"}, product, nil, "", at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 250, quote.BasePrice)
+	assert.Equal(t, 200, quote.Price)
+	assert.Equal(t, []string{"rainy week: -20%"}, quote.Discounts)
+}
+
+func TestEffectivePrice_SKUOverrideWins(t *testing.T) {
+	mockPricingRepo := newBasePricingRepo()
+	price := 90
+	product := &models.Product{Name: "t-shirt", Price: 80}
+	sku := &models.SKU{Code: "t-shirt-l-black", Price: &price}
+
+	quote, err := effectivePrice(mockPricingRepo, &models.User{ID: "user-ID-1"}, product, sku, "", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 90, quote.Price)
+	assert.Equal(t, "t-shirt-l-black", quote.SKU)
+}
+
+func TestEffectivePrice_PromoCode(t *testing.T) {
+	mockPricingRepo := newBasePricingRepo()
+	product := &models.Product{Name: "book", Price: 50}
+
+	mockPricingRepo.On("GetPromoCode", "BOOK50").Return(&models.PromoCode{Code: "BOOK50", ItemName: "book", PercentOff: 50, MaxUses: 1}, nil)
+
+	quote, err := effectivePrice(mockPricingRepo, &models.User{ID: "user-ID-1"}, product, nil, "BOOK50", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25, quote.Price)
+}
+
+func TestEffectivePrice_PromoCodeRejected(t *testing.T) {
+	owner := "user-ID-2"
+	expired := time.Now().Add(-time.Hour)
+	product := &models.Product{Name: "book", Price: 50}
+
+	cases := map[string]*models.PromoCode{
+		"промокод уже использован":            {Code: "X", PercentOff: 10, MaxUses: 1, Uses: 1},
+		"срок действия промокода истёк":       {Code: "X", PercentOff: 10, MaxUses: 1, ExpiresAt: &expired},
+		"промокод не действует на этот товар": {Code: "X", PercentOff: 10, MaxUses: 1, ItemName: "cup"},
+		"промокод выдан другому сотруднику":   {Code: "X", PercentOff: 10, MaxUses: 1, UserID: &owner},
+	}
+
+	for message, code := range cases {
+		mockPricingRepo := newBasePricingRepo()
+		mockPricingRepo.On("GetPromoCode", "X").Return(code, nil)
+
+		_, err := effectivePrice(mockPricingRepo, &models.User{ID: "user-ID-1"}, product, nil, "X", time.Now())
+
+		assert.EqualError(t, err, message)
+	}
+}
+
+func TestBuy_RecordsDiscountedPriceAndRedeemsCode(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockPricingRepo := newBasePricingRepo()
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, nil, mockPricingRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 100}, nil)
+	mockStoreRepo.On("GetItemByName", "book").Return(&models.Product{Name: "book", Price: 50}, nil)
+	mockPricingRepo.On("GetPromoCode", "BOOK20").Return(&models.PromoCode{Code: "BOOK20", PercentOff: 20, MaxUses: 1}, nil)
+	mockPurchaseRepo.On("RecordPurchase", &models.PurchaseOrder{
+		PayerID:   "user-ID-1",
+		Amount:    40,
+		PromoCode: "BOOK20",
+		Inventory: &models.Inventory{
+			UserID:   "user-ID-1",
+			ItemType: "book",
+			Quantity: 1,
+			Price:    intPtr(40),
+		},
+	}).Return(nil)
+
+	err := uc.Buy("user1", models.BuyRequest{Item: "book", PromoCode: "BOOK20"})
+
+	assert.NoError(t, err)
+	mockPricingRepo.AssertExpectations(t)
+	mockPurchaseRepo.AssertExpectations(t)
+}
+
+func TestCreatePromoCode_DefaultsToSingleUse(t *testing.T) {
+	mockPricingRepo := new(mockRepo.MockPricingRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewPricingUseCase(mockPricingRepo, nil, nil, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockPricingRepo.On("CreatePromoCode", mock.MatchedBy(func(code *models.PromoCode) bool {
+		return code.Code == "WELCOME" && code.MaxUses == 1 && code.CreatedBy == "admin-ID"
+	})).Return(nil)
+
+	_, err := uc.CreatePromoCode("admin", models.CreatePromoCodeRequest{Code: "WELCOME", PercentOff: 15})
+
+	assert.NoError(t, err)
+	mockPricingRepo.AssertExpectations(t)
+}
+
+func TestCreatePromotion_InvalidPeriod(t *testing.T) {
+	mockPricingRepo := new(mockRepo.MockPricingRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewPricingUseCase(mockPricingRepo, nil, nil, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+
+	_, err := uc.CreatePromotion("admin", models.CreatePromotionRequest{
+		Name:       "sale",
+		PercentOff: 20,
+		StartsAt:   "2025-05-08T00:00:00Z",
+		EndsAt:     "2025-05-01T00:00:00Z",
+	})
+
+	assert.Error(t, err)
+	mockPricingRepo.AssertNotCalled(t, "CreatePromotion", mock.Anything)
+}
+
+func TestGetCatalog_UsesScheduledPrice(t *testing.T) {
+	mockPricingRepo := new(mockRepo.MockPricingRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewPricingUseCase(mockPricingRepo, mockStoreRepo, nil, nil, nil)
+
+	mockStoreRepo.On("GetItems").Return([]models.Product{
+		{Name: "hoody", Price: 300, HasVariants: true},
+		{Name: "umbrella", Price: 200},
+	}, nil)
+	mockPricingRepo.On("GetScheduledPrice", "hoody", mock.Anything).Return(nil, nil)
+	mockPricingRepo.On("GetScheduledPrice", "umbrella", mock.Anything).Return(&models.PriceChange{Price: 250}, nil)
+
+	catalog, err := uc.GetCatalog()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CatalogItem{
+		{Name: "hoody", Price: 300, HasVariants: true},
+		{Name: "umbrella", Price: 250},
+	}, catalog)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"time"
 
 	"avito-shop-test/internal/models"
 )
@@ -10,44 +11,139 @@ type purchaseUseCase struct {
 	purchaseRepo PurchaseRepository
 	userRepo     UserRepository
 	storeRepo    StoreRepository
+	variantRepo  VariantRepository
+	pricingRepo  PricingRepository
+	listeners    []ActivityListener
 }
 
-func NewPurchaseUseCase(purchaseRepo PurchaseRepository, userRepo UserRepository, storeRepo StoreRepository) PurchaseUseCase {
+func NewPurchaseUseCase(purchaseRepo PurchaseRepository, userRepo UserRepository, storeRepo StoreRepository, variantRepo VariantRepository, pricingRepo PricingRepository, listeners ...ActivityListener) PurchaseUseCase {
 	return &purchaseUseCase{
 		purchaseRepo: purchaseRepo,
 		userRepo:     userRepo,
 		storeRepo:    storeRepo,
+		variantRepo:  variantRepo,
+		pricingRepo:  pricingRepo,
+		listeners:    listeners,
 	}
 }
 
 func (uc *purchaseUseCase) BuyItem(username string, itemName string) error {
+	return uc.Buy(username, models.BuyRequest{Item: itemName})
+}
+
+func (uc *purchaseUseCase) Buy(username string, req models.BuyRequest) error {
 	user, err := uc.userRepo.FindUserByUsername(username)
 	if err != nil || user == nil {
 		return errors.New("пользователь не найден")
 	}
 
-	product, err := uc.storeRepo.GetItemByName(itemName)
+	now := time.Now()
+	quote, err := quotePurchase(uc.storeRepo, uc.variantRepo, uc.pricingRepo, user, req, now)
 	if err != nil {
-		return errors.New("товар не найден")
+		return err
 	}
 
-	if user.Balance < product.Price {
-		return errors.New("недостаточно монет для покупки")
+	if err := checkEligibility(uc.purchaseRepo, user, quote.product, now); err != nil {
+		return err
 	}
 
-	inventory := &models.Inventory{
-		UserID:   user.ID,
-		ItemType: product.Name,
-		Quantity: 1,
+	if user.Balance < quote.price {
+		return errors.New("недостаточно монет для покупки")
 	}
 
-	if err := uc.userRepo.UpdateUserBalance(username, -product.Price); err != nil {
+	order := &models.PurchaseOrder{
+		PayerID:   user.ID,
+		Amount:    quote.price,
+		PromoCode: quote.promoCode,
+		Inventory: quote.inventoryFor(user.ID),
+	}
+	if err := uc.purchaseRepo.RecordPurchase(order); err != nil {
 		return err
 	}
 
-	if err := uc.purchaseRepo.RecordPurchase(inventory); err != nil {
-		return err
+	for _, listener := range uc.listeners {
+		listener.OnActivity(user.ID)
 	}
 
 	return nil
 }
+
+func (uc *purchaseUseCase) GetPurchaseLines(username string) ([]models.PurchaseLine, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	purchases, err := uc.purchaseRepo.GetPurchasedItems(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.PurchaseLine, 0, len(purchases))
+	for _, purchase := range purchases {
+		var price int
+		if purchase.Price != nil {
+			price = *purchase.Price
+		}
+		lines = append(lines, models.PurchaseLine{
+			ID:          purchase.ID,
+			ItemName:    purchase.ItemType,
+			SKU:         purchase.SKU,
+			Variant:     purchase.Variant,
+			Quantity:    purchase.Quantity,
+			Price:       price,
+			PurchasedAt: purchase.CreatedAt,
+		})
+	}
+
+	return lines, nil
+}
+
+type purchaseQuote struct {
+	product   *models.Product
+	sku       *models.SKU
+	promoCode string
+	price     int
+}
+
+func quotePurchase(storeRepo StoreRepository, variantRepo VariantRepository, pricingRepo PricingRepository, user *models.User, req models.BuyRequest, at time.Time) (*purchaseQuote, error) {
+	product, err := storeRepo.GetItemByName(req.Item)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	quote := &purchaseQuote{product: product}
+	if product.HasVariants || req.SKU != "" {
+		if req.SKU == "" {
+			return nil, errors.New("выберите вариант товара")
+		}
+		sku, err := variantRepo.GetSKUByCode(req.SKU)
+		if err != nil || sku == nil || sku.ItemName != product.Name {
+			return nil, errors.New("вариант товара не найден")
+		}
+		quote.sku = sku
+	}
+
+	price, err := effectivePrice(pricingRepo, user, product, quote.sku, req.PromoCode, at)
+	if err != nil {
+		return nil, err
+	}
+	quote.price = price.Price
+	quote.promoCode = req.PromoCode
+
+	return quote, nil
+}
+
+func (q *purchaseQuote) inventoryFor(userID string) *models.Inventory {
+	inventory := &models.Inventory{
+		UserID:   userID,
+		ItemType: q.product.Name,
+		Quantity: 1,
+		Price:    &q.price,
+	}
+	if q.sku != nil {
+		inventory.SKU = q.sku.Code
+		inventory.Variant = q.sku.Description()
+	}
+	return inventory
+}
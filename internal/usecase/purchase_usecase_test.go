@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"avito-shop-test/internal/models"
 	mockRepo "avito-shop-test/internal/repository/mock"
@@ -14,20 +15,25 @@ func TestBuyItem_Success(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	user := &models.User{ID: "user1", Balance: 100}
 	product := &models.Product{Name: "item1", Price: 50}
 	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
 	mockStoreRepo.On("GetItemByName", "item1").Return(product, nil)
 
-	inventory := &models.Inventory{
-		UserID:   user.ID,
-		ItemType: "item1",
-		Quantity: 1,
+	order := &models.PurchaseOrder{
+		PayerID: user.ID,
+		Amount:  50,
+		Inventory: &models.Inventory{
+			UserID:   user.ID,
+			ItemType: "item1",
+			Quantity: 1,
+			Price:    intPtr(50),
+		},
 	}
-	mockUserRepo.On("UpdateUserBalance", "user1", -50).Return(nil)
-	mockPurchaseRepo.On("RecordPurchase", inventory).Return(nil)
+	mockPurchaseRepo.On("RecordPurchase", order).Return(nil)
 
 	err := uc.BuyItem("user1", "item1")
 
@@ -41,7 +47,8 @@ func TestBuyItem_UserNotFound(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	mockUserRepo.On("FindUserByUsername", "user1").Return(nil, nil)
 
@@ -56,7 +63,8 @@ func TestBuyItem_ProductNotFound(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	user := &models.User{ID: "user1", Balance: 100}
 	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
@@ -73,7 +81,8 @@ func TestBuyItem_InsufficientBalance(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	user := &models.User{ID: "user1", Balance: 30}
 	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
@@ -91,11 +100,12 @@ func TestBuyItem_UpdateBalanceError(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	user := &models.User{ID: "user1", Balance: 100}
 	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
-	mockUserRepo.On("UpdateUserBalance", "user1", -50).Return(errors.New("update balance error"))
+	mockPurchaseRepo.On("RecordPurchase", mock.Anything).Return(errors.New("недостаточно монет для покупки"))
 
 	product := &models.Product{Name: "item1", Price: 50}
 	mockStoreRepo.On("GetItemByName", "item1").Return(product, nil)
@@ -103,29 +113,35 @@ func TestBuyItem_UpdateBalanceError(t *testing.T) {
 	err := uc.BuyItem("user1", "item1")
 
 	assert.Error(t, err)
-	assert.Equal(t, "update balance error", err.Error())
+	assert.Equal(t, "недостаточно монет для покупки", err.Error())
 	mockUserRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserBalance", mock.Anything, mock.Anything)
 }
 
 func TestBuyItem_RecordPurchaseError(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockStoreRepo := new(mockRepo.MockStoreRepository)
-	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
 
 	user := &models.User{ID: "user1", Balance: 100}
 	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
-	mockUserRepo.On("UpdateUserBalance", "user1", -50).Return(nil)
 
 	product := &models.Product{Name: "item1", Price: 50}
 	mockStoreRepo.On("GetItemByName", "item1").Return(product, nil)
 
-	inventory := &models.Inventory{
-		UserID:   user.ID,
-		ItemType: "item1",
-		Quantity: 1,
+	order := &models.PurchaseOrder{
+		PayerID: user.ID,
+		Amount:  50,
+		Inventory: &models.Inventory{
+			UserID:   user.ID,
+			ItemType: "item1",
+			Quantity: 1,
+			Price:    intPtr(50),
+		},
 	}
-	mockPurchaseRepo.On("RecordPurchase", inventory).Return(errors.New("record purchase error"))
+	mockPurchaseRepo.On("RecordPurchase", order).Return(errors.New("record purchase error"))
 
 	err := uc.BuyItem("user1", "item1")
 
@@ -133,3 +149,102 @@ func TestBuyItem_RecordPurchaseError(t *testing.T) {
 	assert.Equal(t, "record purchase error", err.Error())
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestBuyItem_VariantSuccess(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
+
+	user := &models.User{ID: "user1", Balance: 100}
+	product := &models.Product{Name: "t-shirt", Price: 80, HasVariants: true}
+	price := 90
+	sku := &models.SKU{
+		Code:     "t-shirt-m-black",
+		ItemName: "t-shirt",
+		Stock:    5,
+		Price:    &price,
+		Options:  []models.SKUOption{{Name: "size", Value: "M"}, {Name: "color", Value: "black"}},
+	}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockStoreRepo.On("GetItemByName", "t-shirt").Return(product, nil)
+	mockVariantRepo.On("GetSKUByCode", "t-shirt-m-black").Return(sku, nil)
+	mockPurchaseRepo.On("RecordPurchase", &models.PurchaseOrder{
+		PayerID: user.ID,
+		Amount:  90,
+		Inventory: &models.Inventory{
+			UserID:   user.ID,
+			ItemType: "t-shirt",
+			Quantity: 1,
+			SKU:      "t-shirt-m-black",
+			Variant:  "color: black, size: M",
+			Price:    intPtr(90),
+		},
+	}).Return(nil)
+
+	err := uc.Buy("user1", models.BuyRequest{Item: "t-shirt", SKU: "t-shirt-m-black"})
+
+	assert.NoError(t, err)
+	mockVariantRepo.AssertExpectations(t)
+	mockPurchaseRepo.AssertExpectations(t)
+}
+
+func TestBuyItem_VariantSKURequired(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user1", Balance: 100}, nil)
+	mockStoreRepo.On("GetItemByName", "t-shirt").Return(&models.Product{Name: "t-shirt", Price: 80, HasVariants: true}, nil)
+
+	err := uc.BuyItem("user1", "t-shirt")
+
+	assert.Error(t, err)
+	assert.Equal(t, "выберите вариант товара", err.Error())
+}
+
+func TestBuyItem_VariantOutOfStock(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user1", Balance: 500}, nil)
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300, HasVariants: true}, nil)
+	mockVariantRepo.On("GetSKUByCode", "hoody-s").Return(&models.SKU{Code: "hoody-s", ItemName: "hoody"}, nil)
+	mockPurchaseRepo.On("RecordPurchase", mock.Anything).Return(errors.New("товара нет в наличии"))
+
+	err := uc.Buy("user1", models.BuyRequest{Item: "hoody", SKU: "hoody-s"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "товара нет в наличии", err.Error())
+	mockUserRepo.AssertNotCalled(t, "UpdateUserBalance", "user1", -300)
+}
+
+func TestBuyItem_EligibilityLimitReached(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewPurchaseUseCase(mockPurchaseRepo, mockUserRepo, mockStoreRepo, mockVariantRepo, newBasePricingRepo())
+
+	limit := 1
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user1", Balance: 1000}, nil)
+	mockStoreRepo.On("GetItemByName", "pink-hoody").Return(&models.Product{
+		Name:  "pink-hoody",
+		Price: 500,
+		Rule:  &models.ItemRule{ItemName: "pink-hoody", MaxPerUser: &limit},
+	}, nil)
+	mockPurchaseRepo.On("CountPurchases", "user1", "pink-hoody", mock.Anything).Return(1, nil)
+
+	err := uc.BuyItem("user1", "pink-hoody")
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityLimitPerUser, eligibilityErr.Code)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserBalance", "user1", -500)
+}
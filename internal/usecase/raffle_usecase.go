@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const maxTicketsPerPurchase = 100
+
+type raffleUseCase struct {
+	raffleRepo  RaffleRepository
+	userRepo    UserRepository
+	storeRepo   StoreRepository
+	variantRepo VariantRepository
+	staff       staffSet
+	listeners   []ActivityListener
+}
+
+func NewRaffleUseCase(raffleRepo RaffleRepository, userRepo UserRepository, storeRepo StoreRepository, variantRepo VariantRepository, staff []string, listeners ...ActivityListener) RaffleUseCase {
+	return &raffleUseCase{
+		raffleRepo:  raffleRepo,
+		userRepo:    userRepo,
+		storeRepo:   storeRepo,
+		variantRepo: variantRepo,
+		staff:       newStaffSet(staff),
+		listeners:   listeners,
+	}
+}
+
+func (uc *raffleUseCase) CreateRaffle(username string, req models.CreateRaffleRequest) (*models.RaffleInfo, error) {
+	admin, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Winners == 0 {
+		req.Winners = 1
+	}
+	if req.TicketPrice <= 0 || req.Winners < 0 || req.MaxTickets < 0 || req.MaxTicketsPerUser < 0 {
+		return nil, errors.New("неверные параметры розыгрыша")
+	}
+	if req.MaxTickets > 0 && req.MaxTickets < req.Winners {
+		return nil, errors.New("билетов должно быть не меньше, чем призов")
+	}
+	if !req.DrawAt.After(time.Now()) {
+		return nil, errors.New("дата розыгрыша должна быть в будущем")
+	}
+
+	product, err := uc.storeRepo.GetItemByName(req.Item)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	seed, err := newRaffleSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	raffle := &models.Raffle{
+		Title:             req.Title,
+		ItemName:          product.Name,
+		TicketPrice:       req.TicketPrice,
+		MaxTickets:        req.MaxTickets,
+		MaxTicketsPerUser: req.MaxTicketsPerUser,
+		Winners:           req.Winners,
+		DrawAt:            req.DrawAt,
+		Seed:              seed,
+		SeedHash:          seedCommitment(seed),
+		CreatedBy:         admin.ID,
+	}
+
+	if product.HasVariants && req.SKU == "" {
+		return nil, errors.New("выберите вариант товара")
+	}
+	if req.SKU != "" {
+		sku, err := uc.variantRepo.GetSKUByCode(req.SKU)
+		if err != nil || sku == nil || sku.ItemName != product.Name {
+			return nil, errors.New("вариант товара не найден")
+		}
+		raffle.SKU = sku.Code
+		raffle.Variant = sku.Description()
+	}
+
+	if err := uc.raffleRepo.CreateRaffle(raffle); err != nil {
+		return nil, err
+	}
+
+	return toRaffleInfo(raffle), nil
+}
+
+func (uc *raffleUseCase) GetRaffles() ([]models.RaffleInfo, error) {
+	raffles, err := uc.raffleRepo.GetRaffles()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]models.RaffleInfo, 0, len(raffles))
+	for i := range raffles {
+		infos = append(infos, *toRaffleInfo(&raffles[i]))
+	}
+	return infos, nil
+}
+
+func (uc *raffleUseCase) GetRaffle(id string) (*models.RaffleInfo, error) {
+	raffle, err := uc.raffleRepo.GetRaffle(id)
+	if err != nil {
+		return nil, err
+	}
+	if raffle == nil {
+		return nil, errors.New("розыгрыш не найден")
+	}
+
+	info := toRaffleInfo(raffle)
+	if raffle.Status != models.RaffleStatusDrawn {
+		return info, nil
+	}
+
+	tickets, err := uc.raffleRepo.GetWinningTickets(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+	won := make(map[int]string, len(tickets))
+	for _, ticket := range tickets {
+		won[ticket.Number] = ticket.UserID
+	}
+
+	for place, number := range drawTickets(raffle.Seed, raffle.ID, raffle.TicketsSold, raffle.Winners) {
+		result := models.RaffleResult{Place: place + 1, TicketNumber: number}
+		if winner, _ := uc.userRepo.GetUserByUserID(won[number]); winner != nil {
+			result.Username = winner.Username
+		}
+		info.Results = append(info.Results, result)
+	}
+
+	return info, nil
+}
+
+func (uc *raffleUseCase) BuyTickets(username, raffleID string, count int) ([]int, error) {
+	if count <= 0 || count > maxTicketsPerPurchase {
+		return nil, errors.New("за раз можно купить от 1 до 100 билетов")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	tickets, err := uc.raffleRepo.BuyTickets(raffleID, user.ID, count, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, 0, len(tickets))
+	for _, ticket := range tickets {
+		numbers = append(numbers, ticket.Number)
+	}
+	return numbers, nil
+}
+
+func (uc *raffleUseCase) CancelRaffle(username, raffleID string) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	return uc.raffleRepo.CancelRaffle(raffleID)
+}
+
+func (uc *raffleUseCase) DrawRaffles() error {
+	raffles, err := uc.raffleRepo.GetDueRaffles(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var drawErr error
+	for _, raffle := range raffles {
+		numbers := drawTickets(raffle.Seed, raffle.ID, raffle.TicketsSold, raffle.Winners)
+		if err := uc.raffleRepo.CompleteDraw(raffle.ID, raffle.TicketsSold, numbers); err != nil {
+			drawErr = err
+			continue
+		}
+		log.Printf("Проведён розыгрыш %s: выигрышные билеты %v", raffle.ID, numbers)
+
+		if err := uc.notifyWinners(raffle.ID); err != nil {
+			drawErr = err
+		}
+	}
+	return drawErr
+}
+
+func (uc *raffleUseCase) notifyWinners(raffleID string) error {
+	if len(uc.listeners) == 0 {
+		return nil
+	}
+
+	tickets, err := uc.raffleRepo.GetWinningTickets(raffleID)
+	if err != nil {
+		return err
+	}
+	for _, ticket := range tickets {
+		for _, listener := range uc.listeners {
+			listener.OnActivity(ticket.UserID)
+		}
+	}
+	return nil
+}
+
+func drawTickets(seed, raffleID string, tickets, winners int) []int {
+	if winners > tickets {
+		winners = tickets
+	}
+
+	pool := make([]int, tickets)
+	for i := range pool {
+		pool[i] = i + 1
+	}
+
+	for i := 0; i < winners; i++ {
+		digest := sha256.Sum256([]byte(seed + ":" + raffleID + ":" + strconv.Itoa(i)))
+		j := i + int(binary.BigEndian.Uint64(digest[:8])%uint64(tickets-i))
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+
+	return pool[:winners]
+}
+
+func newRaffleSeed() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func seedCommitment(seed string) string {
+	digest := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(digest[:])
+}
+
+func toRaffleInfo(raffle *models.Raffle) *models.RaffleInfo {
+	info := &models.RaffleInfo{
+		ID:                raffle.ID,
+		Title:             raffle.Title,
+		ItemName:          raffle.ItemName,
+		SKU:               raffle.SKU,
+		Variant:           raffle.Variant,
+		TicketPrice:       raffle.TicketPrice,
+		MaxTickets:        raffle.MaxTickets,
+		MaxTicketsPerUser: raffle.MaxTicketsPerUser,
+		Winners:           raffle.Winners,
+		TicketsSold:       raffle.TicketsSold,
+		DrawAt:            raffle.DrawAt,
+		Status:            raffle.Status,
+		SeedHash:          raffle.SeedHash,
+		DrawnAt:           raffle.DrawnAt,
+	}
+	if raffle.Status == models.RaffleStatusDrawn {
+		info.Seed = raffle.Seed
+	}
+	return info
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestCreateRaffle_PublishesSeedHash(t *testing.T) {
+	mockRaffleRepo := new(mockRepo.MockRaffleRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewRaffleUseCase(mockRaffleRepo, mockUserRepo, mockStoreRepo, nil, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "powerbank").Return(&models.Product{Name: "powerbank", Price: 200}, nil)
+	mockRaffleRepo.On("CreateRaffle", mock.MatchedBy(func(raffle *models.Raffle) bool {
+		return raffle.Winners == 1 && raffle.SeedHash == seedCommitment(raffle.Seed)
+	})).Return(nil)
+
+	raffle, err := uc.CreateRaffle("admin", models.CreateRaffleRequest{
+		Title:       "Летний розыгрыш",
+		Item:        "powerbank",
+		TicketPrice: 10,
+		DrawAt:      time.Now().Add(time.Hour),
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, raffle.SeedHash, 64)
+	assert.Empty(t, raffle.Seed)
+	mockRaffleRepo.AssertExpectations(t)
+}
+
+func TestCreateRaffle_NotStaff(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRaffleUseCase(nil, mockUserRepo, nil, nil, []string{"admin-ID"})
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Role: models.RoleEmployee}, nil)
+
+	_, err := uc.CreateRaffle("user1", models.CreateRaffleRequest{Item: "powerbank"})
+
+	assert.EqualError(t, err, "недостаточно прав")
+}
+
+func TestBuyTickets_Success(t *testing.T) {
+	mockRaffleRepo := new(mockRepo.MockRaffleRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRaffleUseCase(mockRaffleRepo, mockUserRepo, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockRaffleRepo.On("BuyTickets", "raffle-1", "user-ID-1", 2, mock.Anything).
+		Return([]models.RaffleTicket{{Number: 4}, {Number: 5}}, nil)
+
+	numbers, err := uc.BuyTickets("user1", "raffle-1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, numbers)
+}
+
+func TestBuyTickets_InvalidCount(t *testing.T) {
+	uc := NewRaffleUseCase(nil, nil, nil, nil, nil)
+
+	_, err := uc.BuyTickets("user1", "raffle-1", 0)
+
+	assert.Error(t, err)
+}
+
+func TestDrawTickets_DeterministicAndDistinct(t *testing.T) {
+	first := drawTickets("seed", "raffle-1", 10, 3)
+	second := drawTickets("seed", "raffle-1", 10, 3)
+
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 3)
+
+	seen := make(map[int]bool)
+	for _, number := range first {
+		assert.True(t, number >= 1 && number <= 10)
+		assert.False(t, seen[number])
+		seen[number] = true
+	}
+
+	assert.Len(t, drawTickets("seed", "raffle-1", 2, 5), 2)
+	assert.Empty(t, drawTickets("seed", "raffle-1", 0, 1))
+}
+
+func TestDrawRaffles_CompletesDueRaffles(t *testing.T) {
+	mockRaffleRepo := new(mockRepo.MockRaffleRepository)
+	uc := NewRaffleUseCase(mockRaffleRepo, nil, nil, nil, nil)
+
+	due := models.Raffle{ID: "raffle-1", Seed: "seed", TicketsSold: 10, Winners: 2}
+	mockRaffleRepo.On("GetDueRaffles", mock.Anything).Return([]models.Raffle{due}, nil)
+	mockRaffleRepo.On("CompleteDraw", "raffle-1", 10, drawTickets("seed", "raffle-1", 10, 2)).Return(nil)
+
+	err := uc.DrawRaffles()
+
+	assert.NoError(t, err)
+	mockRaffleRepo.AssertExpectations(t)
+}
+
+func TestDrawRaffles_NotifiesWinners(t *testing.T) {
+	mockRaffleRepo := new(mockRepo.MockRaffleRepository)
+	listener := &recordingListener{}
+	uc := NewRaffleUseCase(mockRaffleRepo, nil, nil, nil, nil, listener)
+
+	due := models.Raffle{ID: "raffle-1", Seed: "seed", TicketsSold: 10, Winners: 2}
+	mockRaffleRepo.On("GetDueRaffles", mock.Anything).Return([]models.Raffle{due}, nil)
+	mockRaffleRepo.On("CompleteDraw", "raffle-1", 10, mock.Anything).Return(nil)
+	mockRaffleRepo.On("GetWinningTickets", "raffle-1").Return([]models.RaffleTicket{{UserID: "user-ID-1"}, {UserID: "user-ID-3"}}, nil)
+
+	err := uc.DrawRaffles()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-ID-1", "user-ID-3"}, listener.userIDs)
+}
+
+func TestGetRaffle_RevealsSeedAfterDraw(t *testing.T) {
+	mockRaffleRepo := new(mockRepo.MockRaffleRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRaffleUseCase(mockRaffleRepo, mockUserRepo, nil, nil, nil)
+
+	numbers := drawTickets("seed", "raffle-1", 5, 1)
+	mockRaffleRepo.On("GetRaffle", "raffle-1").Return(&models.Raffle{
+		ID: "raffle-1", Seed: "seed", SeedHash: seedCommitment("seed"),
+		TicketsSold: 5, Winners: 1, Status: models.RaffleStatusDrawn,
+	}, nil)
+	mockRaffleRepo.On("GetWinningTickets", "raffle-1").Return([]models.RaffleTicket{{Number: numbers[0], UserID: "user-ID-1"}}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(&models.User{Username: "user1"}, nil)
+
+	raffle, err := uc.GetRaffle("raffle-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "seed", raffle.Seed)
+	assert.Equal(t, []models.RaffleResult{{Place: 1, TicketNumber: numbers[0], Username: "user1"}}, raffle.Results)
+}
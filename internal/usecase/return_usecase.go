@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type returnUseCase struct {
+	returnRepo   ReturnRepository
+	purchaseRepo PurchaseRepository
+	userRepo     UserRepository
+	storeRepo    StoreRepository
+	window       time.Duration
+	staff        staffSet
+}
+
+func NewReturnUseCase(returnRepo ReturnRepository, purchaseRepo PurchaseRepository, userRepo UserRepository, storeRepo StoreRepository, window time.Duration, staff []string) ReturnUseCase {
+	return &returnUseCase{
+		returnRepo:   returnRepo,
+		purchaseRepo: purchaseRepo,
+		userRepo:     userRepo,
+		storeRepo:    storeRepo,
+		window:       window,
+		staff:        newStaffSet(staff),
+	}
+}
+
+func (uc *returnUseCase) RequestReturn(username, inventoryID, reason string) (*models.ReturnRequest, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	purchase, err := uc.purchaseRepo.GetPurchaseByID(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil || purchase.UserID != user.ID {
+		return nil, errors.New("покупка не найдена")
+	}
+	if purchase.NoReturn {
+		return nil, errors.New("этот товар нельзя вернуть")
+	}
+
+	if time.Since(purchase.CreatedAt) > uc.window {
+		return nil, errors.New("срок возврата истёк")
+	}
+
+	var price int
+	if purchase.Price != nil {
+		price = *purchase.Price
+	} else {
+		product, err := uc.storeRepo.GetItemByName(purchase.ItemType)
+		if err != nil {
+			return nil, errors.New("товар не найден")
+		}
+		price = product.Price
+	}
+
+	ret := &models.ReturnRequest{
+		InventoryID: purchase.ID,
+		UserID:      user.ID,
+		ItemType:    purchase.ItemType,
+		Quantity:    purchase.Quantity,
+		Amount:      price * purchase.Quantity,
+		Reason:      reason,
+		Status:      models.ReturnStatusPending,
+	}
+
+	if err := uc.returnRepo.CreateReturn(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (uc *returnUseCase) GetUserReturns(username string) ([]models.ReturnRequest, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	return uc.returnRepo.GetUserReturns(user.ID)
+}
+
+func (uc *returnUseCase) GetPendingReturns(username string) ([]models.ReturnRequest, error) {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return nil, err
+	}
+
+	return uc.returnRepo.GetPendingReturns()
+}
+
+func (uc *returnUseCase) ApproveReturn(username, returnID, comment string) error {
+	reviewer, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return err
+	}
+
+	return uc.returnRepo.ApproveReturn(returnID, reviewer.ID, comment)
+}
+
+func (uc *returnUseCase) RejectReturn(username, returnID, comment string) error {
+	reviewer, err := uc.staff.find(uc.userRepo, username)
+	if err != nil {
+		return err
+	}
+
+	return uc.returnRepo.RejectReturn(returnID, reviewer.ID, comment)
+}
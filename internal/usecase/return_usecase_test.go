@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestRequestReturn_Success(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, mockStoreRepo, 24*time.Hour, nil)
+
+	user := &models.User{ID: "user-ID-1", Username: "user1"}
+	purchase := &models.Inventory{ID: "inv-1", UserID: user.ID, ItemType: "hoody", Quantity: 1, CreatedAt: time.Now()}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(purchase, nil)
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300}, nil)
+	mockReturnRepo.On("CreateReturn", mock.MatchedBy(func(ret *models.ReturnRequest) bool {
+		return ret.InventoryID == "inv-1" && ret.Amount == 300 && ret.Status == models.ReturnStatusPending
+	})).Return(nil)
+
+	ret, err := uc.RequestReturn("user1", "inv-1", "wrong size")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "wrong size", ret.Reason)
+	mockReturnRepo.AssertExpectations(t)
+}
+
+func TestRequestReturn_RecordedZeroPrice(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, mockStoreRepo, 24*time.Hour, nil)
+
+	user := &models.User{ID: "user-ID-1", Username: "user1"}
+	purchase := &models.Inventory{ID: "inv-1", UserID: user.ID, ItemType: "hoody", Quantity: 1, Price: intPtr(0), CreatedAt: time.Now()}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(purchase, nil)
+	mockReturnRepo.On("CreateReturn", mock.MatchedBy(func(ret *models.ReturnRequest) bool {
+		return ret.InventoryID == "inv-1" && ret.Amount == 0
+	})).Return(nil)
+
+	ret, err := uc.RequestReturn("user1", "inv-1", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ret.Amount)
+	mockStoreRepo.AssertNotCalled(t, "GetItemByName", mock.Anything)
+}
+
+func TestRequestReturn_NotReturnable(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, nil, 24*time.Hour, nil)
+
+	user := &models.User{ID: "user-ID-1", Username: "user1"}
+	purchase := &models.Inventory{ID: "inv-1", UserID: user.ID, ItemType: "hoody", Quantity: 1, Price: intPtr(500), NoReturn: true, CreatedAt: time.Now()}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(purchase, nil)
+
+	ret, err := uc.RequestReturn("user1", "inv-1", "")
+
+	assert.Nil(t, ret)
+	assert.EqualError(t, err, "этот товар нельзя вернуть")
+	mockReturnRepo.AssertNotCalled(t, "CreateReturn", mock.Anything)
+}
+
+func TestRequestReturn_WindowExpired(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, mockStoreRepo, 24*time.Hour, nil)
+
+	user := &models.User{ID: "user-ID-1", Username: "user1"}
+	purchase := &models.Inventory{ID: "inv-1", UserID: user.ID, ItemType: "hoody", Quantity: 1, CreatedAt: time.Now().Add(-48 * time.Hour)}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(purchase, nil)
+
+	ret, err := uc.RequestReturn("user1", "inv-1", "")
+
+	assert.Nil(t, ret)
+	assert.Equal(t, "срок возврата истёк", err.Error())
+	mockReturnRepo.AssertNotCalled(t, "CreateReturn", mock.Anything)
+}
+
+func TestRequestReturn_ForeignPurchase(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewReturnUseCase(mockReturnRepo, mockPurchaseRepo, mockUserRepo, mockStoreRepo, 24*time.Hour, nil)
+
+	user := &models.User{ID: "user-ID-1", Username: "user1"}
+	purchase := &models.Inventory{ID: "inv-1", UserID: "user-ID-2", ItemType: "hoody", Quantity: 1, CreatedAt: time.Now()}
+	mockUserRepo.On("FindUserByUsername", "user1").Return(user, nil)
+	mockPurchaseRepo.On("GetPurchaseByID", "inv-1").Return(purchase, nil)
+
+	_, err := uc.RequestReturn("user1", "inv-1", "")
+
+	assert.Equal(t, "покупка не найдена", err.Error())
+}
+
+func TestApproveReturn_Success(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewReturnUseCase(mockReturnRepo, nil, mockUserRepo, nil, 24*time.Hour, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockReturnRepo.On("ApproveReturn", "ret-1", "admin-ID", "ok").Return(nil)
+
+	err := uc.ApproveReturn("admin", "ret-1", "ok")
+
+	assert.NoError(t, err)
+	mockReturnRepo.AssertExpectations(t)
+}
+
+func TestApproveReturn_NotStaff(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewReturnUseCase(mockReturnRepo, nil, mockUserRepo, nil, 24*time.Hour, []string{"admin-ID"})
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Role: models.RoleEmployee}, nil)
+
+	err := uc.ApproveReturn("user1", "ret-1", "")
+
+	assert.Equal(t, "недостаточно прав", err.Error())
+	mockReturnRepo.AssertNotCalled(t, "ApproveReturn", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApproveReturn_ConfiguredNameWithoutAccountAtStartup(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewReturnUseCase(mockReturnRepo, nil, mockUserRepo, nil, 24*time.Hour, nil)
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "squatter-ID", Username: "admin"}, nil)
+
+	err := uc.ApproveReturn("admin", "ret-1", "")
+
+	assert.Equal(t, "недостаточно прав", err.Error())
+	mockReturnRepo.AssertNotCalled(t, "ApproveReturn", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRejectReturn_RepositoryError(t *testing.T) {
+	mockReturnRepo := new(mockRepo.MockReturnRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewReturnUseCase(mockReturnRepo, nil, mockUserRepo, nil, 24*time.Hour, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockReturnRepo.On("RejectReturn", "ret-1", "admin-ID", "").Return(errors.New("заявка на возврат уже рассмотрена"))
+
+	err := uc.RejectReturn("admin", "ret-1", "")
+
+	assert.Equal(t, "заявка на возврат уже рассмотрена", err.Error())
+}
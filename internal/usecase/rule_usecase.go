@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+type ruleUseCase struct {
+	ruleRepo  RuleRepository
+	storeRepo StoreRepository
+	userRepo  UserRepository
+	staff     staffSet
+}
+
+func NewRuleUseCase(ruleRepo RuleRepository, storeRepo StoreRepository, userRepo UserRepository, staff []string) RuleUseCase {
+	return &ruleUseCase{
+		ruleRepo:  ruleRepo,
+		storeRepo: storeRepo,
+		userRepo:  userRepo,
+		staff:     newStaffSet(staff),
+	}
+}
+
+func (uc *ruleUseCase) GetRule(itemName string) (*models.ItemRule, error) {
+	rule, err := uc.ruleRepo.GetRule(itemName)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return &models.ItemRule{ItemName: itemName}, nil
+	}
+	return rule, nil
+}
+
+func (uc *ruleUseCase) SaveRule(username, itemName string, rule models.ItemRule) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	if _, err := uc.storeRepo.GetItemByName(itemName); err != nil {
+		return errors.New("товар не найден")
+	}
+
+	if (rule.MaxPerPeriod == nil) != (rule.PeriodDays == nil) {
+		return errors.New("лимит на период задаётся вместе с длительностью периода")
+	}
+	for _, value := range []*int{rule.MaxPerUser, rule.MaxPerPeriod, rule.PeriodDays, rule.MinTenureMonths} {
+		if value != nil && *value < 0 {
+			return errors.New("значения правил не могут быть отрицательными")
+		}
+	}
+
+	rule.ItemName = itemName
+	return uc.ruleRepo.SaveRule(&rule)
+}
+
+func (uc *ruleUseCase) UpdateUserAttributes(username, targetUsername string, req models.UpdateUserAttributesRequest) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	var hiredAt *time.Time
+	if req.HiredAt != "" {
+		parsed, err := time.Parse("2006-01-02", req.HiredAt)
+		if err != nil {
+			return errors.New("дата найма должна быть в формате ГГГГ-ММ-ДД")
+		}
+		hiredAt = &parsed
+	}
+
+	return uc.userRepo.UpdateUserAttributes(targetUsername, req.Department, hiredAt)
+}
+
+func checkEligibility(purchaseRepo PurchaseRepository, user *models.User, product *models.Product, now time.Time) error {
+	rule := product.Rule
+	if rule == nil {
+		return nil
+	}
+
+	if departments := rule.Departments(); len(departments) > 0 {
+		allowed := false
+		for _, department := range departments {
+			if department == user.Department {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return &models.EligibilityError{
+				Code:    models.EligibilityDepartment,
+				Message: "товар недоступен для вашего отдела",
+			}
+		}
+	}
+
+	if rule.MinTenureMonths != nil {
+		since := user.CreatedAt
+		if user.HiredAt != nil {
+			since = *user.HiredAt
+		}
+		if since.AddDate(0, *rule.MinTenureMonths, 0).After(now) {
+			return &models.EligibilityError{
+				Code:    models.EligibilityTenure,
+				Message: fmt.Sprintf("товар доступен после %d мес. работы в компании", *rule.MinTenureMonths),
+			}
+		}
+	}
+
+	count := func(since time.Time) (int, error) {
+		return purchaseRepo.CountPurchases(user.ID, product.Name, since)
+	}
+	return rule.CheckLimits(count, now)
+}
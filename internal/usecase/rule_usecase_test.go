@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestCheckEligibility_LimitPerUser(t *testing.T) {
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	user := &models.User{ID: "user-ID-1"}
+	product := &models.Product{Name: "pink-hoody", Rule: &models.ItemRule{MaxPerUser: intPtr(1)}}
+
+	mockPurchaseRepo.On("CountPurchases", "user-ID-1", "pink-hoody", time.Time{}).Return(1, nil)
+
+	err := checkEligibility(mockPurchaseRepo, user, product, time.Now())
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityLimitPerUser, eligibilityErr.Code)
+}
+
+func TestCheckEligibility_LimitPerPeriod(t *testing.T) {
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	user := &models.User{ID: "user-ID-1"}
+	product := &models.Product{Name: "cup", Rule: &models.ItemRule{MaxPerPeriod: intPtr(2), PeriodDays: intPtr(30)}}
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	mockPurchaseRepo.On("CountPurchases", "user-ID-1", "cup", now.AddDate(0, 0, -30)).Return(2, nil)
+
+	err := checkEligibility(mockPurchaseRepo, user, product, now)
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityLimitPerPeriod, eligibilityErr.Code)
+}
+
+func TestCheckEligibility_Department(t *testing.T) {
+	user := &models.User{ID: "user-ID-1", Department: "sales"}
+	product := &models.Product{Name: "powerbank", Rule: &models.ItemRule{AllowedDepartments: "engineering, design"}}
+
+	err := checkEligibility(nil, user, product, time.Now())
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityDepartment, eligibilityErr.Code)
+
+	user.Department = "design"
+	assert.NoError(t, checkEligibility(nil, user, product, time.Now()))
+}
+
+func TestCheckEligibility_Tenure(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	hired := now.AddDate(0, -2, 0)
+	user := &models.User{ID: "user-ID-1", HiredAt: &hired}
+	product := &models.Product{Name: "wallet", Rule: &models.ItemRule{MinTenureMonths: intPtr(3)}}
+
+	err := checkEligibility(nil, user, product, now)
+
+	var eligibilityErr *models.EligibilityError
+	assert.True(t, errors.As(err, &eligibilityErr))
+	assert.Equal(t, models.EligibilityTenure, eligibilityErr.Code)
+
+	hired = now.AddDate(0, -3, 0)
+	assert.NoError(t, checkEligibility(nil, user, product, now))
+}
+
+func TestSaveRule_PeriodRequiresDays(t *testing.T) {
+	mockRuleRepo := new(mockRepo.MockRuleRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRuleUseCase(mockRuleRepo, mockStoreRepo, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "cup").Return(&models.Product{Name: "cup"}, nil)
+
+	err := uc.SaveRule("admin", "cup", models.ItemRule{MaxPerPeriod: intPtr(1)})
+
+	assert.Error(t, err)
+	mockRuleRepo.AssertNotCalled(t, "SaveRule", mock.Anything)
+}
+
+func TestSaveRule_Success(t *testing.T) {
+	mockRuleRepo := new(mockRepo.MockRuleRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRuleUseCase(mockRuleRepo, mockStoreRepo, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "pink-hoody").Return(&models.Product{Name: "pink-hoody"}, nil)
+	mockRuleRepo.On("SaveRule", &models.ItemRule{ItemName: "pink-hoody", MaxPerUser: intPtr(1)}).Return(nil)
+
+	err := uc.SaveRule("admin", "pink-hoody", models.ItemRule{MaxPerUser: intPtr(1)})
+
+	assert.NoError(t, err)
+	mockRuleRepo.AssertExpectations(t)
+}
+
+func TestUpdateUserAttributes_InvalidDate(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewRuleUseCase(nil, nil, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+
+	err := uc.UpdateUserAttributes("admin", "user1", models.UpdateUserAttributesRequest{HiredAt: "01.02.2024"})
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserAttributes", mock.Anything, mock.Anything, mock.Anything)
+}
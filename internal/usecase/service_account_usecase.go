@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	apiKeyIDLength        = 12
+	maxAPIKeyLifetimeDays = 365
+	maxRotationGrace      = 7 * 24 * time.Hour
+)
+
+var errInvalidAPIKey = errors.New("недействительный API-ключ")
+
+type serviceAccountUseCase struct {
+	accountRepo ServiceAccountRepository
+	userRepo    UserRepository
+}
+
+func NewServiceAccountUseCase(accountRepo ServiceAccountRepository, userRepo UserRepository) ServiceAccountUseCase {
+	return &serviceAccountUseCase{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (uc *serviceAccountUseCase) CreateServiceAccount(username string, req models.CreateServiceAccountRequest) (*models.ServiceAccount, error) {
+	actor, err := requirePermission(uc.userRepo, username, models.PermUsersManage)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if !usernamePattern.MatchString(name) {
+		return nil, errors.New("имя сервисного аккаунта должно состоять из 3–32 латинских букв, цифр или символов . _ -")
+	}
+
+	account := &models.User{
+		Username:             name,
+		Role:                 models.RoleService,
+		ServiceAccount:       true,
+		HideFromLeaderboards: true,
+	}
+	if err := uc.accountRepo.CreateServiceAccount(actor.ID, account); err != nil {
+		return nil, err
+	}
+
+	return &models.ServiceAccount{Name: account.Username, CreatedAt: account.CreatedAt, Keys: []models.APIKeyInfo{}}, nil
+}
+
+func (uc *serviceAccountUseCase) GetServiceAccounts(username string) ([]models.ServiceAccount, error) {
+	if _, err := requirePermission(uc.userRepo, username, models.PermUsersManage); err != nil {
+		return nil, err
+	}
+
+	users, err := uc.accountRepo.GetServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	keys, err := uc.accountRepo.GetAPIKeys(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	keysByUser := make(map[string][]models.APIKeyInfo)
+	for i := range keys {
+		keysByUser[keys[i].UserID] = append(keysByUser[keys[i].UserID], apiKeyInfo(&keys[i]))
+	}
+
+	accounts := make([]models.ServiceAccount, 0, len(users))
+	for _, user := range users {
+		account := models.ServiceAccount{Name: user.Username, CreatedAt: user.CreatedAt, Keys: keysByUser[user.ID]}
+		if account.Keys == nil {
+			account.Keys = []models.APIKeyInfo{}
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (uc *serviceAccountUseCase) CreateAPIKey(username, accountName string, req models.CreateAPIKeyRequest) (*models.APIKeyResponse, error) {
+	actor, err := requirePermission(uc.userRepo, username, models.PermUsersManage)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.userRepo.FindUserByUsername(accountName)
+	if err != nil || account == nil || !account.ServiceAccount {
+		return nil, errors.New("сервисный аккаунт не найден")
+	}
+
+	scopes, err := normalizeScopes(actor, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > maxAPIKeyLifetimeDays {
+		return nil, fmt.Errorf("срок действия ключа не может превышать %d дней", maxAPIKeyLifetimeDays)
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		expires := now.AddDate(0, 0, req.ExpiresInDays)
+		expiresAt = &expires
+	}
+
+	key, secret, err := newAPIKey(actor.ID, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	key.UserID = account.ID
+	key.Scopes = strings.Join(scopes, " ")
+
+	if err := uc.accountRepo.CreateAPIKey(actor.ID, key); err != nil {
+		return nil, err
+	}
+
+	return &models.APIKeyResponse{APIKeyInfo: apiKeyInfo(key), Key: secret}, nil
+}
+
+func (uc *serviceAccountUseCase) RotateAPIKey(username, keyID string, req models.RotateAPIKeyRequest) (*models.APIKeyResponse, error) {
+	actor, err := requirePermission(uc.userRepo, username, models.PermUsersManage)
+	if err != nil {
+		return nil, err
+	}
+
+	grace := time.Duration(req.GraceMinutes) * time.Minute
+	if grace < 0 || grace > maxRotationGrace {
+		return nil, errors.New("период действия старого ключа — не больше 7 дней")
+	}
+
+	old, err := uc.accountRepo.GetAPIKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if old == nil {
+		return nil, errors.New("ключ не найден")
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if old.ExpiresAt != nil {
+		expires := now.Add(old.ExpiresAt.Sub(old.CreatedAt))
+		expiresAt = &expires
+	}
+
+	key, secret, err := newAPIKey(actor.ID, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.accountRepo.RotateAPIKey(actor.ID, old.ID, key, now.Add(grace)); err != nil {
+		return nil, err
+	}
+
+	return &models.APIKeyResponse{APIKeyInfo: apiKeyInfo(key), Key: secret}, nil
+}
+
+func (uc *serviceAccountUseCase) RevokeAPIKey(username, keyID string) error {
+	actor, err := requirePermission(uc.userRepo, username, models.PermUsersManage)
+	if err != nil {
+		return err
+	}
+
+	return uc.accountRepo.RevokeAPIKey(actor.ID, keyID, time.Now())
+}
+
+func (uc *serviceAccountUseCase) AuthenticateAPIKey(secret string) (*models.APIKeyPrincipal, error) {
+	rest := strings.TrimPrefix(secret, models.APIKeyPrefix)
+	if rest == secret || len(rest) <= apiKeyIDLength || rest[apiKeyIDLength] != '_' {
+		return nil, errInvalidAPIKey
+	}
+
+	key, err := uc.accountRepo.GetAPIKeyByPrefix(rest[:apiKeyIDLength])
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(key.KeyHash)) != 1 {
+		return nil, errInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.Active(now) || key.Account == nil || !key.Account.ServiceAccount {
+		return nil, errInvalidAPIKey
+	}
+
+	if err := uc.accountRepo.TouchAPIKey(key.ID, now); err != nil {
+		log.Printf("Не удалось обновить время использования ключа %s: %v", key.Prefix, err)
+	}
+
+	return &models.APIKeyPrincipal{
+		KeyID:   key.ID,
+		Prefix:  key.Prefix,
+		Account: key.Account.Username,
+		Scopes:  key.ScopeList(),
+	}, nil
+}
+
+func normalizeScopes(actor *models.User, requested []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, scope := range requested {
+		scope = strings.TrimSpace(scope)
+		if seen[scope] {
+			continue
+		}
+		if !models.ValidScope(scope) {
+			return nil, fmt.Errorf("неизвестное право ключа: %q", scope)
+		}
+		if !models.HasPermission(actor.Role, scope) {
+			return nil, fmt.Errorf("недостаточно прав для выдачи %s", scope)
+		}
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("укажите права ключа")
+	}
+
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+func newAPIKey(createdBy string, now time.Time, expiresAt *time.Time) (*models.APIKey, string, error) {
+	id := make([]byte, apiKeyIDLength/2)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	token, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := hex.EncodeToString(id)
+	secret := models.APIKeyPrefix + prefix + "_" + token
+	return &models.APIKey{
+		Prefix:    prefix,
+		KeyHash:   hashToken(secret),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}, secret, nil
+}
+
+func apiKeyInfo(key *models.APIKey) models.APIKeyInfo {
+	return models.APIKeyInfo{
+		ID:         key.ID,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func newAdminUserRepo() *mockRepo.MockUserRepository {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true}, nil)
+	return mockUserRepo
+}
+
+func TestCreateServiceAccount_Success(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	uc := NewServiceAccountUseCase(mockAccountRepo, newAdminUserRepo())
+
+	mockAccountRepo.On("CreateServiceAccount", "boss-ID", mock.MatchedBy(func(account *models.User) bool {
+		return account.Username == "slack-bot" && account.ServiceAccount && account.Role == models.RoleService &&
+			account.Password == "" && account.HideFromLeaderboards
+	})).Return(nil)
+
+	account, err := uc.CreateServiceAccount("boss", models.CreateServiceAccountRequest{Name: " slack-bot "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "slack-bot", account.Name)
+	mockAccountRepo.AssertExpectations(t)
+}
+
+func TestCreateServiceAccount_RequiresUsersManage(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewServiceAccountUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "helper").Return(&models.User{ID: "helper-ID", Role: models.RoleStaff}, nil)
+
+	_, err := uc.CreateServiceAccount("helper", models.CreateServiceAccountRequest{Name: "slack-bot"})
+
+	assert.EqualError(t, err, "недостаточно прав")
+}
+
+func TestCreateAPIKey_StoresOnlyHash(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	mockUserRepo := newAdminUserRepo()
+	uc := NewServiceAccountUseCase(mockAccountRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "slack-bot").Return(&models.User{ID: "bot-ID", Username: "slack-bot", ServiceAccount: true}, nil)
+	var stored *models.APIKey
+	mockAccountRepo.On("CreateAPIKey", "boss-ID", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*models.APIKey)
+	}).Return(nil)
+
+	key, err := uc.CreateAPIKey("boss", "slack-bot", models.CreateAPIKeyRequest{
+		Scopes:        []string{"coins:grant", "catalog:read", "coins:grant"},
+		ExpiresInDays: 30,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key.Key, models.APIKeyPrefix+stored.Prefix+"_"))
+	assert.Equal(t, []string{"catalog:read", "coins:grant"}, key.Scopes)
+	assert.Equal(t, "bot-ID", stored.UserID)
+	assert.Equal(t, "catalog:read coins:grant", stored.Scopes)
+	assert.Equal(t, hashToken(key.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, key.Key)
+	require.NotNil(t, stored.ExpiresAt)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *stored.ExpiresAt, time.Minute)
+}
+
+func TestCreateAPIKey_RejectsUnknownScope(t *testing.T) {
+	mockUserRepo := newAdminUserRepo()
+	uc := NewServiceAccountUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "slack-bot").Return(&models.User{ID: "bot-ID", ServiceAccount: true}, nil)
+
+	_, err := uc.CreateAPIKey("boss", "slack-bot", models.CreateAPIKeyRequest{Scopes: []string{"users:manage"}})
+
+	assert.EqualError(t, err, `неизвестное право ключа: "users:manage"`)
+}
+
+func TestCreateAPIKey_RequiresServiceAccount(t *testing.T) {
+	mockUserRepo := newAdminUserRepo()
+	uc := NewServiceAccountUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Role: models.RoleEmployee}, nil)
+
+	_, err := uc.CreateAPIKey("boss", "user1", models.CreateAPIKeyRequest{Scopes: []string{"catalog:read"}})
+
+	assert.EqualError(t, err, "сервисный аккаунт не найден")
+}
+
+func TestRotateAPIKey_KeepsOldKeyDuringGrace(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	uc := NewServiceAccountUseCase(mockAccountRepo, newAdminUserRepo())
+
+	mockAccountRepo.On("GetAPIKey", "key-ID").Return(&models.APIKey{ID: "key-ID", UserID: "bot-ID", Scopes: "catalog:read"}, nil)
+	mockAccountRepo.On("RotateAPIKey", "boss-ID", "key-ID", mock.Anything, mock.MatchedBy(func(at time.Time) bool {
+		return at.Sub(time.Now()) > 59*time.Minute && at.Sub(time.Now()) <= time.Hour
+	})).Return(nil)
+
+	key, err := uc.RotateAPIKey("boss", "key-ID", models.RotateAPIKeyRequest{GraceMinutes: 60})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key.Key, models.APIKeyPrefix))
+	mockAccountRepo.AssertExpectations(t)
+}
+
+func TestRotateAPIKey_GraceTooLong(t *testing.T) {
+	uc := NewServiceAccountUseCase(nil, newAdminUserRepo())
+
+	_, err := uc.RotateAPIKey("boss", "key-ID", models.RotateAPIKeyRequest{GraceMinutes: 8 * 24 * 60})
+
+	assert.EqualError(t, err, "период действия старого ключа — не больше 7 дней")
+}
+
+func TestAuthenticateAPIKey_Success(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	uc := NewServiceAccountUseCase(mockAccountRepo, nil)
+
+	secret := models.APIKeyPrefix + "0123456789ab_secret"
+	mockAccountRepo.On("GetAPIKeyByPrefix", "0123456789ab").Return(&models.APIKey{
+		ID:      "key-ID",
+		Prefix:  "0123456789ab",
+		KeyHash: hashToken(secret),
+		Scopes:  "catalog:read coins:grant",
+		Account: &models.User{Username: "slack-bot", ServiceAccount: true},
+	}, nil)
+	mockAccountRepo.On("TouchAPIKey", "key-ID", mock.Anything).Return(nil)
+
+	principal, err := uc.AuthenticateAPIKey(secret)
+
+	require.NoError(t, err)
+	assert.Equal(t, "slack-bot", principal.Account)
+	assert.Equal(t, []string{"catalog:read", "coins:grant"}, principal.Scopes)
+}
+
+func TestAuthenticateAPIKey_WrongSecret(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	uc := NewServiceAccountUseCase(mockAccountRepo, nil)
+
+	mockAccountRepo.On("GetAPIKeyByPrefix", "0123456789ab").Return(&models.APIKey{
+		ID:      "key-ID",
+		KeyHash: hashToken(models.APIKeyPrefix + "0123456789ab_secret"),
+		Account: &models.User{Username: "slack-bot", ServiceAccount: true},
+	}, nil)
+
+	_, err := uc.AuthenticateAPIKey(models.APIKeyPrefix + "0123456789ab_guess")
+
+	assert.EqualError(t, err, "недействительный API-ключ")
+	mockAccountRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticateAPIKey_Revoked(t *testing.T) {
+	mockAccountRepo := new(mockRepo.MockServiceAccountRepository)
+	uc := NewServiceAccountUseCase(mockAccountRepo, nil)
+
+	secret := models.APIKeyPrefix + "0123456789ab_secret"
+	revokedAt := time.Now().Add(-time.Second)
+	mockAccountRepo.On("GetAPIKeyByPrefix", "0123456789ab").Return(&models.APIKey{
+		ID:        "key-ID",
+		KeyHash:   hashToken(secret),
+		RevokedAt: &revokedAt,
+		Account:   &models.User{Username: "slack-bot", ServiceAccount: true},
+	}, nil)
+
+	_, err := uc.AuthenticateAPIKey(secret)
+
+	assert.EqualError(t, err, "недействительный API-ключ")
+}
+
+func TestAuthenticateAPIKey_Malformed(t *testing.T) {
+	uc := NewServiceAccountUseCase(nil, nil)
+
+	for _, secret := range []string{"", "jwt.token.value", models.APIKeyPrefix + "short", models.APIKeyPrefix + "0123456789abXsecret"} {
+		_, err := uc.AuthenticateAPIKey(secret)
+		assert.EqualError(t, err, "недействительный API-ключ", secret)
+	}
+}
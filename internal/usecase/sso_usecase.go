@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const (
+	ssoLoginStateTTL     = 10 * time.Minute
+	maxSSOUsernameTries  = 5
+	maxSSOUsernameLength = 28
+)
+
+var usernameUnsafeChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type ssoUseCase struct {
+	provider       OIDCProvider
+	identityRepo   IdentityRepository
+	userRepo       UserRepository
+	sessions       SessionIssuer
+	passwordHasher PasswordHasher
+}
+
+func NewSSOUseCase(provider OIDCProvider, identityRepo IdentityRepository, userRepo UserRepository, sessions SessionIssuer, passwordHasher PasswordHasher) SSOUseCase {
+	return &ssoUseCase{
+		provider:       provider,
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		sessions:       sessions,
+		passwordHasher: passwordHasher,
+	}
+}
+
+func (uc *ssoUseCase) StartLogin() (*models.SSOLoginResponse, error) {
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := uc.provider.AuthCodeURL(state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		log.Printf("Ошибка обращения к провайдеру SSO: %v", err)
+		return nil, errors.New("провайдер SSO недоступен")
+	}
+
+	err = uc.identityRepo.SaveLoginState(&models.SSOLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(ssoLoginStateTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SSOLoginResponse{AuthorizationURL: authURL}, nil
+}
+
+func (uc *ssoUseCase) CompleteLogin(state, code string, client models.ClientInfo) (*models.AuthResponse, error) {
+	if state == "" || code == "" {
+		return nil, errors.New("некорректный ответ провайдера SSO")
+	}
+
+	login, err := uc.identityRepo.ConsumeLoginState(state, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if login == nil {
+		return nil, errors.New("сессия входа истекла, начните вход заново")
+	}
+
+	identity, err := uc.provider.Exchange(code, login.CodeVerifier)
+	if err != nil {
+		log.Printf("Ошибка входа через SSO: %v", err)
+		return nil, errors.New("не удалось подтвердить вход через SSO")
+	}
+	if identity.Nonce != login.Nonce {
+		return nil, errors.New("не удалось подтвердить вход через SSO")
+	}
+
+	user, err := uc.resolveUser(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.sessions.IssueTokens(user, client)
+}
+
+func (uc *ssoUseCase) PurgeExpiredStates() error {
+	return uc.identityRepo.DeleteExpiredLoginStates(time.Now())
+}
+
+func (uc *ssoUseCase) resolveUser(identity *models.ExternalIdentity) (*models.User, error) {
+	user, err := uc.identityRepo.GetUserByIdentity(identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		if identity.EmailVerified && identity.Email != "" {
+			if err := uc.identityRepo.UpdateIdentityEmail(identity.Issuer, identity.Subject, identity.Email); err != nil {
+				log.Printf("Не удалось обновить email пользователя %s: %v", user.Username, err)
+			}
+		}
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errors.New("провайдер SSO не подтвердил email")
+	}
+
+	password, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	base := usernameFromEmail(identity.Email)
+	for attempt := 1; attempt <= maxSSOUsernameTries; attempt++ {
+		username := base
+		if attempt > 1 {
+			username = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		email := identity.Email
+		user := &models.User{Username: username, Password: hash, Balance: welcomeGrant, Role: models.RoleEmployee, Email: &email}
+		createErr := uc.identityRepo.CreateUserWithIdentity(user, &models.UserIdentity{
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+			Email:   identity.Email,
+		})
+		if createErr == nil {
+			return user, nil
+		}
+
+		existing, err := uc.identityRepo.GetUserByIdentity(identity.Issuer, identity.Subject)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+		taken, err := uc.userRepo.FindUserByUsername(username)
+		if err != nil || taken == nil {
+			return nil, createErr
+		}
+	}
+
+	return nil, errors.New("не удалось подобрать имя пользователя")
+}
+
+func usernameFromEmail(email string) string {
+	local := strings.ToLower(email)
+	if at := strings.LastIndex(local, "@"); at >= 0 {
+		local = local[:at]
+	}
+	local = strings.Trim(usernameUnsafeChars.ReplaceAllString(local, "-"), "-")
+
+	if len(local) > maxSSOUsernameLength {
+		local = local[:maxSSOUsernameLength]
+	}
+	if len(local) < 3 {
+		local = "user-" + local
+	}
+	return local
+}
+
+func pkceChallenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
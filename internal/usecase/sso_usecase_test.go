@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"avito-shop-test/internal/hasher"
+	"avito-shop-test/internal/models"
+	"avito-shop-test/internal/oidc"
+	"avito-shop-test/internal/oidc/oidctest"
+	mockRepo "avito-shop-test/internal/repository/mock"
+	mockToken "avito-shop-test/internal/token"
+)
+
+func newSSOTestUseCase(idp *oidctest.Server, identityRepo *mockRepo.MockIdentityRepository, userRepo *mockRepo.MockUserRepository) SSOUseCase {
+	provider := oidc.NewClient(oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://shop.local/api/oidc/callback",
+	}, nil)
+	sessions := NewUserUsecase(userRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, newTestThrottle(), nil)
+	return NewSSOUseCase(provider, identityRepo, userRepo, sessions, hasher.NewBcrypt(bcrypt.MinCost))
+}
+
+func signInThroughIdP(t *testing.T, uc SSOUseCase, idp *oidctest.Server, identityRepo *mockRepo.MockIdentityRepository) (string, string) {
+	var saved *models.SSOLoginState
+	identityRepo.On("SaveLoginState", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*models.SSOLoginState)
+	}).Return(nil).Once()
+
+	login, err := uc.StartLogin()
+	require.NoError(t, err)
+
+	code, state, err := idp.Authorize(login.AuthorizationURL)
+	require.NoError(t, err)
+	require.Equal(t, saved.State, state)
+	identityRepo.On("ConsumeLoginState", state, mock.Anything).Return(saved, nil).Once()
+
+	return state, code
+}
+
+func TestCompleteLogin_FirstLoginCreatesUser(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42", Email: "Ivan.Petrov@corp.example", EmailVerified: true})
+
+	identityRepo := new(mockRepo.MockIdentityRepository)
+	userRepo := new(mockRepo.MockUserRepository)
+	uc := newSSOTestUseCase(idp, identityRepo, userRepo)
+	state, code := signInThroughIdP(t, uc, idp, identityRepo)
+
+	identityRepo.On("GetUserByIdentity", idp.Issuer(), "emp-42").Return(nil, nil)
+	var created *models.User
+	identityRepo.On("CreateUserWithIdentity", mock.Anything, &models.UserIdentity{
+		Issuer:  idp.Issuer(),
+		Subject: "emp-42",
+		Email:   "Ivan.Petrov@corp.example",
+	}).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*models.User)
+		created.ID = "user-ID-42"
+	}).Return(nil)
+
+	tokens, err := uc.CompleteLogin(state, code, testClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ivan.petrov", created.Username)
+	assert.Equal(t, welcomeGrant, created.Balance)
+	assert.Equal(t, models.RoleEmployee, created.Role)
+	assert.Equal(t, "Ivan.Petrov@corp.example", *created.Email)
+	assert.NotEqual(t, "", created.Password)
+
+	claims, err := mockToken.NewVerifier(testKeys).Verify(tokens.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "ivan.petrov", claims.Username)
+	assert.NotEmpty(t, tokens.RefreshToken)
+}
+
+func TestCompleteLogin_ReturningUser(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42", Email: "ivan.petrov@corp.example", EmailVerified: true})
+
+	identityRepo := new(mockRepo.MockIdentityRepository)
+	userRepo := new(mockRepo.MockUserRepository)
+	uc := newSSOTestUseCase(idp, identityRepo, userRepo)
+	state, code := signInThroughIdP(t, uc, idp, identityRepo)
+
+	identityRepo.On("GetUserByIdentity", idp.Issuer(), "emp-42").Return(&models.User{ID: "user-ID-42", Username: "ivan", Role: models.RoleStaff}, nil)
+	identityRepo.On("UpdateIdentityEmail", idp.Issuer(), "emp-42", "ivan.petrov@corp.example").Return(nil)
+
+	tokens, err := uc.CompleteLogin(state, code, testClient)
+
+	require.NoError(t, err)
+	claims, err := mockToken.NewVerifier(testKeys).Verify(tokens.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "ivan", claims.Username)
+	assert.Equal(t, models.RoleStaff, claims.Role)
+	identityRepo.AssertNotCalled(t, "CreateUserWithIdentity", mock.Anything, mock.Anything)
+}
+
+func TestCompleteLogin_UsernameTakenGetsSuffix(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42", Email: "ivan@corp.example", EmailVerified: true})
+
+	identityRepo := new(mockRepo.MockIdentityRepository)
+	userRepo := new(mockRepo.MockUserRepository)
+	uc := newSSOTestUseCase(idp, identityRepo, userRepo)
+	state, code := signInThroughIdP(t, uc, idp, identityRepo)
+
+	identityRepo.On("GetUserByIdentity", idp.Issuer(), "emp-42").Return(nil, nil)
+	userRepo.On("FindUserByUsername", "ivan").Return(&models.User{ID: "someone-else", Username: "ivan"}, nil)
+	identityRepo.On("CreateUserWithIdentity", mock.MatchedBy(func(user *models.User) bool {
+		return user.Username == "ivan"
+	}), mock.Anything).Return(errors.New("пользователь уже существует"))
+	identityRepo.On("CreateUserWithIdentity", mock.MatchedBy(func(user *models.User) bool {
+		return user.Username == "ivan-2"
+	}), mock.Anything).Return(nil)
+
+	tokens, err := uc.CompleteLogin(state, code, testClient)
+
+	require.NoError(t, err)
+	claims, err := mockToken.NewVerifier(testKeys).Verify(tokens.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "ivan-2", claims.Username)
+}
+
+func TestCompleteLogin_RequiresVerifiedEmail(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+	idp.SignIn(oidctest.Identity{Subject: "emp-42", Email: "ivan@corp.example"})
+
+	identityRepo := new(mockRepo.MockIdentityRepository)
+	userRepo := new(mockRepo.MockUserRepository)
+	uc := newSSOTestUseCase(idp, identityRepo, userRepo)
+	state, code := signInThroughIdP(t, uc, idp, identityRepo)
+
+	identityRepo.On("GetUserByIdentity", idp.Issuer(), "emp-42").Return(nil, nil)
+
+	_, err := uc.CompleteLogin(state, code, testClient)
+
+	assert.EqualError(t, err, "провайдер SSO не подтвердил email")
+}
+
+func TestCompleteLogin_UnknownState(t *testing.T) {
+	idp := oidctest.NewServer("shop", "s3cret")
+	defer idp.Close()
+
+	identityRepo := new(mockRepo.MockIdentityRepository)
+	uc := newSSOTestUseCase(idp, identityRepo, new(mockRepo.MockUserRepository))
+	identityRepo.On("ConsumeLoginState", "forged", mock.Anything).Return(nil, nil)
+
+	_, err := uc.CompleteLogin("forged", "code", testClient)
+
+	assert.EqualError(t, err, "сессия входа истекла, начните вход заново")
+}
+
+func TestUsernameFromEmail(t *testing.T) {
+	assert.Equal(t, "ivan.petrov", usernameFromEmail("Ivan.Petrov@corp.example"))
+	assert.Equal(t, "o-brien", usernameFromEmail("o'brien@corp.example"))
+	assert.Equal(t, "user-ab", usernameFromEmail("ab@corp.example"))
+	assert.Len(t, usernameFromEmail("a.very.long.name.that.does.not.fit@corp.example"), maxSSOUsernameLength)
+}
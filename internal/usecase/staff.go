@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"errors"
+
+	"avito-shop-test/internal/models"
+)
+
+type staffSet map[string]bool
+
+func newStaffSet(ids []string) staffSet {
+	staff := make(staffSet, len(ids))
+	for _, id := range ids {
+		staff[id] = true
+	}
+	return staff
+}
+
+func (s staffSet) check(user *models.User) error {
+	if twoFactorRequired(user) && !user.TOTPEnabled {
+		return errAdminTwoFactorRequired
+	}
+	if !s[user.ID] && !models.HasPermission(user.Role, models.PermStoreManage) {
+		return errors.New("недостаточно прав")
+	}
+	return nil
+}
+
+func (s staffSet) allows(user *models.User) bool {
+	return s.check(user) == nil
+}
+
+func (s staffSet) find(userRepo UserRepository, username string) (*models.User, error) {
+	user, err := userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	if err := s.check(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"errors"
+
+	"avito-shop-test/internal/models"
+)
+
+type tradeUseCase struct {
+	tradeRepo TradeRepository
+	userRepo  UserRepository
+}
+
+func NewTradeUseCase(tradeRepo TradeRepository, userRepo UserRepository) TradeUseCase {
+	return &tradeUseCase{
+		tradeRepo: tradeRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (uc *tradeUseCase) ProposeTrade(username string, req models.CreateTradeRequest) (*models.TradeInfo, error) {
+	if username == req.ToUser {
+		return nil, errors.New("нельзя предложить обмен самому себе")
+	}
+	if req.OfferCoins < 0 || req.RequestCoins < 0 {
+		return nil, errors.New("количество монет не может быть отрицательным")
+	}
+	if len(req.OfferItems) == 0 && len(req.RequestItems) == 0 {
+		return nil, errors.New("в обмене должен участвовать хотя бы один товар")
+	}
+
+	proposer, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || proposer == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	counterparty, err := uc.userRepo.FindUserByUsername(req.ToUser)
+	if err != nil || counterparty == nil {
+		return nil, errors.New("получатель не найден")
+	}
+
+	if proposer.Balance < req.OfferCoins {
+		return nil, errors.New("недостаточно монет для обмена")
+	}
+
+	trade := &models.Trade{
+		ProposerID:        proposer.ID,
+		CounterpartyID:    counterparty.ID,
+		ProposerCoins:     req.OfferCoins,
+		CounterpartyCoins: req.RequestCoins,
+		Message:           req.Message,
+	}
+
+	seen := make(map[string]bool)
+	for _, side := range []struct {
+		ownerID string
+		items   []string
+	}{
+		{proposer.ID, req.OfferItems},
+		{counterparty.ID, req.RequestItems},
+	} {
+		for _, inventoryID := range side.items {
+			if seen[inventoryID] {
+				return nil, errors.New("товар указан в обмене несколько раз")
+			}
+			seen[inventoryID] = true
+			trade.Items = append(trade.Items, models.TradeItem{InventoryID: inventoryID, OwnerID: side.ownerID})
+		}
+	}
+
+	if err := uc.tradeRepo.CreateTrade(trade); err != nil {
+		return nil, err
+	}
+
+	info := toTradeInfo(*trade, proposer.Username, counterparty.Username)
+	return &info, nil
+}
+
+func (uc *tradeUseCase) GetTrades(username string) (*models.TradeHistory, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	trades, err := uc.tradeRepo.GetUserTrades(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.TradeHistory{}
+	for _, trade := range trades {
+		if trade.ProposerID == user.ID {
+			var counterparty string
+			if other, _ := uc.userRepo.GetUserByUserID(trade.CounterpartyID); other != nil {
+				counterparty = other.Username
+			}
+			history.Outgoing = append(history.Outgoing, toTradeInfo(trade, user.Username, counterparty))
+			continue
+		}
+
+		var proposer string
+		if other, _ := uc.userRepo.GetUserByUserID(trade.ProposerID); other != nil {
+			proposer = other.Username
+		}
+		history.Incoming = append(history.Incoming, toTradeInfo(trade, proposer, user.Username))
+	}
+
+	return history, nil
+}
+
+func (uc *tradeUseCase) AcceptTrade(username, tradeID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.tradeRepo.AcceptTrade(tradeID, user.ID)
+}
+
+func (uc *tradeUseCase) RejectTrade(username, tradeID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.tradeRepo.RejectTrade(tradeID, user.ID)
+}
+
+func (uc *tradeUseCase) CancelTrade(username, tradeID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.tradeRepo.CancelTrade(tradeID, user.ID)
+}
+
+func toTradeInfo(trade models.Trade, proposer, counterparty string) models.TradeInfo {
+	info := models.TradeInfo{
+		ID:         trade.ID,
+		Offer:      models.TradeSide{Username: proposer, Coins: trade.ProposerCoins, Items: []models.TradeItem{}},
+		Request:    models.TradeSide{Username: counterparty, Coins: trade.CounterpartyCoins, Items: []models.TradeItem{}},
+		Message:    trade.Message,
+		Status:     trade.Status,
+		CreatedAt:  trade.CreatedAt,
+		ResolvedAt: trade.ResolvedAt,
+	}
+	for _, item := range trade.Items {
+		if item.OwnerID == trade.ProposerID {
+			info.Offer.Items = append(info.Offer.Items, item)
+		} else {
+			info.Request.Items = append(info.Request.Items, item)
+		}
+	}
+	return info
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestProposeTrade_Success(t *testing.T) {
+	mockTradeRepo := new(mockRepo.MockTradeRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTradeUseCase(mockTradeRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1", Balance: 100}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2", Username: "user2"}, nil)
+	mockTradeRepo.On("CreateTrade", mock.MatchedBy(func(trade *models.Trade) bool {
+		return len(trade.Items) == 2 &&
+			trade.Items[0].OwnerID == "user-ID-1" && trade.Items[1].OwnerID == "user-ID-2" &&
+			trade.ProposerCoins == 50
+	})).Return(nil)
+
+	trade, err := uc.ProposeTrade("user1", models.CreateTradeRequest{
+		ToUser:       "user2",
+		OfferItems:   []string{"cup-1"},
+		OfferCoins:   50,
+		RequestItems: []string{"socks-1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", trade.Offer.Username)
+	assert.Len(t, trade.Offer.Items, 1)
+	assert.Len(t, trade.Request.Items, 1)
+	mockTradeRepo.AssertExpectations(t)
+}
+
+func TestProposeTrade_ToSelf(t *testing.T) {
+	uc := NewTradeUseCase(nil, nil)
+
+	_, err := uc.ProposeTrade("user1", models.CreateTradeRequest{ToUser: "user1", OfferItems: []string{"cup-1"}})
+
+	assert.EqualError(t, err, "нельзя предложить обмен самому себе")
+}
+
+func TestProposeTrade_CoinsOnly(t *testing.T) {
+	uc := NewTradeUseCase(nil, nil)
+
+	_, err := uc.ProposeTrade("user1", models.CreateTradeRequest{ToUser: "user2", OfferCoins: 10})
+
+	assert.EqualError(t, err, "в обмене должен участвовать хотя бы один товар")
+}
+
+func TestProposeTrade_DuplicateItem(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTradeUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+
+	_, err := uc.ProposeTrade("user1", models.CreateTradeRequest{
+		ToUser:       "user2",
+		OfferItems:   []string{"cup-1"},
+		RequestItems: []string{"cup-1"},
+	})
+
+	assert.EqualError(t, err, "товар указан в обмене несколько раз")
+}
+
+func TestProposeTrade_InsufficientBalance(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTradeUseCase(nil, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Balance: 10}, nil)
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+
+	_, err := uc.ProposeTrade("user1", models.CreateTradeRequest{ToUser: "user2", OfferCoins: 50, RequestItems: []string{"socks-1"}})
+
+	assert.EqualError(t, err, "недостаточно монет для обмена")
+}
+
+func TestAcceptTrade_Success(t *testing.T) {
+	mockTradeRepo := new(mockRepo.MockTradeRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTradeUseCase(mockTradeRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockTradeRepo.On("AcceptTrade", "trade-1", "user-ID-2").Return(nil)
+
+	err := uc.AcceptTrade("user2", "trade-1")
+
+	assert.NoError(t, err)
+	mockTradeRepo.AssertExpectations(t)
+}
+
+func TestGetTrades_SplitsDirections(t *testing.T) {
+	mockTradeRepo := new(mockRepo.MockTradeRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTradeUseCase(mockTradeRepo, mockUserRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-2").Return(&models.User{ID: "user-ID-2", Username: "user2"}, nil)
+	mockTradeRepo.On("GetUserTrades", "user-ID-1").Return([]models.Trade{
+		{ID: "trade-1", ProposerID: "user-ID-1", CounterpartyID: "user-ID-2"},
+		{ID: "trade-2", ProposerID: "user-ID-2", CounterpartyID: "user-ID-1"},
+	}, nil)
+
+	history, err := uc.GetTrades("user1")
+
+	assert.NoError(t, err)
+	assert.Len(t, history.Outgoing, 1)
+	assert.Len(t, history.Incoming, 1)
+	assert.Equal(t, "user2", history.Incoming[0].Offer.Username)
+}
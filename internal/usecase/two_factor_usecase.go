@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"avito-shop-test/internal/models"
+	"avito-shop-test/internal/totp"
+)
+
+const (
+	recoveryCodeCount    = 10
+	challengeTTL         = 5 * time.Minute
+	maxChallengeAttempts = 5
+	freshLoginWindow     = 10 * time.Minute
+)
+
+var (
+	errInvalidSecondFactor    = errors.New("неверный код подтверждения")
+	errTwoFactorDisabled      = errors.New("двухфакторная аутентификация не включена")
+	errAdminTwoFactorRequired = errors.New("включите двухфакторную аутентификацию, чтобы выполнять действия администратора")
+	recoveryCodeEncoding      = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+type twoFactorUseCase struct {
+	twoFactorRepo TwoFactorRepository
+	userRepo      UserRepository
+	tokenRepo     TokenRepository
+	issuer        string
+}
+
+func NewTwoFactorUseCase(twoFactorRepo TwoFactorRepository, userRepo UserRepository, tokenRepo TokenRepository, issuer string) TwoFactorUseCase {
+	return &twoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		issuer:        issuer,
+	}
+}
+
+func (uc *twoFactorUseCase) user(username string) (*models.User, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	return user, nil
+}
+
+func (uc *twoFactorUseCase) requireFreshLogin(user *models.User, sessionID string, now time.Time) error {
+	if sessionID != "" {
+		sessions, err := uc.tokenRepo.GetSessions(user.ID, now)
+		if err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			if session.ID == sessionID && now.Sub(session.CreatedAt) <= freshLoginWindow {
+				return nil
+			}
+		}
+	}
+	return errors.New("войдите заново, чтобы подключить двухфакторную аутентификацию")
+}
+
+func (uc *twoFactorUseCase) GetStatus(username string) (*models.TwoFactorStatus, error) {
+	user, err := uc.user(username)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.TwoFactorStatus{Enabled: user.TOTPEnabled, Required: twoFactorRequired(user)}
+	if user.TOTPEnabled {
+		if status.RecoveryCodesLeft, err = uc.twoFactorRepo.CountRecoveryCodes(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func (uc *twoFactorUseCase) Enroll(username, sessionID string) (*models.TOTPEnrollment, error) {
+	user, err := uc.user(username)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("двухфакторная аутентификация уже включена")
+	}
+	if err := uc.requireFreshLogin(user, sessionID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.SaveTOTPSecret(user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(uc.issuer, user.Username, secret),
+	}, nil
+}
+
+func (uc *twoFactorUseCase) Confirm(username, sessionID string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error) {
+	user, err := uc.user(username)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("двухфакторная аутентификация уже включена")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("сначала начните подключение двухфакторной аутентификации")
+	}
+	now := time.Now()
+	if err := uc.requireFreshLogin(user, sessionID, now); err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(user.TOTPSecret, req.Code, now)
+	if !ok {
+		return nil, errInvalidSecondFactor
+	}
+
+	codes, hashes, err := newRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.EnableTOTP(user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.RecoveryCodes{Codes: codes}, nil
+}
+
+func (uc *twoFactorUseCase) Disable(username string, req models.TwoFactorCodeRequest) error {
+	user, err := uc.user(username)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return errTwoFactorDisabled
+	}
+	if twoFactorRequired(user) {
+		return errors.New("администратор не может отключить двухфакторную аутентификацию")
+	}
+
+	ok, err := verifySecondFactor(uc.twoFactorRepo, user, req.Code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidSecondFactor
+	}
+
+	return uc.twoFactorRepo.DisableTOTP(user.ID)
+}
+
+func (uc *twoFactorUseCase) RegenerateRecoveryCodes(username string, req models.TwoFactorCodeRequest) (*models.RecoveryCodes, error) {
+	user, err := uc.user(username)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, errTwoFactorDisabled
+	}
+
+	step, ok := totp.Validate(user.TOTPSecret, req.Code, time.Now())
+	if !ok {
+		return nil, errInvalidSecondFactor
+	}
+	fresh, err := uc.twoFactorRepo.UseTOTPStep(user.ID, step)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errInvalidSecondFactor
+	}
+
+	codes, hashes, err := newRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.RecoveryCodes{Codes: codes}, nil
+}
+
+func (uc *twoFactorUseCase) PurgeExpiredChallenges() error {
+	return uc.twoFactorRepo.DeleteExpiredChallenges(time.Now())
+}
+
+func twoFactorRequired(user *models.User) bool {
+	return user.Role == models.RoleAdmin
+}
+
+func verifySecondFactor(twoFactorRepo TwoFactorRepository, user *models.User, code string, now time.Time) (bool, error) {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(user.TOTPSecret, code, now); ok {
+		return twoFactorRepo.UseTOTPStep(user.ID, step)
+	}
+	if len(code) == totp.Digits {
+		return false, nil
+	}
+	return twoFactorRepo.UseRecoveryCode(user.ID, recoveryCodeHash(user.ID, code), now)
+}
+
+func newRecoveryCodes(userID string) ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+		code := raw[:4] + "-" + raw[4:]
+		codes = append(codes, code)
+		hashes = append(hashes, recoveryCodeHash(userID, code))
+	}
+	return codes, hashes, nil
+}
+
+func recoveryCodeHash(userID, code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashToken(userID + ":" + normalized)
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"avito-shop-test/internal/hasher"
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+	mockToken "avito-shop-test/internal/token"
+	"avito-shop-test/internal/totp"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func currentCode(t *testing.T) (string, int64) {
+	step := totp.Step(time.Now())
+	code, err := totp.Code(testTOTPSecret, step)
+	require.NoError(t, err)
+	return code, step
+}
+
+func freshSessionRepo(userID string, createdAt time.Time) *mockRepo.MockTokenRepository {
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	mockTokenRepo.On("GetSessions", userID, mock.Anything).Return([]models.Session{{ID: "session-1", UserID: userID, CreatedAt: createdAt}}, nil)
+	return mockTokenRepo
+}
+
+func TestEnroll_ReturnsProvisioningURI(t *testing.T) {
+	mockTwoFactorRepo := new(mockRepo.MockTwoFactorRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, freshSessionRepo("user-ID-1", time.Now()), "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockTwoFactorRepo.On("SaveTOTPSecret", "user-ID-1", mock.Anything).Return(nil)
+
+	enrollment, err := uc.Enroll("user1", "session-1")
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Avito%20Shop:user1?"))
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+	mockTwoFactorRepo.AssertCalled(t, "SaveTOTPSecret", "user-ID-1", enrollment.Secret)
+}
+
+func TestEnroll_AlreadyEnabled(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(nil, mockUserRepo, nil, "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", TOTPEnabled: true}, nil)
+
+	_, err := uc.Enroll("user1", "session-1")
+
+	assert.EqualError(t, err, "двухфакторная аутентификация уже включена")
+}
+
+func TestEnroll_RequiresFreshLogin(t *testing.T) {
+	mockTwoFactorRepo := new(mockRepo.MockTwoFactorRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, freshSessionRepo("user-ID-1", time.Now().Add(-time.Hour)), "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+
+	_, err := uc.Enroll("user1", "session-1")
+
+	assert.EqualError(t, err, "войдите заново, чтобы подключить двухфакторную аутентификацию")
+	mockTwoFactorRepo.AssertNotCalled(t, "SaveTOTPSecret", mock.Anything, mock.Anything)
+}
+
+func TestConfirm_IssuesRecoveryCodes(t *testing.T) {
+	mockTwoFactorRepo := new(mockRepo.MockTwoFactorRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, freshSessionRepo("user-ID-1", time.Now()), "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", TOTPSecret: testTOTPSecret}, nil)
+	code, step := currentCode(t)
+	var hashes []string
+	mockTwoFactorRepo.On("EnableTOTP", "user-ID-1", step, mock.Anything).Run(func(args mock.Arguments) {
+		hashes = args.Get(2).([]string)
+	}).Return(nil)
+
+	codes, err := uc.Confirm("user1", "session-1", models.TwoFactorCodeRequest{Code: code})
+
+	require.NoError(t, err)
+	require.Len(t, codes.Codes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}$`, codes.Codes[0])
+	assert.Equal(t, recoveryCodeHash("user-ID-1", codes.Codes[0]), hashes[0])
+	assert.NotContains(t, hashes, codes.Codes[0])
+}
+
+func TestConfirm_WrongCode(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(nil, mockUserRepo, freshSessionRepo("user-ID-1", time.Now()), "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", TOTPSecret: testTOTPSecret}, nil)
+
+	_, err := uc.Confirm("user1", "session-1", models.TwoFactorCodeRequest{Code: "000000"})
+
+	assert.EqualError(t, err, "неверный код подтверждения")
+}
+
+func TestDisable_AdminCannotDisable(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(nil, mockUserRepo, nil, "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin, TOTPEnabled: true, TOTPSecret: testTOTPSecret}, nil)
+
+	code, _ := currentCode(t)
+	err := uc.Disable("boss", models.TwoFactorCodeRequest{Code: code})
+
+	assert.EqualError(t, err, "администратор не может отключить двухфакторную аутентификацию")
+}
+
+func TestDisable_WithRecoveryCode(t *testing.T) {
+	mockTwoFactorRepo := new(mockRepo.MockTwoFactorRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, nil, "Avito Shop")
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", TOTPEnabled: true, TOTPSecret: testTOTPSecret}, nil)
+	mockTwoFactorRepo.On("UseRecoveryCode", "user-ID-1", recoveryCodeHash("user-ID-1", "abcd-efgh"), mock.Anything).Return(true, nil)
+	mockTwoFactorRepo.On("DisableTOTP", "user-ID-1").Return(nil)
+
+	err := uc.Disable("user1", models.TwoFactorCodeRequest{Code: " ABCD EFGH "})
+
+	assert.NoError(t, err)
+	mockTwoFactorRepo.AssertExpectations(t)
+}
+
+func TestAdminAction_RequiresTwoFactor(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewAdminUseCase(nil, mockUserRepo, newTestThrottle(), nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin}, nil)
+
+	err := uc.GrantCoins("boss", models.GrantRequest{ToUser: "user1", Amount: 100, Reason: "бонус"})
+
+	assert.EqualError(t, err, "включите двухфакторную аутентификацию, чтобы выполнять действия администратора")
+}
+
+func TestStaffAction_AdminRequiresTwoFactor(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	uc := NewVariantUseCase(mockVariantRepo, nil, mockUserRepo, []string{"boss-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "boss").Return(&models.User{ID: "boss-ID", Role: models.RoleAdmin}, nil)
+
+	err := uc.AdjustStock("boss", "hoody-s", 5)
+
+	assert.EqualError(t, err, "включите двухфакторную аутентификацию, чтобы выполнять действия администратора")
+	mockVariantRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything)
+}
+
+func newTwoFactorLogin(t *testing.T) (UserUseCase, *mockRepo.MockUserRepository, *mockRepo.MockTwoFactorRepository) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTwoFactorRepo := new(mockRepo.MockTwoFactorRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	hash, err := passwordHasher.Hash("password")
+	require.NoError(t, err)
+
+	user := &models.User{ID: "user-ID-1", Username: "testuser", Password: hash, TOTPEnabled: true, TOTPSecret: testTOTPSecret}
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(user, nil)
+
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), passwordHasher, false, newTestThrottle(), mockTwoFactorRepo)
+	return uc, mockUserRepo, mockTwoFactorRepo
+}
+
+func TestLogin_TwoFactorReturnsChallenge(t *testing.T) {
+	uc, _, mockTwoFactorRepo := newTwoFactorLogin(t)
+
+	var stored *models.MFAChallenge
+	mockTwoFactorRepo.On("CreateChallenge", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.MFAChallenge)
+	}).Return(nil)
+
+	response, err := uc.Login("testuser", "password", testClient)
+
+	require.NoError(t, err)
+	assert.Empty(t, response.Token)
+	assert.NotEmpty(t, response.Challenge)
+	assert.Equal(t, hashToken(response.Challenge), stored.ID)
+	assert.Equal(t, "user-ID-1", stored.UserID)
+}
+
+func TestVerifyTwoFactor_IssuesTokens(t *testing.T) {
+	uc, _, mockTwoFactorRepo := newTwoFactorLogin(t)
+
+	mockTwoFactorRepo.On("UseChallenge", hashToken("challenge"), mock.Anything, maxChallengeAttempts).
+		Return(&models.MFAChallenge{ID: hashToken("challenge"), UserID: "user-ID-1"}, nil)
+	code, step := currentCode(t)
+	mockTwoFactorRepo.On("UseTOTPStep", "user-ID-1", step).Return(true, nil)
+	mockTwoFactorRepo.On("DeleteChallenge", hashToken("challenge")).Return(nil)
+
+	response, err := uc.VerifyTwoFactor("challenge", code, testClient)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	mockTwoFactorRepo.AssertExpectations(t)
+}
+
+func TestVerifyTwoFactor_ReplayedCodeRejected(t *testing.T) {
+	uc, _, mockTwoFactorRepo := newTwoFactorLogin(t)
+
+	mockTwoFactorRepo.On("UseChallenge", hashToken("challenge"), mock.Anything, maxChallengeAttempts).
+		Return(&models.MFAChallenge{ID: hashToken("challenge"), UserID: "user-ID-1"}, nil)
+	code, step := currentCode(t)
+	mockTwoFactorRepo.On("UseTOTPStep", "user-ID-1", step).Return(false, nil)
+
+	_, err := uc.VerifyTwoFactor("challenge", code, testClient)
+
+	assert.EqualError(t, err, "неверный код подтверждения")
+	mockTwoFactorRepo.AssertNotCalled(t, "DeleteChallenge", mock.Anything)
+}
+
+func TestVerifyTwoFactor_UnknownChallenge(t *testing.T) {
+	uc, _, mockTwoFactorRepo := newTwoFactorLogin(t)
+
+	mockTwoFactorRepo.On("UseChallenge", hashToken("stale"), mock.Anything, maxChallengeAttempts).Return(nil, nil)
+
+	_, err := uc.VerifyTwoFactor("stale", "123456", testClient)
+
+	assert.EqualError(t, err, "запрос подтверждения недействителен или истёк, войдите заново")
+}
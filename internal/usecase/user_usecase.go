@@ -1,56 +1,430 @@
 package usecase
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
 
 	"avito-shop-test/internal/models"
 )
 
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+	refreshTokenTTL   = 30 * 24 * time.Hour
+	welcomeGrant      = 1000
+
+	maxUserAgentLength = 255
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{3,32}$`)
+	errUserExists   = errors.New("пользователь уже существует")
+)
+
 type userUseCase struct {
 	userRepo            UserRepository
 	purchaseRepo        PurchaseRepository
 	coinTransactionRepo CoinTransactionRepository
+	tokenRepo           TokenRepository
 	tokenGenerator      TokenGenerator
+	passwordHasher      PasswordHasher
+	autoSignup          bool
+	throttle            LoginThrottle
+	twoFactorRepo       TwoFactorRepository
 }
 
-func NewUserUsecase(userRepo UserRepository, purchaseRepo PurchaseRepository, coinTransactionRepo CoinTransactionRepository, tokenGenerator TokenGenerator) UserUseCase {
+func NewUserUsecase(userRepo UserRepository, purchaseRepo PurchaseRepository, coinTransactionRepo CoinTransactionRepository, tokenRepo TokenRepository, tokenGenerator TokenGenerator, passwordHasher PasswordHasher, autoSignup bool, throttle LoginThrottle, twoFactorRepo TwoFactorRepository) UserUseCase {
 	return &userUseCase{
 		userRepo:            userRepo,
 		purchaseRepo:        purchaseRepo,
 		coinTransactionRepo: coinTransactionRepo,
+		tokenRepo:           tokenRepo,
 		tokenGenerator:      tokenGenerator,
+		passwordHasher:      passwordHasher,
+		autoSignup:          autoSignup,
+		throttle:            throttle,
+		twoFactorRepo:       twoFactorRepo,
 	}
 }
 
-func (uc *userUseCase) Authenticate(username, password string) (string, error) {
+func (uc *userUseCase) Authenticate(username, password string, client models.ClientInfo) (*models.AuthResponse, error) {
+	if !uc.autoSignup {
+		return uc.Login(username, password, client)
+	}
 	if username == "" || password == "" {
-		return "", errors.New("username and password cannot be empty")
+		return nil, errors.New("username and password cannot be empty")
+	}
+	if err := uc.throttle.Check(username, client.IP); err != nil {
+		return nil, err
 	}
 	user, err := uc.userRepo.FindUserByUsername(username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if user == nil {
-
-		newUser := &models.User{Username: username, Password: password, Balance: 1000}
-		if err := uc.userRepo.CreateUser(newUser); err != nil {
-			return "", err
+		newUser, err := uc.createUser(username, password)
+		if err == nil {
+			return uc.issueTokens(newUser, "", client)
+		}
+		if !errors.Is(err, errUserExists) {
+			return nil, err
 		}
 		user = newUser
-	} else {
+	}
+
+	return uc.checkPassword(user, password, client)
+}
+
+func (uc *userUseCase) Register(username, password string, client models.ClientInfo) (*models.AuthResponse, error) {
+	if err := uc.throttle.Check(username, client.IP); err != nil {
+		return nil, err
+	}
+	existing, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errUserExists
+	}
+
+	user, err := uc.createUser(username, password)
+	if err != nil {
+		return nil, err
+	}
 
-		if user.Password != password {
-			return "", errors.New("неавторизован")
+	return uc.issueTokens(user, "", client)
+}
+
+func (uc *userUseCase) Login(username, password string, client models.ClientInfo) (*models.AuthResponse, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password cannot be empty")
+	}
+	if err := uc.throttle.Check(username, client.IP); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		uc.throttle.RecordFailure(username, client.IP)
+		return nil, errors.New("неавторизован")
+	}
+
+	return uc.checkPassword(user, password, client)
+}
+
+func (uc *userUseCase) Refresh(refreshToken string, client models.ClientInfo) (*models.AuthResponse, error) {
+	now := time.Now()
+	current, fresh, err := uc.tokenRepo.UseRefreshToken(hashToken(refreshToken), now)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New("недействительный токен обновления")
+	}
+	if !fresh {
+		if err := uc.tokenRepo.RevokeFamily(current.FamilyID, now); err != nil {
+			return nil, err
 		}
+		return nil, errors.New("токен обновления уже использован, сессия отозвана")
+	}
+	if now.After(current.ExpiresAt) {
+		return nil, errors.New("срок действия токена обновления истёк")
+	}
+
+	user, err := uc.userRepo.GetUserByUserID(current.UserID)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	return uc.issueTokens(user, current.FamilyID, client)
+}
+
+func (uc *userUseCase) Logout(username, jti string, expiresAt time.Time, req models.LogoutRequest) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	now := time.Now()
+	if err := uc.tokenRepo.RevokeAccessToken(jti, expiresAt); err != nil {
+		return err
+	}
+
+	if req.All {
+		return uc.tokenRepo.RevokeUserTokens(user.ID, now)
+	}
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	current, err := uc.tokenRepo.GetRefreshToken(hashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	if current == nil || current.UserID != user.ID {
+		return errors.New("недействительный токен обновления")
+	}
+	return uc.tokenRepo.RevokeFamily(current.FamilyID, now)
+}
+
+func (uc *userUseCase) PurgeExpiredTokens() error {
+	return uc.tokenRepo.DeleteExpired(time.Now())
+}
+
+func (uc *userUseCase) checkPassword(user *models.User, password string, client models.ClientInfo) (*models.AuthResponse, error) {
+	if user.ServiceAccount || !uc.passwordHasher.Compare(user.Password, password) {
+		uc.throttle.RecordFailure(user.Username, client.IP)
+		return nil, errors.New("неавторизован")
+	}
+	uc.rehashPassword(user, password)
+
+	return uc.startSession(user, client)
+}
+
+func (uc *userUseCase) IssueTokens(user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	return uc.startSession(user, client)
+}
+
+func (uc *userUseCase) startSession(user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	if !user.TOTPEnabled {
+		uc.throttle.RecordSuccess(user.Username)
+		return uc.issueTokens(user, "", client)
+	}
+
+	challenge, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	err = uc.twoFactorRepo.CreateChallenge(&models.MFAChallenge{
+		ID:        hashToken(challenge),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(challengeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{Challenge: challenge}, nil
+}
+
+func (uc *userUseCase) VerifyTwoFactor(challenge, code string, client models.ClientInfo) (*models.AuthResponse, error) {
+	now := time.Now()
+	pending, err := uc.twoFactorRepo.UseChallenge(hashToken(challenge), now, maxChallengeAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, errors.New("запрос подтверждения недействителен или истёк, войдите заново")
+	}
+
+	user, err := uc.userRepo.GetUserByUserID(pending.UserID)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	if err := uc.throttle.Check(user.Username, client.IP); err != nil {
+		return nil, err
 	}
 
-	tokenString, err := uc.tokenGenerator.Generate(user.Username)
+	ok, err := verifySecondFactor(uc.twoFactorRepo, user, code, now)
 	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		uc.throttle.RecordFailure(user.Username, client.IP)
+		return nil, errInvalidSecondFactor
+	}
+
+	if err := uc.twoFactorRepo.DeleteChallenge(pending.ID); err != nil {
+		return nil, err
+	}
+	uc.throttle.RecordSuccess(user.Username)
+	return uc.issueTokens(user, "", client)
+}
+
+func (uc *userUseCase) issueTokens(user *models.User, familyID string, client models.ClientInfo) (*models.AuthResponse, error) {
+	role := user.Role
+	if role == "" {
+		role = models.RoleEmployee
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(refreshTokenTTL)
+	if familyID == "" {
+		session := &models.Session{
+			UserID:     user.ID,
+			UserAgent:  truncateUserAgent(client.UserAgent),
+			IP:         client.IP,
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiresAt:  expiresAt,
+		}
+		if err := uc.tokenRepo.CreateSession(session); err != nil {
+			return nil, err
+		}
+		familyID = session.ID
+	} else if err := uc.tokenRepo.ExtendSession(familyID, client.IP, now, expiresAt); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.tokenGenerator.Generate(user.Username, role, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := uc.tokenRepo.CreateRefreshToken(record); err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{Token: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (uc *userUseCase) GetSessions(username, currentID string) ([]models.SessionInfo, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	sessions, err := uc.tokenRepo.GetSessions(user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, models.SessionInfo{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentID,
+		})
+	}
+	return result, nil
+}
+
+func (uc *userUseCase) RevokeSession(username, sessionID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	revoked, err := uc.tokenRepo.RevokeSession(user.ID, sessionID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("сессия не найдена")
+	}
+	return nil
+}
+
+func (uc *userUseCase) RevokeAllSessions(username, currentID string, req models.RevokeSessionsRequest) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	now := time.Now()
+	if !req.KeepCurrent {
+		return uc.tokenRepo.RevokeUserTokens(user.ID, now)
+	}
+	if currentID == "" {
+		return errors.New("текущая сессия не определена, войдите заново")
+	}
+	return uc.tokenRepo.RevokeOtherSessions(user.ID, currentID, now)
+}
+
+func truncateUserAgent(userAgent string) string {
+	runes := []rune(userAgent)
+	if len(runes) > maxUserAgentLength {
+		return string(runes[:maxUserAgentLength])
+	}
+	return userAgent
+}
+
+func (uc *userUseCase) createUser(username, password string) (*models.User, error) {
+	if err := validateCredentials(username, password); err != nil {
+		return nil, err
+	}
+
+	hash, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{Username: username, Password: hash, Balance: welcomeGrant, Role: models.RoleEmployee}
+	if err := uc.userRepo.CreateUser(user); err != nil {
+		existing, findErr := uc.userRepo.FindUserByUsername(username)
+		if findErr == nil && existing != nil {
+			return existing, errUserExists
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+func validateCredentials(username, password string) error {
+	if !usernamePattern.MatchString(username) {
+		return errors.New("имя пользователя должно состоять из 3–32 латинских букв, цифр или символов . _ -")
+	}
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return errors.New("пароль должен содержать от 8 до 72 байт")
+	}
+	if strings.EqualFold(password, username) {
+		return errors.New("пароль не должен совпадать с именем пользователя")
+	}
+	return nil
+}
+
+func (uc *userUseCase) rehashPassword(user *models.User, password string) {
+	if !uc.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
 
-	return tokenString, nil
+	hash, err := uc.passwordHasher.Hash(password)
+	if err == nil {
+		err = uc.userRepo.UpdatePassword(user.Username, hash)
+	}
+	if err != nil {
+		log.Printf("Не удалось обновить хеш пароля пользователя %s: %v", user.Username, err)
+	}
 }
 
 func (uc *userUseCase) GetCoinHistory(userID string) (models.CoinHistory, error) {
@@ -101,6 +475,10 @@ func (uc *userUseCase) GetPurchasedItems(userID string) ([]models.PurchasedItem,
 				Quantity: purchase.Quantity,
 			}
 		}
+
+		if purchase.SKU != "" {
+			addPurchasedVariant(itemMap[purchase.ItemType], purchase)
+		}
 	}
 
 	var items []models.PurchasedItem
@@ -111,6 +489,21 @@ func (uc *userUseCase) GetPurchasedItems(userID string) ([]models.PurchasedItem,
 	return items, nil
 }
 
+func addPurchasedVariant(item *models.PurchasedItem, purchase models.Inventory) {
+	for i := range item.Variants {
+		if item.Variants[i].SKU == purchase.SKU {
+			item.Variants[i].Quantity += purchase.Quantity
+			return
+		}
+	}
+
+	item.Variants = append(item.Variants, models.PurchasedVariant{
+		SKU:      purchase.SKU,
+		Variant:  purchase.Variant,
+		Quantity: purchase.Quantity,
+	})
+}
+
 func (uc *userUseCase) GetUserInfo(username string) (*models.UserInfo, error) {
 	user, err := uc.userRepo.FindUserByUsername(username)
 	if err != nil {
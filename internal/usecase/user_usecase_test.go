@@ -3,25 +3,56 @@ package usecase
 import (
 	"errors"
 	"testing"
+	"time"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 
+	"avito-shop-test/internal/hasher"
 	"avito-shop-test/internal/models"
+	"avito-shop-test/internal/repository"
 	mockRepo "avito-shop-test/internal/repository/mock"
 	mockToken "avito-shop-test/internal/token"
 )
 
+var testKeys = newTestKeys()
+
+func newTestKeys() *mockToken.Keyring {
+	keys, err := mockToken.NewKeyring(mockToken.NewMemoryKeyStore(), mockToken.AlgEdDSA, time.Hour, mockToken.DefaultTTL)
+	if err != nil {
+		panic(err)
+	}
+	if err := keys.Refresh(); err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+func newTestThrottle() LoginThrottle {
+	return NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 10, 100, 15*time.Minute)
+}
+
+var testClient = models.ClientInfo{IP: "10.0.0.1", UserAgent: "test-agent"}
+
+func newMockTokenRepo() *mockRepo.MockTokenRepository {
+	tokenRepo := new(mockRepo.MockTokenRepository)
+	tokenRepo.On("CreateSession", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Session).ID = "session-1"
+	}).Return(nil).Maybe()
+	tokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil).Maybe()
+	return tokenRepo
+}
+
 func TestAuthenticate_FindUserByUsername_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, errors.New("database error"))
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -33,12 +64,12 @@ func TestAuthenticate_CreateUser_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil)
 	mockUserRepo.On("CreateUser", mock.Anything).Return(errors.New("error creating user"))
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -50,12 +81,12 @@ func TestAuthenticate_UserCreation_ValIDationError(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil)
 	mockUserRepo.On("CreateUser", mock.Anything).Return(errors.New("valIDation error"))
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -68,9 +99,9 @@ func TestAuthenticate_EmptyUsernameOrPassword(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
-	token, err := uc.Authenticate("", "")
+	token, err := uc.Authenticate("", "", testClient)
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -80,12 +111,12 @@ func TestAuthenticate_UserNotFound_CreateUser(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil)
 	mockUserRepo.On("CreateUser", mock.Anything).Return(nil)
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -96,12 +127,12 @@ func TestAuthenticate_UserFound_WrongPassword(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{Username: "testuser", Password: "password"}
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
 
-	token, err := uc.Authenticate("testuser", "wrongpassword")
+	token, err := uc.Authenticate("testuser", "wrongpassword", testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, "неавторизован", err.Error())
@@ -113,12 +144,13 @@ func TestAuthenticate_UserFound_CorrectPassword(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{Username: "testuser", Password: "password"}
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
+	mockUserRepo.On("UpdatePassword", "testuser", mock.Anything).Return(nil)
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -129,41 +161,40 @@ func TestAuthenticate_GenerateToken_Success(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{Username: "testuser", Password: "password"}
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
+	mockUserRepo.On("UpdatePassword", "testuser", mock.Anything).Return(nil)
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 
-	claims := jwt.MapClaims{}
-	parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte("secret"), nil
-	})
+	claims, err := mockToken.NewVerifier(testKeys).Verify(token.Token)
 
 	assert.NoError(t, err)
-	assert.True(t, parsedToken.Valid)
-	assert.Equal(t, user.Username, claims["username"])
+	assert.Equal(t, user.Username, claims.Username)
+	assert.Equal(t, models.RoleEmployee, claims.Role)
 }
 
 func TestAuthenticate_TokenGenerationError(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret"))).(*userUseCase)
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil).(*userUseCase)
 	mockTokenGenerator := new(mockToken.MockTokenGenerator)
 
 	user := &models.User{Username: "testuser", Password: "password"}
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
+	mockUserRepo.On("UpdatePassword", "testuser", mock.Anything).Return(nil)
 
-	mockTokenGenerator.On("Generate", user.Username).Return("", errors.New("token generation error"))
+	mockTokenGenerator.On("Generate", user.Username, models.RoleEmployee, "session-1").Return("", errors.New("token generation error"))
 
 	uc.tokenGenerator = mockTokenGenerator
 
-	token, err := uc.Authenticate("testuser", "password")
+	token, err := uc.Authenticate("testuser", "password", testClient)
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -173,11 +204,362 @@ func TestAuthenticate_TokenGenerationError(t *testing.T) {
 	mockTokenGenerator.AssertExpectations(t)
 }
 
+func TestAuthenticate_NewUserPasswordIsHashed(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), passwordHasher, true, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil)
+	mockUserRepo.On("CreateUser", mock.MatchedBy(func(user *models.User) bool {
+		return user.Password != "password" && passwordHasher.Compare(user.Password, "password")
+	})).Return(nil)
+
+	_, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthenticate_PlaintextPasswordUpgraded(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), passwordHasher, true, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{Username: "testuser", Password: "password"}, nil)
+	mockUserRepo.On("UpdatePassword", "testuser", mock.MatchedBy(func(hash string) bool {
+		return passwordHasher.Compare(hash, "password") && !passwordHasher.NeedsRehash(hash)
+	})).Return(nil)
+
+	token, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthenticate_HashedPasswordNotRewritten(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), passwordHasher, true, newTestThrottle(), nil)
+
+	hash, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{Username: "testuser", Password: hash}, nil)
+
+	token, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_RehashFailureDoesNotBlockLogin(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost+1), true, newTestThrottle(), nil)
+
+	hash, err := hasher.NewBcrypt(bcrypt.MinCost).Hash("password")
+	assert.NoError(t, err)
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{Username: "testuser", Password: hash}, nil)
+	mockUserRepo.On("UpdatePassword", "testuser", mock.Anything).Return(errors.New("database error"))
+
+	token, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthenticate_ConcurrentSignupLogsIntoWinner(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), passwordHasher, true, newTestThrottle(), nil)
+
+	hash, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil).Once()
+	mockUserRepo.On("CreateUser", mock.Anything).Return(errors.New("пользователь уже существует"))
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{Username: "testuser", Password: hash}, nil).Once()
+
+	token, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthenticate_AutoSignupDisabled(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, nil)
+
+	token, err := uc.Authenticate("testuser", "password", testClient)
+
+	assert.EqualError(t, err, "неавторизован")
+	assert.Empty(t, token)
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestRegister_Success(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "new.user").Return(nil, nil)
+	mockUserRepo.On("CreateUser", mock.MatchedBy(func(user *models.User) bool {
+		return user.Username == "new.user" && user.Balance == 1000
+	})).Return(nil)
+
+	token, err := uc.Register("new.user", "s3cret-pass", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestRegister_UserExists(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{Username: "testuser"}, nil)
+
+	_, err := uc.Register("testuser", "password", testClient)
+
+	assert.EqualError(t, err, "пользователь уже существует")
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestRegister_BlockedIP(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	throttle := NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), 100, 20, 15*time.Minute)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, throttle, nil)
+
+	for i := 0; i < 20; i++ {
+		throttle.RecordFailure("user"+string(rune('a'+i)), testClient.IP)
+	}
+
+	_, err := uc.Register("new.user", "s3cret-pass", testClient)
+
+	var throttled *models.LoginThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestRegister_ValidationRules(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", mock.Anything).Return(nil, nil)
+
+	cases := map[string][2]string{
+		"имя пользователя должно состоять из 3–32 латинских букв, цифр или символов . _ -": {"ab", "password"},
+		"пароль должен содержать от 8 до 72 байт":                                          {"testuser", "short"},
+		"пароль не должен совпадать с именем пользователя":                                 {"testuser1", "TestUser1"},
+	}
+	for message, credentials := range cases {
+		_, err := uc.Register(credentials[0], credentials[1], testClient)
+		assert.EqualError(t, err, message)
+	}
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestAuthenticate_IssuesRefreshToken(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	passwordHasher := hasher.NewBcrypt(bcrypt.MinCost)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), passwordHasher, false, newTestThrottle(), nil)
+
+	hash, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1", Username: "testuser", Password: hash}, nil)
+
+	var session *models.Session
+	mockTokenRepo.On("CreateSession", mock.Anything).Run(func(args mock.Arguments) {
+		session = args.Get(0).(*models.Session)
+		session.ID = "session-1"
+	}).Return(nil)
+	var stored *models.RefreshToken
+	mockTokenRepo.On("CreateRefreshToken", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.RefreshToken)
+	}).Return(nil)
+
+	tokens, err := uc.Login("testuser", "password", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, "user-1", stored.UserID)
+	assert.Equal(t, "session-1", stored.FamilyID)
+	assert.Equal(t, "user-1", session.UserID)
+	assert.Equal(t, "10.0.0.1", session.IP)
+	assert.Equal(t, "test-agent", session.UserAgent)
+	assert.Equal(t, stored.ExpiresAt, session.ExpiresAt)
+	assert.Equal(t, hashToken(tokens.RefreshToken), stored.TokenHash)
+	assert.NotEqual(t, tokens.RefreshToken, stored.TokenHash)
+}
+
+func TestRefresh_RotatesWithinFamily(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	current := &models.RefreshToken{UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.On("UseRefreshToken", hashToken("old-token"), mock.Anything).Return(current, true, nil)
+	mockUserRepo.On("GetUserByUserID", "user-1").Return(&models.User{ID: "user-1", Username: "testuser"}, nil)
+	mockTokenRepo.On("ExtendSession", "family-1", "10.0.0.1", mock.Anything, mock.Anything).Return(nil)
+	mockTokenRepo.On("CreateRefreshToken", mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.FamilyID == "family-1" && token.UserID == "user-1"
+	})).Return(nil)
+
+	tokens, err := uc.Refresh("old-token", testClient)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEqual(t, "old-token", tokens.RefreshToken)
+	claims, err := mockToken.NewVerifier(testKeys).Verify(tokens.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "family-1", claims.SessionID)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(nil, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	usedAt := time.Now().Add(-time.Minute)
+	used := &models.RefreshToken{UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	mockTokenRepo.On("UseRefreshToken", hashToken("stolen-token"), mock.Anything).Return(used, false, nil)
+	mockTokenRepo.On("RevokeFamily", "family-1", mock.Anything).Return(nil)
+
+	tokens, err := uc.Refresh("stolen-token", testClient)
+
+	assert.EqualError(t, err, "токен обновления уже использован, сессия отозвана")
+	assert.Nil(t, tokens)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(nil, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockTokenRepo.On("UseRefreshToken", mock.Anything, mock.Anything).Return(nil, false, nil)
+
+	_, err := uc.Refresh("unknown", testClient)
+
+	assert.EqualError(t, err, "недействительный токен обновления")
+}
+
+func TestLogout_RevokesAccessAndRefreshTokens(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("RevokeAccessToken", "jti-1", expiresAt).Return(nil)
+	mockTokenRepo.On("GetRefreshToken", hashToken("refresh")).Return(&models.RefreshToken{UserID: "user-1", FamilyID: "family-1"}, nil)
+	mockTokenRepo.On("RevokeFamily", "family-1", mock.Anything).Return(nil)
+
+	err := uc.Logout("testuser", "jti-1", expiresAt, models.LogoutRequest{RefreshToken: "refresh"})
+
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestLogout_ForeignRefreshTokenRejected(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("RevokeAccessToken", "jti-1", mock.Anything).Return(nil)
+	mockTokenRepo.On("GetRefreshToken", mock.Anything).Return(&models.RefreshToken{UserID: "user-2", FamilyID: "family-2"}, nil)
+
+	err := uc.Logout("testuser", "jti-1", time.Now(), models.LogoutRequest{RefreshToken: "refresh"})
+
+	assert.EqualError(t, err, "недействительный токен обновления")
+	mockTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+func TestGetSessions_MarksCurrent(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("GetSessions", "user-1", mock.Anything).Return([]models.Session{
+		{ID: "session-1", UserAgent: "laptop", IP: "10.0.0.1"},
+		{ID: "session-2", UserAgent: "phone", IP: "10.0.0.2"},
+	}, nil)
+
+	sessions, err := uc.GetSessions("testuser", "session-2")
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	assert.Equal(t, "phone", sessions[1].UserAgent)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("RevokeSession", "user-1", "foreign-session", mock.Anything).Return(false, nil)
+
+	err := uc.RevokeSession("testuser", "foreign-session")
+
+	assert.EqualError(t, err, "сессия не найдена")
+}
+
+func TestRevokeAllSessions_KeepCurrent(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("RevokeOtherSessions", "user-1", "session-1", mock.Anything).Return(nil)
+
+	err := uc.RevokeAllSessions("testuser", "session-1", models.RevokeSessionsRequest{KeepCurrent: true})
+
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "RevokeUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestRevokeAllSessions_Everything(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+	mockTokenRepo.On("RevokeUserTokens", "user-1", mock.Anything).Return(nil)
+
+	err := uc.RevokeAllSessions("testuser", "session-1", models.RevokeSessionsRequest{})
+
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestRevokeAllSessions_KeepCurrentWithoutSession(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockTokenRepo := new(mockRepo.MockTokenRepository)
+	uc := NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockToken.NewGenerator(testKeys), nil, false, newTestThrottle(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "testuser").Return(&models.User{ID: "user-1"}, nil)
+
+	err := uc.RevokeAllSessions("testuser", "", models.RevokeSessionsRequest{KeepCurrent: true})
+
+	assert.EqualError(t, err, "текущая сессия не определена, войдите заново")
+	mockTokenRepo.AssertNotCalled(t, "RevokeUserTokens", mock.Anything, mock.Anything)
+}
+
 func TestGetUserInfo_GetPurchasedItems_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -197,7 +579,7 @@ func TestGetUserInfo_Success(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{Username: "testuser", Balance: 100, ID: "user-ID-1"}
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(user, nil)
@@ -223,7 +605,7 @@ func TestGetUserInfo_UserNotFound(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	mockUserRepo.On("FindUserByUsername", "testuser").Return(nil, errors.New("user not found"))
 
@@ -239,7 +621,7 @@ func TestGetUserInfo_GetCoinHistory_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -261,7 +643,7 @@ func TestGetCoinHistory_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 	mockTransactionRepo.On("GetTransactionsHistory", user.ID).Return(nil, errors.New("error retrieving transactions"))
@@ -278,7 +660,7 @@ func TestGetCoinHistory_Success(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -304,7 +686,7 @@ func TestGetCoinHistory_NoTransactions(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -323,7 +705,7 @@ func TestGetCoinHistory_NoTransactions_EmptyHistory(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -343,7 +725,7 @@ func TestGetPurchasedItems_Success(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -370,7 +752,7 @@ func TestGetPurchasedItems_NoItems(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -388,7 +770,7 @@ func TestGetPurchasedItems_Error(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -407,7 +789,7 @@ func TestGetPurchasedItems_InvalIDItems(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -429,7 +811,7 @@ func TestGetPurchasedItems_DuplicateItems(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
@@ -452,7 +834,7 @@ func TestGetPurchasedItems_OneItem(t *testing.T) {
 	mockUserRepo := new(mockRepo.MockUserRepository)
 	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
 	mockTransactionRepo := new(mockRepo.MockTransactionRepository)
-	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, mockToken.NewGenerator([]byte("secret")))
+	uc := NewUserUsecase(mockUserRepo, mockPurchaseRepo, mockTransactionRepo, newMockTokenRepo(), mockToken.NewGenerator(testKeys), hasher.NewBcrypt(bcrypt.MinCost), true, newTestThrottle(), nil)
 
 	user := &models.User{ID: "user-ID-1"}
 
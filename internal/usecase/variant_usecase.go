@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+
+	"avito-shop-test/internal/models"
+)
+
+type variantUseCase struct {
+	variantRepo VariantRepository
+	storeRepo   StoreRepository
+	userRepo    UserRepository
+	staff       staffSet
+}
+
+func NewVariantUseCase(variantRepo VariantRepository, storeRepo StoreRepository, userRepo UserRepository, staff []string) VariantUseCase {
+	return &variantUseCase{
+		variantRepo: variantRepo,
+		storeRepo:   storeRepo,
+		userRepo:    userRepo,
+		staff:       newStaffSet(staff),
+	}
+}
+
+func (uc *variantUseCase) GetVariants(itemName string) (*models.ItemVariants, error) {
+	product, err := uc.storeRepo.GetItemByName(itemName)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	options, err := uc.variantRepo.GetItemOptions(itemName)
+	if err != nil {
+		return nil, err
+	}
+
+	skus, err := uc.variantRepo.GetSKUs(itemName)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := &models.ItemVariants{
+		Item:    product.Name,
+		Price:   product.Price,
+		Options: make(map[string][]string),
+		SKUs:    skus,
+	}
+	for _, option := range options {
+		variants.Options[option.Name] = append(variants.Options[option.Name], option.Value)
+	}
+
+	return variants, nil
+}
+
+func (uc *variantUseCase) AddOption(username, itemName string, req models.AddItemOptionRequest) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	if _, err := uc.storeRepo.GetItemByName(itemName); err != nil {
+		return errors.New("товар не найден")
+	}
+
+	if req.Name == "" || len(req.Values) == 0 {
+		return errors.New("не указаны значения характеристики")
+	}
+
+	options := make([]models.ItemOption, 0, len(req.Values))
+	for _, value := range req.Values {
+		if value == "" {
+			return errors.New("не указаны значения характеристики")
+		}
+		options = append(options, models.ItemOption{ItemName: itemName, Name: req.Name, Value: value})
+	}
+
+	return uc.variantRepo.AddItemOptions(options)
+}
+
+func (uc *variantUseCase) CreateSKU(username, itemName string, req models.CreateSKURequest) (*models.SKU, error) {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.storeRepo.GetItemByName(itemName); err != nil {
+		return nil, errors.New("товар не найден")
+	}
+
+	if req.Stock < 0 || (req.Price != nil && *req.Price <= 0) {
+		return nil, errors.New("некорректный остаток или цена")
+	}
+
+	options, err := uc.variantRepo.GetItemOptions(itemName)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]map[string]bool)
+	for _, option := range options {
+		if allowed[option.Name] == nil {
+			allowed[option.Name] = make(map[string]bool)
+		}
+		allowed[option.Name][option.Value] = true
+	}
+
+	if len(allowed) == 0 || len(req.Options) != len(allowed) {
+		return nil, errors.New("нужно указать значение каждой характеристики товара")
+	}
+
+	sku := &models.SKU{
+		Code:     req.Code,
+		ItemName: itemName,
+		Stock:    req.Stock,
+		Price:    req.Price,
+	}
+	for name, value := range req.Options {
+		if !allowed[name][value] {
+			return nil, errors.New("недопустимое значение характеристики " + name)
+		}
+		sku.Options = append(sku.Options, models.SKUOption{Name: name, Value: value})
+	}
+
+	if err := uc.variantRepo.CreateSKU(sku); err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+func (uc *variantUseCase) AdjustStock(username, code string, delta int) error {
+	if _, err := uc.staff.find(uc.userRepo, username); err != nil {
+		return err
+	}
+
+	return uc.variantRepo.AdjustStock(code, delta)
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestGetVariants_GroupsOptions(t *testing.T) {
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewVariantUseCase(mockVariantRepo, mockStoreRepo, nil, nil)
+
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300}, nil)
+	mockVariantRepo.On("GetItemOptions", "hoody").Return([]models.ItemOption{
+		{ItemName: "hoody", Name: "size", Value: "M"},
+		{ItemName: "hoody", Name: "size", Value: "S"},
+	}, nil)
+	mockVariantRepo.On("GetSKUs", "hoody").Return([]models.SKU{{Code: "hoody-s", Stock: 3}}, nil)
+
+	variants, err := uc.GetVariants("hoody")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"M", "S"}, variants.Options["size"])
+	assert.Len(t, variants.SKUs, 1)
+}
+
+func TestCreateSKU_Success(t *testing.T) {
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewVariantUseCase(mockVariantRepo, mockStoreRepo, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300}, nil)
+	mockVariantRepo.On("GetItemOptions", "hoody").Return([]models.ItemOption{
+		{ItemName: "hoody", Name: "size", Value: "XL"},
+	}, nil)
+	mockVariantRepo.On("CreateSKU", mock.MatchedBy(func(sku *models.SKU) bool {
+		return sku.Code == "hoody-xl" && sku.Stock == 10 && len(sku.Options) == 1
+	})).Return(nil)
+
+	sku, err := uc.CreateSKU("admin", "hoody", models.CreateSKURequest{
+		Code:    "hoody-xl",
+		Options: map[string]string{"size": "XL"},
+		Stock:   10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "size: XL", sku.Description())
+	mockVariantRepo.AssertExpectations(t)
+}
+
+func TestCreateSKU_UnknownOptionValue(t *testing.T) {
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewVariantUseCase(mockVariantRepo, mockStoreRepo, mockUserRepo, []string{"admin-ID"})
+
+	mockUserRepo.On("FindUserByUsername", "admin").Return(&models.User{ID: "admin-ID"}, nil)
+	mockStoreRepo.On("GetItemByName", "hoody").Return(&models.Product{Name: "hoody", Price: 300}, nil)
+	mockVariantRepo.On("GetItemOptions", "hoody").Return([]models.ItemOption{
+		{ItemName: "hoody", Name: "size", Value: "M"},
+	}, nil)
+
+	_, err := uc.CreateSKU("admin", "hoody", models.CreateSKURequest{
+		Code:    "hoody-xxl",
+		Options: map[string]string{"size": "XXL"},
+	})
+
+	assert.Error(t, err)
+	mockVariantRepo.AssertNotCalled(t, "CreateSKU", mock.Anything)
+}
+
+func TestAdjustStock_NotStaff(t *testing.T) {
+	mockVariantRepo := new(mockRepo.MockVariantRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewVariantUseCase(mockVariantRepo, nil, mockUserRepo, []string{"admin-ID"})
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Role: models.RoleEmployee}, nil)
+
+	err := uc.AdjustStock("user1", "hoody-s", 5)
+
+	assert.Equal(t, "недостаточно прав", err.Error())
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"avito-shop-test/internal/models"
+)
+
+const maxCampaignDays = 30
+
+type wishlistUseCase struct {
+	wishlistRepo WishlistRepository
+	userRepo     UserRepository
+	storeRepo    StoreRepository
+	variantRepo  VariantRepository
+	pricingRepo  PricingRepository
+	purchaseRepo PurchaseRepository
+	listeners    []ActivityListener
+}
+
+func NewWishlistUseCase(wishlistRepo WishlistRepository, userRepo UserRepository, storeRepo StoreRepository, variantRepo VariantRepository, pricingRepo PricingRepository, purchaseRepo PurchaseRepository, listeners ...ActivityListener) WishlistUseCase {
+	return &wishlistUseCase{
+		wishlistRepo: wishlistRepo,
+		userRepo:     userRepo,
+		storeRepo:    storeRepo,
+		variantRepo:  variantRepo,
+		pricingRepo:  pricingRepo,
+		purchaseRepo: purchaseRepo,
+		listeners:    listeners,
+	}
+}
+
+func (uc *wishlistUseCase) GetWishlist(username string) (*models.WishlistView, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	wishlist, err := uc.wishlistRepo.GetWishlist(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		token, err := newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.wishlistRepo.CreateWishlist(&models.Wishlist{UserID: user.ID, ShareToken: token}); err != nil {
+			return nil, err
+		}
+		if wishlist, err = uc.wishlistRepo.GetWishlist(user.ID); err != nil || wishlist == nil {
+			return nil, errors.New("не удалось создать список желаний")
+		}
+	}
+
+	items, err := uc.wishlistRepo.GetItems(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WishlistView{Owner: user.Username, ShareToken: wishlist.ShareToken, Items: items}, nil
+}
+
+func (uc *wishlistUseCase) GetSharedWishlist(token string) (*models.WishlistView, error) {
+	wishlist, err := uc.wishlistRepo.GetWishlistByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.New("список желаний не найден")
+	}
+
+	owner, err := uc.userRepo.GetUserByUserID(wishlist.UserID)
+	if err != nil || owner == nil {
+		return nil, errors.New("список желаний не найден")
+	}
+
+	items, err := uc.wishlistRepo.GetItems(wishlist.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WishlistView{Owner: owner.Username, Items: items}, nil
+}
+
+func (uc *wishlistUseCase) AddItem(username string, req models.AddWishlistItemRequest) (*models.WishlistItem, error) {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	product, err := uc.storeRepo.GetItemByName(req.Item)
+	if err != nil {
+		return nil, errors.New("товар не найден")
+	}
+	if req.SKU != "" {
+		sku, err := uc.variantRepo.GetSKUByCode(req.SKU)
+		if err != nil || sku == nil || sku.ItemName != product.Name {
+			return nil, errors.New("вариант товара не найден")
+		}
+	}
+
+	item := &models.WishlistItem{
+		UserID:   user.ID,
+		ItemName: product.Name,
+		SKU:      req.SKU,
+		Status:   models.WishlistStatusWished,
+	}
+	if err := uc.wishlistRepo.AddItem(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (uc *wishlistUseCase) RemoveItem(username, itemID string) error {
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return errors.New("пользователь не найден")
+	}
+
+	return uc.wishlistRepo.RemoveItem(itemID, user.ID)
+}
+
+func (uc *wishlistUseCase) StartCampaign(username, itemID string, days int) (*models.WishlistItem, error) {
+	if days <= 0 || days > maxCampaignDays {
+		return nil, errors.New("сбор можно открыть на срок от 1 до 30 дней")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	item, err := uc.wishlistRepo.GetItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || item.UserID != user.ID {
+		return nil, errors.New("желание не найдено")
+	}
+
+	now := time.Now()
+	quote, err := quotePurchase(uc.storeRepo, uc.variantRepo, uc.pricingRepo, user, models.BuyRequest{Item: item.ItemName, SKU: item.SKU}, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEligibility(uc.purchaseRepo, user, quote.product, now); err != nil {
+		return nil, err
+	}
+	if quote.price <= 0 {
+		return nil, errors.New("товар достаётся бесплатно, сбор не нужен")
+	}
+
+	expiresAt := now.AddDate(0, 0, days)
+	if err := uc.wishlistRepo.StartCampaign(item.ID, user.ID, quote.price, expiresAt); err != nil {
+		return nil, err
+	}
+
+	item.Status = models.WishlistStatusFunding
+	item.Target = quote.price
+	item.Funded = 0
+	item.ExpiresAt = &expiresAt
+
+	return item, nil
+}
+
+func (uc *wishlistUseCase) Contribute(username, itemID string, amount int) (*models.WishlistItem, error) {
+	if amount <= 0 {
+		return nil, errors.New("сумма взноса должна быть положительной")
+	}
+
+	user, err := uc.userRepo.FindUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	item, err := uc.wishlistRepo.Contribute(itemID, user.ID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status == models.WishlistStatusFunded {
+		for _, listener := range uc.listeners {
+			listener.OnActivity(user.ID, item.UserID)
+		}
+	}
+	return item, nil
+}
+
+func (uc *wishlistUseCase) ExpireCampaigns() error {
+	expired, err := uc.wishlistRepo.ExpireCampaigns(time.Now())
+	if expired > 0 {
+		log.Printf("Закрыто просроченных сборов: %d", expired)
+	}
+	return err
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"avito-shop-test/internal/models"
+	mockRepo "avito-shop-test/internal/repository/mock"
+)
+
+func TestGetWishlist_CreatesShareToken(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockWishlistRepo.On("GetWishlist", "user-ID-1").Return(nil, nil).Once()
+	mockWishlistRepo.On("CreateWishlist", mock.MatchedBy(func(wishlist *models.Wishlist) bool {
+		return wishlist.UserID == "user-ID-1" && len(wishlist.ShareToken) == 32
+	})).Return(nil)
+	mockWishlistRepo.On("GetWishlist", "user-ID-1").Return(&models.Wishlist{UserID: "user-ID-1", ShareToken: "token"}, nil)
+	mockWishlistRepo.On("GetItems", "user-ID-1").Return([]models.WishlistItem{{ItemName: "pink-hoody"}}, nil)
+
+	wishlist, err := uc.GetWishlist("user1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token", wishlist.ShareToken)
+	assert.Len(t, wishlist.Items, 1)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestGetSharedWishlist_HidesToken(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockWishlistRepo.On("GetWishlistByToken", "token").Return(&models.Wishlist{UserID: "user-ID-1", ShareToken: "token"}, nil)
+	mockUserRepo.On("GetUserByUserID", "user-ID-1").Return(&models.User{ID: "user-ID-1", Username: "user1"}, nil)
+	mockWishlistRepo.On("GetItems", "user-ID-1").Return([]models.WishlistItem{}, nil)
+
+	wishlist, err := uc.GetSharedWishlist("token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", wishlist.Owner)
+	assert.Empty(t, wishlist.ShareToken)
+}
+
+func TestAddWishlistItem_UnknownItem(t *testing.T) {
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewWishlistUseCase(nil, mockUserRepo, mockStoreRepo, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockStoreRepo.On("GetItemByName", "yacht").Return(nil, assert.AnError)
+
+	_, err := uc.AddItem("user1", models.AddWishlistItemRequest{Item: "yacht"})
+
+	assert.EqualError(t, err, "товар не найден")
+}
+
+func TestStartCampaign_TargetsEffectivePrice(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockPricingRepo := newBasePricingRepo()
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, mockStoreRepo, nil, mockPricingRepo, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockWishlistRepo.On("GetItem", "wish-1").Return(&models.WishlistItem{ID: "wish-1", UserID: "user-ID-1", ItemName: "pink-hoody"}, nil)
+	mockStoreRepo.On("GetItemByName", "pink-hoody").Return(&models.Product{Name: "pink-hoody", Price: 500}, nil)
+	mockWishlistRepo.On("StartCampaign", "wish-1", "user-ID-1", 500, mock.Anything).Return(nil)
+
+	item, err := uc.StartCampaign("user1", "wish-1", 14)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.WishlistStatusFunding, item.Status)
+	assert.Equal(t, 500, item.Target)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestStartCampaign_FreeItem(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, mockStoreRepo, nil, newBasePricingRepo(), nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockWishlistRepo.On("GetItem", "wish-1").Return(&models.WishlistItem{ID: "wish-1", UserID: "user-ID-1", ItemName: "sticker"}, nil)
+	mockStoreRepo.On("GetItemByName", "sticker").Return(&models.Product{Name: "sticker", Price: 0}, nil)
+
+	_, err := uc.StartCampaign("user1", "wish-1", 14)
+
+	assert.EqualError(t, err, "товар достаётся бесплатно, сбор не нужен")
+	mockWishlistRepo.AssertNotCalled(t, "StartCampaign", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStartCampaign_OwnerOverLimit(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	mockStoreRepo := new(mockRepo.MockStoreRepository)
+	mockPurchaseRepo := new(mockRepo.MockPurchaseRepository)
+	mockPricingRepo := newBasePricingRepo()
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, mockStoreRepo, nil, mockPricingRepo, mockPurchaseRepo)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockWishlistRepo.On("GetItem", "wish-1").Return(&models.WishlistItem{ID: "wish-1", UserID: "user-ID-1", ItemName: "pink-hoody"}, nil)
+	mockStoreRepo.On("GetItemByName", "pink-hoody").Return(&models.Product{
+		Name:  "pink-hoody",
+		Price: 500,
+		Rule:  &models.ItemRule{ItemName: "pink-hoody", MaxPerUser: intPtr(1)},
+	}, nil)
+	mockPurchaseRepo.On("CountPurchases", "user-ID-1", "pink-hoody", mock.Anything).Return(1, nil)
+
+	_, err := uc.StartCampaign("user1", "wish-1", 14)
+
+	assert.EqualError(t, err, "можно купить не больше 1 шт. этого товара")
+	mockWishlistRepo.AssertNotCalled(t, "StartCampaign", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStartCampaign_InvalidDuration(t *testing.T) {
+	uc := NewWishlistUseCase(nil, nil, nil, nil, nil, nil)
+
+	_, err := uc.StartCampaign("user1", "wish-1", 90)
+
+	assert.Error(t, err)
+}
+
+func TestStartCampaign_ForeignItem(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user1").Return(&models.User{ID: "user-ID-1"}, nil)
+	mockWishlistRepo.On("GetItem", "wish-1").Return(&models.WishlistItem{ID: "wish-1", UserID: "user-ID-2"}, nil)
+
+	_, err := uc.StartCampaign("user1", "wish-1", 7)
+
+	assert.EqualError(t, err, "желание не найдено")
+}
+
+func TestContribute_Success(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, nil, nil, nil, nil)
+
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockWishlistRepo.On("Contribute", "wish-1", "user-ID-2", 100).Return(&models.WishlistItem{Funded: 100, Target: 500}, nil)
+
+	item, err := uc.Contribute("user2", "wish-1", 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, item.Funded)
+}
+
+func TestContribute_CompletionNotifiesActivityListeners(t *testing.T) {
+	mockWishlistRepo := new(mockRepo.MockWishlistRepository)
+	mockUserRepo := new(mockRepo.MockUserRepository)
+	listener := &recordingListener{}
+	uc := NewWishlistUseCase(mockWishlistRepo, mockUserRepo, nil, nil, nil, nil, listener)
+
+	mockUserRepo.On("FindUserByUsername", "user2").Return(&models.User{ID: "user-ID-2"}, nil)
+	mockWishlistRepo.On("Contribute", "wish-1", "user-ID-2", 100).
+		Return(&models.WishlistItem{UserID: "user-ID-1", Status: models.WishlistStatusFunded, Funded: 500, Target: 500}, nil)
+
+	_, err := uc.Contribute("user2", "wish-1", 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-ID-2", "user-ID-1"}, listener.userIDs)
+}
+
+func TestContribute_NonPositiveAmount(t *testing.T) {
+	uc := NewWishlistUseCase(nil, nil, nil, nil, nil, nil)
+
+	_, err := uc.Contribute("user2", "wish-1", 0)
+
+	assert.Error(t, err)
+}